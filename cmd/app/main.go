@@ -1,30 +1,43 @@
 package main
 
 import (
-	storage "API/internal/Storage"
 	"API/internal/Storage/postgres"
 	"API/internal/auth"
 	"API/internal/config"
-	authHandlers "API/internal/http-server/handlers/auth"
-	"API/internal/http-server/handlers/bookings"
-	"API/internal/http-server/handlers/events"
-	"API/internal/http-server/handlers/profile"
-	"API/internal/http-server/handlers/search"
-	"API/internal/http-server/handlers/url/save"
-	authMiddleware "API/internal/http-server/middleware/auth"
-	resp "API/internal/lib/api/response"
+	"API/internal/http-server/api"
+	v1 "API/internal/http-server/api/v1"
+	"API/internal/http-server/handlers/health"
+	"API/internal/http-server/handlers/redirect"
+	mwLogger "API/internal/http-server/middleware/logger"
+	mwMetrics "API/internal/http-server/middleware/metrics"
+	mwTracing "API/internal/http-server/middleware/tracing"
+	aliasgen "API/internal/lib/alias"
+	"API/internal/lib/clicks"
+	"API/internal/lib/holds"
 	"API/internal/lib/logger/sl"
+	"API/internal/lib/metrics"
+	"API/internal/lib/notify"
+	"API/internal/lib/savedsearch"
+	"API/internal/lib/sweeper"
+	"API/internal/lib/tracing"
+	"API/internal/lib/urlcache"
+	"API/internal/lib/urlpolicy"
+	"API/internal/lib/waitlist"
+	"context"
+	"encoding/base64"
 	"errors"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	_ "API/docs"
+	_ "API/docs/v1"
 
 	"golang.org/x/exp/slog"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
-	"github.com/go-chi/render"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
@@ -34,11 +47,6 @@ const (
 	envProd  = "prod"
 )
 
-// URLGetter is an interface for getting url by alias.
-type URLGetter interface {
-	GetURL(alias string) (string, error)
-}
-
 func main() {
 	cfg := config.MustLoad()
 	log := setupLogger(cfg.Env)
@@ -46,6 +54,12 @@ func main() {
 	log.Info("initializing server", slog.String("address", cfg.HTTPServer.Address))
 	log.Debug("logger debug mode enabled")
 
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Error("failed to initialize tracing", sl.Err(err))
+		os.Exit(1)
+	}
+
 	storage, err := postgres.New(
 		cfg.Database.Host,
 		cfg.Database.Port,
@@ -60,63 +74,123 @@ func main() {
 	}
 	defer storage.Close()
 
+	metrics.Registry.MustRegister(metrics.NewPoolCollector(storage.PoolStat))
+
+	// Переходы по коротким ссылкам пишутся в базу в фоне
+	clickRecorder := clicks.NewRecorder(log, storage, cfg.Analytics)
+	go clickRecorder.Run()
+
+	// Уведомления пока пишутся в лог; лимит защищает от потока писем,
+	// если автор создаст много мероприятий подряд
+	logNotifier := notify.NewLog(log)
+	notifier := notify.NewLimiter(logNotifier, cfg.Search.Saved.NotifyLimit, cfg.Search.Saved.NotifyWindow)
+	searchMatcher := savedsearch.NewMatcher(log, storage, notifier, cfg.Search.Saved.QueueSize)
+	go searchMatcher.Run()
+
+	// Предложение из листа ожидания ограничено сроком оплаты, поэтому
+	// его уведомление не должно упираться в лимит сохраненных поисков
+	waitlistOfferer := waitlist.NewOfferer(log, storage, logNotifier, cfg.Bookings.Waitlist.ClaimWindow)
+
 	jwtManager := auth.NewJWTManager(cfg.JWT.Secret, cfg.JWT.TokenTTL)
 
+	ticketSigner, err := auth.NewTicketSigner(cfg.Tickets.SigningKey)
+	if err != nil {
+		log.Error("invalid tickets signing key", sl.Err(err))
+		os.Exit(1)
+	}
+	// Публичный ключ нужен контролю на входе для офлайн проверки билетов
+	log.Info("ticket verification key", slog.String("public_key", base64.StdEncoding.EncodeToString(ticketSigner.PublicKey())))
+
+	aliasGenerator, err := aliasgen.NewGenerator(cfg.ShortLinks.Alias, api.IsReserved)
+	if err != nil {
+		log.Error("invalid alias generator config", sl.Err(err))
+		os.Exit(1)
+	}
+
+	urlPolicy, err := urlpolicy.Load(cfg.ShortLinks.Policy)
+	if err != nil {
+		log.Error("failed to load url policy", sl.Err(err))
+		os.Exit(1)
+	}
+
+	// Кэш редиректов перед хранилищем
+	var (
+		urlGetter   redirect.URLGetter = storage
+		urlCache    *urlcache.Cache
+		invalidator v1.URLCacheInvalidator
+	)
+	if cfg.ShortLinks.Cache.Enabled {
+		urlCache = urlcache.New(log, storage, nil, cfg.ShortLinks.Cache)
+		urlGetter = urlCache
+		invalidator = urlCache
+	}
+
+	// Инстанс готов принимать трафик только после старта сервера
+	healthStatus := health.NewStatus()
+
 	router := chi.NewRouter()
 	router.Use(middleware.RequestID)
-	router.Use(middleware.Logger)
+	router.Use(mwTracing.New())
+	router.Use(mwLogger.New(log))
+	router.Use(mwMetrics.New())
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.URLFormat)
 	router.Use(middleware.RedirectSlashes)
 
-	router.Get("/swagger/*", httpSwagger.Handler(
-		httpSwagger.URL("/swagger/doc.json"),
+	// Проверки для оркестратора
+	router.Get("/healthz", health.NewLiveness())
+	router.Get("/readyz", health.NewReadiness(log, healthStatus, cfg.Health.CheckTimeout,
+		health.Check{Name: "postgres", Check: storage.Ping},
+		health.Check{Name: "migrations", Check: storage.CheckSchema},
 	))
 
-	router.Route("/auth", func(r chi.Router) {
-		r.Post("/register", authHandlers.NewRegister(log, storage, jwtManager))
-		r.Post("/login", authHandlers.NewLogin(log, storage, jwtManager))
-	})
-
-	router.Route("/events", func(r chi.Router) {
-		r.Use(authMiddleware.JWTAuth(log, jwtManager))
-		r.Post("/", events.NewCreate(log, storage))
-		r.Get("/", events.NewGetAll(log, storage))
-		r.Get("/{id}", events.NewGetByID(log, storage))
-		// Бронирование на мероприятие
-		r.Post("/{id}/book", bookings.NewCreate(log, storage))
-	})
+	// Документация отдельно для каждой версии API
+	router.Get("/swagger", http.RedirectHandler("/swagger/"+v1.Version+"/index.html", http.StatusFound).ServeHTTP)
+	router.Get("/swagger/"+v1.Version+"/*", httpSwagger.Handler(
+		httpSwagger.URL("/swagger/"+v1.Version+"/doc.json"),
+		httpSwagger.InstanceName(v1.Version),
+	))
 
-	router.Route("/profile", func(r chi.Router) {
-		r.Use(authMiddleware.JWTAuth(log, jwtManager))
-		r.Get("/", profile.NewGet(log, storage))
-		r.Put("/", profile.NewUpdate(log, storage))
-		r.Post("/balance", profile.NewTopUpBalance(log, storage))
-	})
+	// Версионированное JSON API. Следующая версия монтируется рядом
+	// под своим префиксом, не затрагивая клиентов v1.
+	router.Mount(api.Prefix+"/"+v1.Version, v1.NewRouter(v1.Deps{
+		Log:              log,
+		Storage:          storage,
+		JWTManager:       jwtManager,
+		Aliases:          aliasGenerator,
+		Policy:           urlPolicy,
+		Tickets:          ticketSigner,
+		URLCache:         invalidator,
+		Matcher:          searchMatcher,
+		Offerer:          waitlistOfferer,
+		ShortLinkBase:    cfg.ShortLinks.BaseURL,
+		MaxBatchSize:     cfg.ShortLinks.MaxBatchSize,
+		SuggestTimeout:   cfg.Search.SuggestTimeout,
+		MaxSavedSearches: cfg.Search.Saved.MaxPerUser,
+		HoldTTL:          cfg.Bookings.HoldTTL,
+	}))
 
-	router.Route("/bookings", func(r chi.Router) {
-		r.Use(authMiddleware.JWTAuth(log, jwtManager))
-		r.Get("/", bookings.NewList(log, storage))
-		r.Delete("/{id}", bookings.NewCancel(log, storage))
-	})
-
-	router.Route("/search", func(r chi.Router) {
-		r.Use(authMiddleware.JWTAuth(log, jwtManager))
-		r.Get("/", search.NewSearch(log, storage))
-	})
+	// Временные редиректы со старых путей без версии
+	if cfg.HTTPServer.LegacyRedirects.Enabled {
+		sunset, err := cfg.HTTPServer.LegacyRedirects.SunsetTime()
+		if err != nil {
+			log.Error("invalid legacy redirects sunset date", sl.Err(err))
+			os.Exit(1)
+		}
 
-	// Роуты с JWT аутентификацией для URL
-	router.Route("/url", func(r chi.Router) {
-		r.Use(authMiddleware.JWTAuth(log, jwtManager))
-		r.Post("/", save.New(log, storage))
-	})
+		legacy := api.NewLegacyRedirect(v1.Version, sunset)
+		for _, prefix := range api.LegacyPrefixes {
+			router.Handle(prefix, legacy)
+			router.Handle(prefix+"/*", legacy)
+		}
+	}
 
 	// Публичный роут для редиректа
-	router.Get("/{alias}", redirectHandler(log, storage))
+	router.Get("/{alias}", redirect.New(log, urlGetter, clickRecorder, urlPolicy))
 
 	// Запуск сервера
 	log.Info("starting server", slog.String("address", cfg.HTTPServer.Address))
-	log.Info("swagger UI available at", slog.String("url", "http://"+cfg.HTTPServer.Address+"/swagger/index.html"))
+	log.Info("swagger UI available at", slog.String("url", "http://"+cfg.HTTPServer.Address+"/swagger/"+v1.Version+"/index.html"))
 
 	srv := &http.Server{
 		Addr:         cfg.HTTPServer.Address,
@@ -126,10 +200,93 @@ func main() {
 		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
 	}
 
-	if err := srv.ListenAndServe(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Истекшие ссылки удаляются не сразу, чтобы редирект успел отдать 410
+	urlSweeper := sweeper.New(log, "expired_urls", cfg.ShortLinks.SweepInterval, func(ctx context.Context) (int64, error) {
+		return storage.DeleteExpiredURLs(ctx, time.Now().Add(-cfg.ShortLinks.ExpiredRetention))
+	})
+	go urlSweeper.Run(ctx)
+
+	holdSweeper := sweeper.New(log, "expired_holds", cfg.Bookings.HoldSweepInterval, holds.NewReleaser(storage).Release)
+	go holdSweeper.Run(ctx)
+
+	go sweeper.New(log, "waitlist_offers", cfg.Bookings.Waitlist.OfferInterval, waitlistOfferer.Offer).Run(ctx)
+
+	if urlCache != nil && cfg.ShortLinks.Cache.StatsInterval > 0 {
+		go urlCache.LogStats(ctx, cfg.ShortLinks.Cache.StatsInterval)
+	}
+
+	// Служебный listener с метриками, наружу не публикуется
+	adminRouter := chi.NewRouter()
+	adminRouter.Handle("/metrics", metrics.Handler())
+
+	adminSrv := &http.Server{
+		Addr:         cfg.Admin.Address,
+		Handler:      adminRouter,
+		ReadTimeout:  cfg.HTTPServer.Timeout,
+		WriteTimeout: cfg.HTTPServer.Timeout,
+	}
+
+	log.Info("starting admin server", slog.String("address", cfg.Admin.Address))
+
+	serverErr := make(chan error, 2)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+	go func() {
+		if err := adminSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	healthStatus.SetReady(true)
+
+	select {
+	case err := <-serverErr:
 		log.Error("failed to start server", sl.Err(err))
 		os.Exit(1)
+	case <-ctx.Done():
+	}
+
+	// Сначала снимаем готовность, чтобы оркестратор перестал слать трафик,
+	// и только потом дожидаемся завершения активных запросов
+	log.Info("shutting down server")
+	healthStatus.SetReady(false)
+	time.Sleep(cfg.Health.DrainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTPServer.ShutdownTimeout)
+	defer cancel()
+
+	// Ошибка остановки сервера не отменяет запись буферов: переходы и трейсы,
+	// уже принятые в очередь, иначе будут потеряны
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Error("failed to shutdown server", sl.Err(err))
+	}
+	if err := adminSrv.Shutdown(shutdownCtx); err != nil {
+		log.Error("failed to shutdown admin server", sl.Err(err))
+	}
+
+	// Если Shutdown исчерпал таймаут, у записи буферов должно остаться свое время
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), cfg.HTTPServer.ShutdownTimeout)
+	defer cancelFlush()
+
+	// Редиректы больше не приходят, дописываем оставшиеся переходы
+	if err := clickRecorder.Close(flushCtx); err != nil {
+		log.Error("failed to flush clicks", sl.Err(err))
+	}
+	if err := searchMatcher.Close(flushCtx); err != nil {
+		log.Error("failed to finish saved search matching", sl.Err(err))
 	}
+	if err := shutdownTracing(flushCtx); err != nil {
+		log.Error("failed to flush traces", sl.Err(err))
+		return
+	}
+
+	log.Info("server stopped")
 }
 
 func setupLogger(env string) *slog.Logger {
@@ -146,39 +303,3 @@ func setupLogger(env string) *slog.Logger {
 	}
 	return log
 }
-
-// redirectHandler обрабатывает редирект по алиасу
-func redirectHandler(log *slog.Logger, urlGetter URLGetter) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		const op = "handlers.url.redirect"
-
-		log = log.With(
-			slog.String("op", op),
-			slog.String("request_id", middleware.GetReqID(r.Context())),
-		)
-
-		alias := chi.URLParam(r, "alias")
-		if alias == "" {
-			log.Error("alias is empty")
-			render.JSON(w, r, resp.Error("alias is empty"))
-			return
-		}
-
-		resURL, err := urlGetter.GetURL(alias)
-		if errors.Is(err, storage.ErrURLNotFound) {
-			log.Error("url not found", slog.String("alias", alias))
-			w.WriteHeader(http.StatusNotFound)
-			render.JSON(w, r, resp.Error("url not found"))
-			return
-		}
-		if err != nil {
-			log.Error("failed to get URL", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("internal error"))
-			return
-		}
-
-		log.Info("redirecting", slog.String("alias", alias), slog.String("url", resURL))
-		http.Redirect(w, r, resURL, http.StatusFound)
-	}
-}
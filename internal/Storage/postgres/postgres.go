@@ -2,19 +2,27 @@ package postgres
 
 import (
 	storage "API/internal/Storage"
+	"API/internal/lib/geo"
 	"API/internal/models"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// SchemaVersion версия схемы БД, которую ожидает приложение (см. MIGRATIONS.md)
+const SchemaVersion = 19
+
 // Storage представляет PostgreSQL хранилище
 type Storage struct {
 	pool *pgxpool.Pool
@@ -38,6 +46,7 @@ func New(host string, port int, user, password, dbname, sslmode string) (*Storag
 	config.MinConns = 5
 	config.MaxConnLifetime = time.Hour
 	config.MaxConnIdleTime = 30 * time.Minute
+	config.ConnConfig.Tracer = queryTracer{}
 
 	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
@@ -56,17 +65,71 @@ func (s *Storage) Close() {
 	s.pool.Close()
 }
 
+// PoolStat возвращает статистику пула соединений для метрик
+func (s *Storage) PoolStat() *pgxpool.Stat {
+	return s.pool.Stat()
+}
+
+// Ping проверяет доступность базы данных
+func (s *Storage) Ping(ctx context.Context) error {
+	const op = "storage.postgres.Ping"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	if err := s.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MigrationVersion возвращает номер последней примененной миграции
+func (s *Storage) MigrationVersion(ctx context.Context) (int, error) {
+	const op = "storage.postgres.MigrationVersion"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	var version int
+	err := s.pool.QueryRow(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return version, nil
+}
+
+// CheckSchema проверяет, что все ожидаемые миграции применены
+func (s *Storage) CheckSchema(ctx context.Context) error {
+	const op = "storage.postgres.CheckSchema"
+
+	version, err := s.MigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if version < SchemaVersion {
+		return fmt.Errorf("%s: %w: have %d, want %d", op, storage.ErrSchemaOutdated, version, SchemaVersion)
+	}
+
+	return nil
+}
+
 // ==================== URL Methods ====================
 
-// SaveURL сохраняет URL с алиасом
-func (s *Storage) SaveURL(urlToSave string, alias string) (int64, error) {
+// SaveURL сохраняет URL с алиасом от имени пользователя.
+// expiresAt и maxClicks необязательны: nil означает отсутствие ограничения.
+func (s *Storage) SaveURL(ctx context.Context, urlToSave string, alias string, userID int64, expiresAt *time.Time, maxClicks *int) (int64, error) {
 	const op = "storage.postgres.SaveURL"
 
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
 	var id int64
 	err := s.pool.QueryRow(
-		context.Background(),
-		`INSERT INTO url(url, alias) VALUES($1, $2) RETURNING id`,
-		urlToSave, alias,
+		ctx,
+		`INSERT INTO url(url, alias, user_id, expires_at, max_clicks) VALUES($1, $2, $3, $4, $5) RETURNING id`,
+		urlToSave, alias, userID, expiresAt, maxClicks,
 	).Scan(&id)
 
 	if err != nil {
@@ -80,36 +143,392 @@ func (s *Storage) SaveURL(urlToSave string, alias string) (int64, error) {
 	return id, nil
 }
 
-// GetURL возвращает URL по алиасу
-func (s *Storage) GetURL(alias string) (string, error) {
+// SaveURLs сохраняет пакет ссылок пользователя в одной транзакции. Каждая
+// вставка идет в своей точке сохранения, поэтому занятый алиас отмечается
+// в результате элемента и не прерывает остальные. При atomic любой занятый
+// алиас откатывает весь пакет и возвращается storage.ErrBatchRejected.
+func (s *Storage) SaveURLs(ctx context.Context, userID int64, urls []models.NewURL, atomic bool) ([]models.SavedURL, error) {
+	const op = "storage.postgres.SaveURLs"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: begin tx: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	results := make([]models.SavedURL, len(urls))
+	failed := false
+
+	for i, u := range urls {
+		// вложенная транзакция pgx - это SAVEPOINT
+		sp, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("%s: savepoint: %w", op, err)
+		}
+
+		err = sp.QueryRow(
+			ctx,
+			`INSERT INTO url(url, alias, user_id, expires_at, max_clicks) VALUES($1, $2, $3, $4, $5) RETURNING id`,
+			u.URL, u.Alias, userID, u.ExpiresAt, u.MaxClicks,
+		).Scan(&results[i].ID)
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			if err := sp.Rollback(ctx); err != nil {
+				return nil, fmt.Errorf("%s: rollback to savepoint: %w", op, err)
+			}
+			results[i] = models.SavedURL{Err: storage.ErrURLExists}
+			failed = true
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: insert %q: %w", op, u.Alias, err)
+		}
+
+		if err := sp.Commit(ctx); err != nil {
+			return nil, fmt.Errorf("%s: release savepoint: %w", op, err)
+		}
+	}
+
+	if atomic && failed {
+		return results, fmt.Errorf("%s: %w", op, storage.ErrBatchRejected)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// GetURL возвращает ссылку по алиасу. Для истекшей или исчерпавшей лимит
+// ссылки возвращает storage.ErrURLExpired. Переход не списывает: это делает
+// CountClick, когда редирект действительно выполняется.
+func (s *Storage) GetURL(ctx context.Context, alias string) (*models.URL, error) {
 	const op = "storage.postgres.GetURL"
 
-	var resURL string
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	u, err := s.ResolveURL(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Expired(time.Now()) {
+		return nil, storage.ErrURLExpired
+	}
+
+	return u, nil
+}
+
+// ResolveURL возвращает ссылку по алиасу без проверки срока и лимита
+func (s *Storage) ResolveURL(ctx context.Context, alias string) (*models.URL, error) {
+	const op = "storage.postgres.ResolveURL"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	var u models.URL
 	err := s.pool.QueryRow(
-		context.Background(),
-		`SELECT url FROM url WHERE alias = $1`,
+		ctx,
+		`SELECT id, alias, url, user_id, expires_at, max_clicks, click_count, created_at FROM url WHERE alias = $1`,
 		alias,
-	).Scan(&resURL)
+	).Scan(&u.ID, &u.Alias, &u.URL, &u.UserID, &u.ExpiresAt, &u.MaxClicks, &u.ClickCount, &u.CreatedAt)
 
 	if errors.Is(err, pgx.ErrNoRows) {
-		return "", storage.ErrURLNotFound
+		return nil, storage.ErrURLNotFound
 	}
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &u, nil
+}
+
+// CountClick списывает переход у ссылки с лимитом. Списание атомарное,
+// чтобы параллельные редиректы не превысили max_clicks. Последний переход
+// записывает exhausted_at, от которого считается хранение исчерпанной ссылки.
+func (s *Storage) CountClick(ctx context.Context, urlID int64) error {
+	const op = "storage.postgres.CountClick"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	result, err := s.pool.Exec(
+		ctx,
+		`UPDATE url SET click_count = click_count + 1,
+		        exhausted_at = CASE WHEN click_count + 1 >= max_clicks THEN NOW() END
+		 WHERE id = $1 AND click_count < max_clicks`,
+		urlID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return storage.ErrURLExpired
+	}
+
+	return nil
+}
+
+// GetURLsByUserID возвращает ссылки пользователя, новые первыми
+func (s *Storage) GetURLsByUserID(ctx context.Context, userID int64, page models.Page) ([]*models.URL, *models.Cursor, error) {
+	const op = "storage.postgres.GetURLsByUserID"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	query := `SELECT id, alias, url, user_id, expires_at, max_clicks, click_count, created_at
+		FROM url
+		WHERE user_id = $1`
+	args := []interface{}{userID}
+	if page.After != nil {
+		query += ` AND (created_at, id) < ($2, $3)`
+		args = append(args, page.After.Time, page.After.ID)
+	}
+	query += fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	args = append(args, page.Fetch(), page.SkipRows())
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var urls []*models.URL
+	for rows.Next() {
+		var u models.URL
+		if err := rows.Scan(&u.ID, &u.Alias, &u.URL, &u.UserID, &u.ExpiresAt, &u.MaxClicks, &u.ClickCount, &u.CreatedAt); err != nil {
+			return nil, nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		urls = append(urls, &u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	urls, next := models.NextPage(urls, page, func(u *models.URL) models.Cursor {
+		return models.NewestCursor(u.CreatedAt, u.ID)
+	})
+
+	return urls, next, nil
+}
+
+// UpdateURL меняет адрес ссылки. Чужая ссылка считается ненайденной,
+// чтобы не раскрывать, какие алиасы заняты другими пользователями.
+func (s *Storage) UpdateURL(ctx context.Context, alias string, userID int64, newURL string) (*models.URL, error) {
+	const op = "storage.postgres.UpdateURL"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	var u models.URL
+	err := s.pool.QueryRow(
+		ctx,
+		`UPDATE url SET url = $1
+		 WHERE alias = $2 AND user_id = $3
+		 RETURNING id, alias, url, user_id, expires_at, max_clicks, click_count, created_at`,
+		newURL, alias, userID,
+	).Scan(&u.ID, &u.Alias, &u.URL, &u.UserID, &u.ExpiresAt, &u.MaxClicks, &u.ClickCount, &u.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrURLNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &u, nil
+}
+
+// DeleteURL удаляет ссылку владельца
+func (s *Storage) DeleteURL(ctx context.Context, alias string, userID int64) error {
+	const op = "storage.postgres.DeleteURL"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	result, err := s.pool.Exec(
+		ctx,
+		`DELETE FROM url WHERE alias = $1 AND user_id = $2`,
+		alias, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return storage.ErrURLNotFound
+	}
+
+	return nil
+}
+
+// DeleteExpiredURLs удаляет ссылки, срок действия которых истек или лимит
+// переходов исчерпан раньше before. Вместе со ссылками каскадно удаляется их статистика.
+func (s *Storage) DeleteExpiredURLs(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgres.DeleteExpiredURLs"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	result, err := s.pool.Exec(ctx, `DELETE FROM url WHERE expires_at < $1 OR exhausted_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// ==================== Click Methods ====================
+
+// statsTopN сколько значений отдавать в разрезах статистики
+const statsTopN = 10
+
+// SaveClicks записывает батч переходов. Переходы по ссылкам, удаленным
+// до записи батча, пропускаются.
+func (s *Storage) SaveClicks(ctx context.Context, clicks []models.Click) error {
+	const op = "storage.postgres.SaveClicks"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	batch := &pgx.Batch{}
+	for _, c := range clicks {
+		batch.Queue(
+			`INSERT INTO url_clicks(url_id, clicked_at, referrer, user_agent, region)
+			 SELECT id, $2, $3, $4, $5 FROM url WHERE alias = $1`,
+			c.Alias, c.ClickedAt, c.Referrer, c.UserAgent, c.Region,
+		)
+	}
+
+	if err := s.pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetURLStats возвращает статистику переходов по ссылке владельца за [from, to)
+func (s *Storage) GetURLStats(ctx context.Context, alias string, userID int64, from, to time.Time, bucket string) (*models.URLStats, error) {
+	const op = "storage.postgres.GetURLStats"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	var urlID int64
+	err := s.pool.QueryRow(ctx,
+		`SELECT id FROM url WHERE alias = $1 AND user_id = $2`,
+		alias, userID,
+	).Scan(&urlID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrURLNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stats := &models.URLStats{
+		Alias:  alias,
+		From:   from,
+		To:     to,
+		Bucket: bucket,
+	}
+
+	err = s.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM url_clicks WHERE url_id = $1 AND clicked_at >= $2 AND clicked_at < $3`,
+		urlID, from, to,
+	).Scan(&stats.Total)
+	if err != nil {
+		return nil, fmt.Errorf("%s: total: %w", op, err)
+	}
+
+	// Имена колонок фиксированы и не приходят из запроса
+	for column, dst := range map[string]*[]models.ClickCount{
+		"referrer":   &stats.Referrers,
+		"user_agent": &stats.UserAgents,
+		"region":     &stats.Regions,
+	} {
+		counts, err := s.countClicksBy(ctx, column, urlID, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", op, column, err)
+		}
+		*dst = counts
+	}
+
+	rows, err := s.pool.Query(ctx,
+		`SELECT date_trunc($1, clicked_at, 'UTC') AS bucket, COUNT(*)
+		 FROM url_clicks
+		 WHERE url_id = $2 AND clicked_at >= $3 AND clicked_at < $4
+		 GROUP BY bucket
+		 ORDER BY bucket`,
+		bucket, urlID, from, to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: series: %w", op, err)
+	}
+	defer rows.Close()
+
+	stats.Series = []models.ClickPoint{}
+	for rows.Next() {
+		var p models.ClickPoint
+		if err := rows.Scan(&p.Time, &p.Clicks); err != nil {
+			return nil, fmt.Errorf("%s: scan series: %w", op, err)
+		}
+		p.Time = p.Time.UTC()
+		stats.Series = append(stats.Series, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: series: %w", op, err)
+	}
+
+	return stats, nil
+}
+
+func (s *Storage) countClicksBy(ctx context.Context, column string, urlID int64, from, to time.Time) ([]models.ClickCount, error) {
+	rows, err := s.pool.Query(ctx,
+		fmt.Sprintf(
+			`SELECT %[1]s, COUNT(*) AS clicks
+			 FROM url_clicks
+			 WHERE url_id = $1 AND clicked_at >= $2 AND clicked_at < $3
+			 GROUP BY %[1]s
+			 ORDER BY clicks DESC, %[1]s
+			 LIMIT $4`, column),
+		urlID, from, to, statsTopN,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []models.ClickCount{}
+	for rows.Next() {
+		var c models.ClickCount
+		if err := rows.Scan(&c.Key, &c.Clicks); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
 	}
 
-	return resURL, nil
+	return counts, rows.Err()
 }
 
 // ==================== User Methods ====================
 
 // CreateUser создает нового пользователя
-func (s *Storage) CreateUser(email, name, passwordHash string) (*models.User, error) {
+func (s *Storage) CreateUser(ctx context.Context, email, name, passwordHash string) (*models.User, error) {
 	const op = "storage.postgres.CreateUser"
 
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
 	var user models.User
 	err := s.pool.QueryRow(
-		context.Background(),
+		ctx,
 		`INSERT INTO users(email, name, password_hash, balance, created_at, updated_at) 
 		 VALUES($1, $2, $3, 0, $4, $4) 
 		 RETURNING id, email, name, password_hash, phone, avatar_url, bio, balance, created_at, updated_at`,
@@ -132,12 +551,15 @@ func (s *Storage) CreateUser(email, name, passwordHash string) (*models.User, er
 }
 
 // GetUserByEmail возвращает пользователя по email
-func (s *Storage) GetUserByEmail(email string) (*models.User, error) {
+func (s *Storage) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	const op = "storage.postgres.GetUserByEmail"
 
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
 	var user models.User
 	err := s.pool.QueryRow(
-		context.Background(),
+		ctx,
 		`SELECT id, email, name, password_hash, phone, avatar_url, bio, balance, created_at, updated_at 
 		 FROM users WHERE email = $1`,
 		email,
@@ -158,12 +580,15 @@ func (s *Storage) GetUserByEmail(email string) (*models.User, error) {
 }
 
 // GetUserByID возвращает пользователя по ID
-func (s *Storage) GetUserByID(id int64) (*models.User, error) {
+func (s *Storage) GetUserByID(ctx context.Context, id int64) (*models.User, error) {
 	const op = "storage.postgres.GetUserByID"
 
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
 	var user models.User
 	err := s.pool.QueryRow(
-		context.Background(),
+		ctx,
 		`SELECT id, email, name, password_hash, phone, avatar_url, bio, balance, created_at, updated_at 
 		 FROM users WHERE id = $1`,
 		id,
@@ -184,12 +609,15 @@ func (s *Storage) GetUserByID(id int64) (*models.User, error) {
 }
 
 // UpdateUserProfile обновляет профиль пользователя
-func (s *Storage) UpdateUserProfile(userID int64, name string, phone, avatarURL, bio *string) (*models.User, error) {
+func (s *Storage) UpdateUserProfile(ctx context.Context, userID int64, name string, phone, avatarURL, bio *string) (*models.User, error) {
 	const op = "storage.postgres.UpdateUserProfile"
 
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
 	var user models.User
 	err := s.pool.QueryRow(
-		context.Background(),
+		ctx,
 		`UPDATE users SET name = $1, phone = $2, avatar_url = $3, bio = $4, updated_at = $5
 		 WHERE id = $6
 		 RETURNING id, email, name, password_hash, phone, avatar_url, bio, balance, created_at, updated_at`,
@@ -211,11 +639,14 @@ func (s *Storage) UpdateUserProfile(userID int64, name string, phone, avatarURL,
 }
 
 // UpdateUserBalance обновляет баланс пользователя
-func (s *Storage) UpdateUserBalance(userID int64, amount float64) error {
+func (s *Storage) UpdateUserBalance(ctx context.Context, userID int64, amount float64) error {
 	const op = "storage.postgres.UpdateUserBalance"
 
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
 	result, err := s.pool.Exec(
-		context.Background(),
+		ctx,
 		`UPDATE users SET balance = balance + $1, updated_at = $2 WHERE id = $3`,
 		amount, time.Now(), userID,
 	)
@@ -231,12 +662,15 @@ func (s *Storage) UpdateUserBalance(userID int64, amount float64) error {
 }
 
 // EmailExists проверяет существование email
-func (s *Storage) EmailExists(email string) (bool, error) {
+func (s *Storage) EmailExists(ctx context.Context, email string) (bool, error) {
 	const op = "storage.postgres.EmailExists"
 
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
 	var exists bool
 	err := s.pool.QueryRow(
-		context.Background(),
+		ctx,
 		`SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)`,
 		email,
 	).Scan(&exists)
@@ -248,290 +682,2146 @@ func (s *Storage) EmailExists(email string) (bool, error) {
 	return exists, nil
 }
 
-// ==================== Event Methods ====================
+// ==================== Venue Methods ====================
 
-// CreateEvent создает новое мероприятие
-func (s *Storage) CreateEvent(event *models.Event) (*models.Event, error) {
-	const op = "storage.postgres.CreateEvent"
+// venueColumns колонки площадки в порядке scanVenue
+const venueColumns = `id, name, address, capacity, latitude, longitude, creator_id, created_at`
 
-	err := s.pool.QueryRow(
-		context.Background(),
-		`INSERT INTO events(title, description, category, image_url, venue, address, price, capacity, available_tickets, start_time, end_time, creator_id, created_at, updated_at) 
-		 VALUES($1, $2, $3, $4, $5, $6, $7, $8, $8, $9, $10, $11, $12, $12) 
-		 RETURNING id, title, description, category, image_url, venue, address, price, capacity, available_tickets, start_time, end_time, creator_id, created_at, updated_at`,
-		event.Title, event.Description, event.Category, event.ImageURL, event.Venue, event.Address,
-		event.Price, event.Capacity, event.StartTime, event.EndTime, event.CreatorID, time.Now(),
-	).Scan(
-		&event.ID, &event.Title, &event.Description, &event.Category, &event.ImageURL,
-		&event.Venue, &event.Address, &event.Price, &event.Capacity, &event.AvailableTickets,
-		&event.StartTime, &event.EndTime, &event.CreatorID, &event.CreatedAt, &event.UpdatedAt,
-	)
+func scanVenue(row pgx.Row, venue *models.Venue, extra ...interface{}) error {
+	dest := []interface{}{
+		&venue.ID, &venue.Name, &venue.Address, &venue.Capacity,
+		&venue.Latitude, &venue.Longitude, &venue.CreatorID, &venue.CreatedAt,
+	}
+	return row.Scan(append(dest, extra...)...)
+}
+
+// CreateVenue создает площадку. Названия сравниваются нормализованными
+// (регистр, ё, кавычки и пунктуация не важны), дубликат дает ErrVenueExists.
+func (s *Storage) CreateVenue(ctx context.Context, venue *models.Venue) (*models.Venue, error) {
+	const op = "storage.postgres.CreateVenue"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
 
+	err := scanVenue(s.pool.QueryRow(ctx,
+		`INSERT INTO venues(name, address, capacity, latitude, longitude, creator_id)
+		 VALUES($1, $2, $3, $4, $5, $6)
+		 RETURNING `+venueColumns,
+		venue.Name, venue.Address, venue.Capacity, venue.Latitude, venue.Longitude, venue.CreatorID,
+	), venue)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, storage.ErrVenueExists
+		}
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return event, nil
+	return venue, nil
 }
 
-// GetEventByID возвращает мероприятие по ID
-func (s *Storage) GetEventByID(id int64) (*models.Event, error) {
-	const op = "storage.postgres.GetEventByID"
+// GetVenueByID возвращает площадку по ID
+func (s *Storage) GetVenueByID(ctx context.Context, id int64) (*models.Venue, error) {
+	const op = "storage.postgres.GetVenueByID"
 
-	var event models.Event
-	err := s.pool.QueryRow(
-		context.Background(),
-		`SELECT id, title, description, category, image_url, venue, address, price, capacity, available_tickets, start_time, end_time, creator_id, created_at, updated_at 
-		 FROM events WHERE id = $1`,
-		id,
-	).Scan(
-		&event.ID, &event.Title, &event.Description, &event.Category, &event.ImageURL,
-		&event.Venue, &event.Address, &event.Price, &event.Capacity, &event.AvailableTickets,
-		&event.StartTime, &event.EndTime, &event.CreatorID, &event.CreatedAt, &event.UpdatedAt,
-	)
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
 
+	var venue models.Venue
+	err := scanVenue(s.pool.QueryRow(ctx, `SELECT `+venueColumns+` FROM venues WHERE id = $1`, id), &venue)
 	if errors.Is(err, pgx.ErrNoRows) {
-		return nil, storage.ErrEventNotFound
+		return nil, storage.ErrVenueNotFound
 	}
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &event, nil
+	return &venue, nil
 }
 
-// GetAllEvents возвращает все мероприятия с пагинацией
-func (s *Storage) GetAllEvents(limit, offset int) ([]*models.Event, error) {
-	const op = "storage.postgres.GetAllEvents"
+// venueSimilarity сходство запроса с нормализованным названием площадки.
+// Нормализация убирает и символы % и _, поэтому запрос можно подставлять в LIKE.
+const venueSimilarity = `word_similarity(normalize_venue_name($1), normalized_name)::float8`
+
+// GetVenues возвращает площадки постранично: без запроса новые первыми,
+// с запросом — по сходству названия, в том числе с опечатками
+func (s *Storage) GetVenues(ctx context.Context, query string, page models.Page) ([]*models.Venue, *models.Cursor, error) {
+	const op = "storage.postgres.GetVenues"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	var sql string
+	var args []interface{}
+	if query == "" {
+		sql = `SELECT ` + venueColumns + `, 0::float8 FROM venues WHERE 1=1`
+		if page.After != nil {
+			sql += ` AND (created_at, id) < ($1, $2)`
+			args = append(args, page.After.Time, page.After.ID)
+		}
+		sql += ` ORDER BY created_at DESC, id DESC`
+	} else {
+		sql = `SELECT ` + venueColumns + `, ` + venueSimilarity + ` FROM venues
+			WHERE (normalized_name LIKE '%' || normalize_venue_name($1) || '%' OR normalize_venue_name($1) <% normalized_name)`
+		args = append(args, query)
+		if page.After != nil {
+			var key float64
+			if page.After.Key != nil {
+				key = *page.After.Key
+			}
+			sql += ` AND (` + venueSimilarity + ` < $2 OR (` + venueSimilarity + ` = $2 AND id > $3))`
+			args = append(args, key, page.After.ID)
+		}
+		sql += ` ORDER BY ` + venueSimilarity + ` DESC, id ASC`
+	}
+	sql += fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	args = append(args, page.Fetch(), page.SkipRows())
 
-	rows, err := s.pool.Query(
-		context.Background(),
-		`SELECT id, title, description, category, image_url, venue, address, price, capacity, available_tickets, start_time, end_time, creator_id, created_at, updated_at 
-		 FROM events 
-		 ORDER BY start_time ASC 
-		 LIMIT $1 OFFSET $2`,
-		limit, offset,
-	)
+	rows, err := s.pool.Query(ctx, sql, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
-	var events []*models.Event
+	var venues []*models.Venue
+	similarity := map[int64]float64{}
 	for rows.Next() {
-		var event models.Event
-		err := rows.Scan(
-			&event.ID, &event.Title, &event.Description, &event.Category, &event.ImageURL,
-			&event.Venue, &event.Address, &event.Price, &event.Capacity, &event.AvailableTickets,
-			&event.StartTime, &event.EndTime, &event.CreatorID, &event.CreatedAt, &event.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		var venue models.Venue
+		var sim float64
+		if err := scanVenue(rows, &venue, &sim); err != nil {
+			return nil, nil, fmt.Errorf("%s: scan: %w", op, err)
 		}
-		events = append(events, &event)
+		venues = append(venues, &venue)
+		similarity[venue.ID] = sim
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: rows: %w", op, err)
 	}
 
-	return events, rows.Err()
+	venues, next := models.NextPage(venues, page, func(v *models.Venue) models.Cursor {
+		if query == "" {
+			return models.NewestCursor(v.CreatedAt, v.ID)
+		}
+		key := similarity[v.ID]
+		return models.Cursor{Order: models.SortRelevance, Key: &key, ID: v.ID}
+	})
+
+	return venues, next, nil
 }
 
-// SearchEvents выполняет полнотекстовый поиск мероприятий
-func (s *Storage) SearchEvents(query string, category string, dateFrom, dateTo *time.Time, priceMin, priceMax *float64, limit, offset int) ([]*models.Event, int, error) {
-	const op = "storage.postgres.SearchEvents"
+// ==================== Seat Map Methods ====================
 
-	// Базовый запрос с условиями
-	baseQuery := `
-		FROM events 
-		WHERE 1=1
-	`
-	args := []interface{}{}
-	argNum := 1
+// CreateSeatMap создает схему зала площадки вместе с местами
+func (s *Storage) CreateSeatMap(ctx context.Context, seatMap *models.SeatMap) (*models.SeatMap, error) {
+	const op = "storage.postgres.CreateSeatMap"
 
-	// Полнотекстовый поиск
-	if query != "" {
-		baseQuery += fmt.Sprintf(` AND (
-			to_tsvector('russian', title || ' ' || COALESCE(description, '') || ' ' || venue || ' ' || COALESCE(address, '')) 
-			@@ plainto_tsquery('russian', $%d)
-			OR title ILIKE $%d
-			OR venue ILIKE $%d
-		)`, argNum, argNum+1, argNum+2)
-		args = append(args, query, "%"+query+"%", "%"+query+"%")
-		argNum += 3
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: begin tx: %w", op, err)
 	}
+	defer tx.Rollback(ctx)
 
-	// Фильтр по категории
-	if category != "" {
-		baseQuery += fmt.Sprintf(` AND category = $%d`, argNum)
-		args = append(args, category)
-		argNum++
+	err = tx.QueryRow(ctx,
+		`INSERT INTO seat_maps(venue_id, name, creator_id) VALUES($1, $2, $3) RETURNING id, created_at`,
+		seatMap.VenueID, seatMap.Name, seatMap.CreatorID,
+	).Scan(&seatMap.ID, &seatMap.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, storage.ErrVenueNotFound
+		}
+		return nil, fmt.Errorf("%s: insert map: %w", op, err)
 	}
 
-	// Фильтр по датам
-	if dateFrom != nil {
-		baseQuery += fmt.Sprintf(` AND start_time >= $%d`, argNum)
-		args = append(args, *dateFrom)
-		argNum++
+	// Места вставляются одним запросом из параллельных массивов
+	n := len(seatMap.Seats)
+	sections, rows, numbers, categories := make([]string, n), make([]string, n), make([]int32, n), make([]string, n)
+	for i, seat := range seatMap.Seats {
+		sections[i], rows[i], numbers[i], categories[i] = seat.Section, seat.Row, int32(seat.Number), seat.PriceCategory
 	}
-	if dateTo != nil {
-		baseQuery += fmt.Sprintf(` AND start_time <= $%d`, argNum)
-		args = append(args, *dateTo)
-		argNum++
+	_, err = tx.Exec(ctx,
+		`INSERT INTO seats(seat_map_id, section, row_name, number, price_category)
+		 SELECT $1::bigint, * FROM unnest($2::text[], $3::text[], $4::int[], $5::text[])`,
+		seatMap.ID, sections, rows, numbers, categories,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: insert seats: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	seatMap.SeatCount = n
+	seatMap.PriceCategories = nil
+	for _, category := range categories {
+		if !slices.Contains(seatMap.PriceCategories, category) {
+			seatMap.PriceCategories = append(seatMap.PriceCategories, category)
+		}
+	}
+	slices.Sort(seatMap.PriceCategories)
+
+	return seatMap, nil
+}
+
+// GetSeatMaps возвращает схемы зала площадки, новые первыми
+func (s *Storage) GetSeatMaps(ctx context.Context, venueID int64) ([]*models.SeatMap, error) {
+	const op = "storage.postgres.GetSeatMaps"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	rows, err := s.pool.Query(ctx,
+		`SELECT m.id, m.venue_id, m.name, m.creator_id, m.created_at,
+		        COUNT(s.id), array_agg(DISTINCT s.price_category ORDER BY s.price_category)
+		 FROM seat_maps m JOIN seats s ON s.seat_map_id = m.id
+		 WHERE m.venue_id = $1
+		 GROUP BY m.id
+		 ORDER BY m.created_at DESC, m.id DESC`,
+		venueID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	seatMaps := []*models.SeatMap{}
+	for rows.Next() {
+		var m models.SeatMap
+		err := rows.Scan(&m.ID, &m.VenueID, &m.Name, &m.CreatorID, &m.CreatedAt, &m.SeatCount, &m.PriceCategories)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		seatMaps = append(seatMaps, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	return seatMaps, nil
+}
+
+// GetEventSeats возвращает места мероприятия со схемой зала, их цены и
+// доступность. Для мероприятия без схемы возвращает ErrSeatMapNotFound.
+func (s *Storage) GetEventSeats(ctx context.Context, eventID int64) (*models.EventSeats, error) {
+	const op = "storage.postgres.GetEventSeats"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	var seatMapID *int64
+	var prices map[string]float64
+	err := s.pool.QueryRow(ctx,
+		`SELECT seat_map_id, seat_prices FROM events WHERE id = $1`, eventID,
+	).Scan(&seatMapID, &prices)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: get event: %w", op, err)
+	}
+	if seatMapID == nil {
+		return nil, storage.ErrSeatMapNotFound
+	}
+
+	rows, err := s.pool.Query(ctx,
+		`SELECT s.id, s.section, s.row_name, s.number, s.price_category, bs.seat_id IS NULL
+		 FROM seats s
+		 LEFT JOIN booking_seats bs ON bs.event_id = $1 AND bs.seat_id = s.id
+		 WHERE s.seat_map_id = $2
+		 ORDER BY s.id`,
+		eventID, *seatMapID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	result := &models.EventSeats{EventID: eventID, SeatMapID: *seatMapID, Seats: []models.EventSeat{}}
+	for rows.Next() {
+		var seat models.EventSeat
+		err := rows.Scan(&seat.ID, &seat.Section, &seat.Row, &seat.Number, &seat.PriceCategory, &seat.Available)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		seat.Price = prices[seat.PriceCategory]
+		if seat.Available {
+			result.Available++
+		}
+		result.Seats = append(result.Seats, seat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// ==================== Event Methods ====================
+
+// eventColumns колонки мероприятия в порядке scanEvent
+const eventColumns = `id, title, description, category, image_url, venue, address, price, capacity, available_tickets, start_time, end_time, creator_id, created_at, updated_at, language, latitude, longitude, venue_id, seat_map_id, seat_prices`
+
+// scanEvent читает строку с колонками eventColumns, за которыми могут идти extra
+func scanEvent(row pgx.Row, event *models.Event, extra ...interface{}) error {
+	dest := []interface{}{
+		&event.ID, &event.Title, &event.Description, &event.Category, &event.ImageURL,
+		&event.Venue, &event.Address, &event.Price, &event.Capacity, &event.AvailableTickets,
+		&event.StartTime, &event.EndTime, &event.CreatorID, &event.CreatedAt, &event.UpdatedAt,
+		&event.Language, &event.Latitude, &event.Longitude, &event.VenueID,
+		&event.SeatMapID, &event.SeatPrices,
+	}
+	return row.Scan(append(dest, extra...)...)
+}
+
+// CreateEvent создает новое мероприятие. Если задан VenueID, название
+// площадки берется из справочника, а адрес, координаты и вместимость —
+// из площадки, если их нет в мероприятии. Площадка, записанная только
+// названием, привязывается к справочнику по нормализованному названию.
+// Со схемой зала вместимость и цена считаются по ее местам.
+func (s *Storage) CreateEvent(ctx context.Context, event *models.Event) (*models.Event, error) {
+	const op = "storage.postgres.CreateEvent"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	// как DEFAULT колонки
+	if event.Language == "" {
+		event.Language = models.LanguageRussian
+	}
+
+	// nil map в pgx стал бы JSON null, а не NULL
+	var seatPrices interface{}
+	if event.SeatMapID != nil {
+		if err := s.applySeatMap(ctx, event); err != nil {
+			return nil, err
+		}
+		seatPrices = event.SeatPrices
+	}
+
+	query := `WITH v AS (SELECT id, name FROM venues WHERE normalized_name = normalize_venue_name($5))
+		 INSERT INTO events(title, description, category, image_url, venue_id, venue, address, price, capacity, available_tickets, start_time, end_time, creator_id, created_at, updated_at, language, latitude, longitude, seat_map_id, seat_prices) 
+		 VALUES($1, $2, $3, $4, (SELECT id FROM v), COALESCE((SELECT name FROM v), $5), $6, $7, $8, $8, $9, $10, $11, $12, $12, $13, $14, $15, $16, $17) 
+		 RETURNING ` + eventColumns
+	venue := interface{}(event.Venue)
+	if event.VenueID != nil {
+		// координаты площадки берутся только парой
+		query = `INSERT INTO events(title, description, category, image_url, venue_id, venue, address, price, capacity, available_tickets, start_time, end_time, creator_id, created_at, updated_at, language, latitude, longitude, seat_map_id, seat_prices)
+		 SELECT $1::text, $2::text, $3::text, $4::text, v.id, v.name, COALESCE(NULLIF($6::text, ''), v.address),
+		        $7::numeric, COALESCE(NULLIF($8::int, 0), v.capacity), COALESCE(NULLIF($8::int, 0), v.capacity),
+		        $9::timestamptz, $10::timestamptz, $11::bigint, $12::timestamptz, $12::timestamptz, $13::text,
+		        CASE WHEN $14::float8 IS NULL THEN v.latitude ELSE $14 END,
+		        CASE WHEN $14::float8 IS NULL THEN v.longitude ELSE $15::float8 END,
+		        $16::bigint, $17::jsonb
+		 FROM venues v WHERE v.id = $5
+		 RETURNING ` + eventColumns
+		venue = *event.VenueID
+	}
+
+	err := scanEvent(s.pool.QueryRow(
+		ctx, query,
+		event.Title, event.Description, event.Category, event.ImageURL, venue, event.Address,
+		event.Price, event.Capacity, event.StartTime, event.EndTime, event.CreatorID, time.Now(), event.Language,
+		event.Latitude, event.Longitude, event.SeatMapID, seatPrices,
+	), event)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrVenueNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return event, nil
+}
+
+// applySeatMap проверяет, что схема зала принадлежит площадке мероприятия
+// и у каждой ее категории есть цена, и выставляет вместимость по числу
+// мест и цену билета по самой дешевой категории. Схемы не меняются после
+// создания, поэтому проверка не требует транзакции с вставкой.
+func (s *Storage) applySeatMap(ctx context.Context, event *models.Event) error {
+	const op = "storage.postgres.applySeatMap"
+
+	var venueID int64
+	var seats int
+	var categories []string
+	err := s.pool.QueryRow(ctx,
+		`SELECT m.venue_id, COUNT(s.id), array_agg(DISTINCT s.price_category)
+		 FROM seat_maps m JOIN seats s ON s.seat_map_id = m.id
+		 WHERE m.id = $1
+		 GROUP BY m.venue_id`,
+		*event.SeatMapID,
+	).Scan(&venueID, &seats, &categories)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrSeatMapNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Схема чужой площадки для мероприятия не существует
+	if event.VenueID == nil || *event.VenueID != venueID {
+		return storage.ErrSeatMapNotFound
+	}
+
+	if len(event.SeatPrices) != len(categories) {
+		return storage.ErrSeatPrices
+	}
+	for i, category := range categories {
+		price, ok := event.SeatPrices[category]
+		if !ok {
+			return storage.ErrSeatPrices
+		}
+		if i == 0 || price < event.Price {
+			event.Price = price
+		}
+	}
+	event.Capacity = seats
+
+	return nil
+}
+
+// GetEventByID возвращает мероприятие по ID
+func (s *Storage) GetEventByID(ctx context.Context, id int64) (*models.Event, error) {
+	const op = "storage.postgres.GetEventByID"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	var event models.Event
+	err := scanEvent(s.pool.QueryRow(
+		ctx,
+		`SELECT `+eventColumns+` FROM events WHERE id = $1`,
+		id,
+	), &event)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &event, nil
+}
+
+// UpdateEventCapacity меняет вместимость мероприятия организатора creatorID.
+// Свободные билеты меняются на ту же разницу, проданные остаются за
+// покупателями, поэтому опустить вместимость ниже проданного нельзя. У
+// мероприятия со схемой зала вместимость задают места схемы.
+func (s *Storage) UpdateEventCapacity(ctx context.Context, eventID, creatorID int64, capacity int) (*models.Event, error) {
+	const op = "storage.postgres.UpdateEventCapacity"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	var event models.Event
+	err := scanEvent(s.pool.QueryRow(ctx,
+		`UPDATE events
+		 SET capacity = $3, available_tickets = available_tickets + ($3 - capacity), updated_at = $4
+		 WHERE id = $1 AND creator_id = $2 AND seat_map_id IS NULL AND capacity - available_tickets <= $3
+		 RETURNING `+eventColumns,
+		eventID, creatorID, capacity, time.Now(),
+	), &event)
+	if err == nil {
+		return &event, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var seated bool
+	err = s.pool.QueryRow(ctx,
+		`SELECT seat_map_id IS NOT NULL FROM events WHERE id = $1 AND creator_id = $2`,
+		eventID, creatorID,
+	).Scan(&seated)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: check event: %w", op, err)
+	}
+	if seated {
+		return nil, storage.ErrSeatedCapacity
+	}
+
+	return nil, storage.ErrCapacityBelowSold
+}
+
+// eventFilterWhere добавляет к where условия фильтра и их аргументы
+func eventFilterWhere(where string, args []interface{}, f models.EventFilter) (string, []interface{}) {
+	if len(f.Categories) > 0 {
+		args = append(args, f.Categories)
+		where += fmt.Sprintf(` AND category = ANY($%d)`, len(args))
+	}
+	if f.CreatorID != nil {
+		args = append(args, *f.CreatorID)
+		where += fmt.Sprintf(` AND creator_id = $%d`, len(args))
+	}
+	if f.VenueID != nil {
+		args = append(args, *f.VenueID)
+		where += fmt.Sprintf(` AND venue_id = $%d`, len(args))
+	}
+	if f.OnlyAvailable {
+		where += ` AND available_tickets > 0`
+	}
+
+	switch {
+	case f.IncludePast:
+	case f.Upcoming:
+		where += ` AND start_time > now()`
+	default:
+		where += ` AND end_time > now()`
+	}
+
+	return where, args
+}
+
+// GetAllEvents возвращает мероприятия по дате начала постранично
+func (s *Storage) GetAllEvents(ctx context.Context, filter models.EventFilter, page models.Page) ([]*models.Event, *models.Cursor, error) {
+	const op = "storage.postgres.GetAllEvents"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	where, args := eventFilterWhere(`WHERE 1=1`, []interface{}{}, filter)
+	if page.After != nil {
+		where += fmt.Sprintf(` AND (start_time, id) > ($%d, $%d)`, len(args)+1, len(args)+2)
+		args = append(args, page.After.Time, page.After.ID)
+	}
+	query := `SELECT ` + eventColumns + ` FROM events ` + where +
+		fmt.Sprintf(` ORDER BY start_time ASC, id ASC LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	args = append(args, page.Fetch(), page.SkipRows())
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var events []*models.Event
+	for rows.Next() {
+		var event models.Event
+		if err := scanEvent(rows, &event); err != nil {
+			return nil, nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	events, next := models.NextPage(events, page, func(e *models.Event) models.Cursor {
+		return models.Cursor{Order: models.SortDate, Time: e.StartTime, ID: e.ID}
+	})
+
+	return events, next, nil
+}
+
+// eventQuery поисковый запрос, разобранный всеми конфигурациями, по которым
+// строится events.search_vector: так запрос на русском или английском находит
+// стеммы, а на любом другом языке — точные слова. Запрос всегда первый аргумент.
+const eventQuery = `(plainto_tsquery('russian', $1) || plainto_tsquery('english', $1) || plainto_tsquery('simple', $1))`
+
+// eventSimilarity сходство запроса с названием или площадкой по триграммам,
+// для запросов с опечатками
+const eventSimilarity = `GREATEST(word_similarity($1, title), word_similarity($1, venue))`
+
+// Параметры ts_headline: название целиком, из описания до двух фрагментов
+var (
+	titleHeadline = fmt.Sprintf(`StartSel="%s", StopSel="%s", HighlightAll=true`,
+		models.HighlightStart, models.HighlightStop)
+	descriptionHeadline = fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" … "`,
+		models.HighlightStart, models.HighlightStop)
+)
+
+// eventSortKey числовой ключ сортировки поиска. После него мероприятия
+// всегда идут по start_time и id, что делает порядок однозначным и
+// позволяет продолжать выдачу с курсора.
+type eventSortKey struct {
+	expr string // пустой у сортировки только по дате
+	desc bool
+}
+
+// eventSortKeys ключи сортировок поиска. Ключи приводятся к float8,
+// чтобы значение из курсора сравнивалось с ними без потери точности.
+var eventSortKeys = map[string]eventSortKey{
+	models.SortDate:      {},
+	models.SortPriceAsc:  {expr: `price::float8`},
+	models.SortPriceDesc: {expr: `price::float8`, desc: true},
+	// поисковый запрос всегда первый аргумент, см. eventSearchWhere
+	models.SortRelevance:  {expr: `(ts_rank(search_vector, ` + eventQuery + `) + ` + eventSimilarity + `)::float8`, desc: true},
+	models.SortPopularity: {expr: `(capacity - available_tickets)::float8`, desc: true},
+	// выражение расстояния зависит от точки поиска, см. eventSearchWhere
+	models.SortDistance: {},
+}
+
+// orderBy ORDER BY для ключа
+func (k eventSortKey) orderBy() string {
+	if k.expr == "" {
+		return `start_time ASC, id ASC`
+	}
+	if k.desc {
+		return k.expr + ` DESC, start_time ASC, id ASC`
+	}
+	return k.expr + ` ASC, start_time ASC, id ASC`
+}
+
+// after добавляет условие "строго после курсора" в порядке ключа
+// и его аргументы: ключ (если он есть), время и id
+func (k eventSortKey) after(where string, args []interface{}, c *models.Cursor) (string, []interface{}) {
+	argNum := len(args) + 1
+
+	if k.expr == "" {
+		where += fmt.Sprintf(` AND (start_time, id) > ($%d, $%d)`, argNum, argNum+1)
+		return where, append(args, c.Time, c.ID)
+	}
+
+	var key float64
+	if c.Key != nil {
+		key = *c.Key
+	}
+
+	cmp := ">"
+	if k.desc {
+		cmp = "<"
+	}
+	where += fmt.Sprintf(` AND (%s %s $%d OR (%s = $%d AND (start_time, id) > ($%d, $%d)))`,
+		k.expr, cmp, argNum, k.expr, argNum, argNum+1, argNum+2)
+
+	return where, append(args, key, c.Time, c.ID)
+}
+
+// selectKey колонка с ключом для курсора
+func (k eventSortKey) selectKey() string {
+	if k.expr == "" {
+		return `0::float8`
+	}
+	return k.expr
+}
+
+// eventSearchWhere собирает условия поиска. Поисковый запрос, если есть,
+// всегда первый аргумент: на него ссылается сортировка по релевантности.
+// Если задана точка поиска, возвращает и выражение расстояния до нее в км.
+func eventSearchWhere(params models.EventSearch) (string, []interface{}, string) {
+	where := `WHERE 1=1`
+	args := []interface{}{}
+	argNum := 1
+
+	// Полнотекстовый поиск, подстрока и сходство по триграммам
+	// (<% использует индексы gin_trgm_ops)
+	if params.Query != "" {
+		where += fmt.Sprintf(` AND (
+			search_vector @@ `+eventQuery+`
+			OR title ILIKE $%d
+			OR venue ILIKE $%d
+			OR $1 <%% title
+			OR $1 <%% venue
+		)`, argNum+1, argNum+1)
+		args = append(args, params.Query, "%"+params.Query+"%")
+		argNum += 2
+	}
+
+	// Категории, автор, наличие билетов и время
+	where, args = eventFilterWhere(where, args, params.EventFilter)
+	argNum = len(args) + 1
+
+	// Фильтр по датам
+	if params.DateFrom != nil {
+		where += fmt.Sprintf(` AND start_time >= $%d`, argNum)
+		args = append(args, *params.DateFrom)
+		argNum++
+	}
+	if params.DateTo != nil {
+		where += fmt.Sprintf(` AND start_time <= $%d`, argNum)
+		args = append(args, *params.DateTo)
+		argNum++
 	}
 
 	// Фильтр по цене
-	if priceMin != nil {
-		baseQuery += fmt.Sprintf(` AND price >= $%d`, argNum)
-		args = append(args, *priceMin)
+	if params.PriceMin != nil {
+		where += fmt.Sprintf(` AND price >= $%d`, argNum)
+		args = append(args, *params.PriceMin)
 		argNum++
 	}
-	if priceMax != nil {
-		baseQuery += fmt.Sprintf(` AND price <= $%d`, argNum)
-		args = append(args, *priceMax)
+	if params.PriceMax != nil {
+		where += fmt.Sprintf(` AND price <= $%d`, argNum)
+		args = append(args, *params.PriceMax)
 		argNum++
 	}
 
-	// Получаем общее количество
-	var total int
-	countQuery := `SELECT COUNT(*) ` + baseQuery
-	err := s.pool.QueryRow(context.Background(), countQuery, args...).Scan(&total)
+	// Расстояние по формуле гаверсинусов, без PostGIS. Радиус сначала
+	// сужается прямоугольником по индексу idx_events_location.
+	var distance string
+	if params.Near != nil {
+		distance = fmt.Sprintf(`(%g * 2 * asin(LEAST(1, sqrt(
+			power(sin(radians(latitude - $%d) / 2), 2) +
+			cos(radians($%d)) * cos(radians(latitude)) * power(sin(radians(longitude - $%d) / 2), 2)))))`,
+			geo.EarthRadiusKm, argNum, argNum, argNum+1)
+		where += ` AND latitude IS NOT NULL AND longitude IS NOT NULL`
+		args = append(args, params.Near.Lat, params.Near.Lng)
+		argNum += 2
+
+		if params.RadiusKm != nil {
+			box := geo.BoundingBox(params.Near.Lat, params.Near.Lng, *params.RadiusKm)
+			lngCond := `longitude BETWEEN $%d AND $%d`
+			if box.CrossesAntimeridian() {
+				lngCond = `(longitude >= $%d OR longitude <= $%d)`
+			}
+			where += fmt.Sprintf(` AND latitude BETWEEN $%d AND $%d AND `+lngCond+` AND %s <= $%d`,
+				argNum, argNum+1, argNum+2, argNum+3, distance, argNum+4)
+			args = append(args, box.MinLat, box.MaxLat, box.MinLng, box.MaxLng, *params.RadiusKm)
+		}
+	}
+
+	return where, args, distance
+}
+
+// SearchEvents выполняет полнотекстовый поиск мероприятий. Вместе со страницей
+// возвращает фасеты по категориям, ценам и датам для тех же фильтров.
+func (s *Storage) SearchEvents(ctx context.Context, params models.EventSearch) (*models.EventSearchResult, error) {
+	const op = "storage.postgres.SearchEvents"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	where, args, distance := eventSearchWhere(params)
+
+	sort := params.Sort
+	if _, ok := eventSortKeys[sort]; !ok ||
+		(sort == models.SortRelevance && params.Query == "") ||
+		(sort == models.SortDistance && distance == "") {
+		sort = models.SortDate
+	}
+	key := eventSortKeys[sort]
+	if sort == models.SortDistance {
+		key = eventSortKey{expr: distance}
+	}
+
+	// Фасеты и total считаются по всем найденным, без учета курсора
+	facets, total, err := s.searchFacets(ctx, where, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: facets: %w", op, err)
+	}
+
+	if params.After != nil {
+		where, args = key.after(where, args, params.After)
+	}
+
+	// Фрагменты с совпадениями разбираются конфигурацией языка мероприятия
+	headlines := `'', ''`
+	if params.Query != "" {
+		argNum := len(args) + 1
+		headlines = fmt.Sprintf(`ts_headline(language::regconfig, title, %s, $%d),
+			ts_headline(language::regconfig, COALESCE(description, ''), %s, $%d)`,
+			eventQuery, argNum, eventQuery, argNum+1)
+		args = append(args, titleHeadline, descriptionHeadline)
+	}
+
+	distanceColumn := `NULL::float8`
+	if distance != "" {
+		distanceColumn = distance
+	}
+
+	// Получаем записи с пагинацией
+	argNum := len(args) + 1
+	selectQuery := `SELECT ` + eventColumns + `, ` + key.selectKey() + `, ` + headlines + `, ` + distanceColumn +
+		` FROM events ` + where +
+		fmt.Sprintf(` ORDER BY %s LIMIT $%d OFFSET $%d`, key.orderBy(), argNum, argNum+1)
+	args = append(args, params.Page.Fetch(), params.Page.SkipRows())
+
+	rows, err := s.pool.Query(ctx, selectQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: query: %w", op, err)
+	}
+	defer rows.Close()
+
+	var (
+		events     []*models.Event
+		keys       = map[int64]float64{}
+		highlights = map[int64]models.Highlight{}
+		distances  = map[int64]float64{}
+	)
+	for rows.Next() {
+		var (
+			event     models.Event
+			sortKey   float64
+			highlight models.Highlight
+			km        *float64
+		)
+		if err := scanEvent(rows, &event, &sortKey, &highlight.Title, &highlight.Description, &km); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		events = append(events, &event)
+		keys[event.ID] = sortKey
+		if params.Query != "" {
+			highlights[event.ID] = highlight
+		}
+		if km != nil {
+			distances[event.ID] = *km
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	events, next := models.NextPage(events, params.Page, func(e *models.Event) models.Cursor {
+		c := models.Cursor{Order: sort, Time: e.StartTime, ID: e.ID}
+		if key.expr != "" {
+			k := keys[e.ID]
+			c.Key = &k
+		}
+		return c
+	})
+
+	return &models.EventSearchResult{Events: events, Total: total, Facets: facets, Next: next, Highlights: highlights, Distances: distances}, nil
+}
+
+// searchFacets считает фасеты одним запросом через GROUPING SETS.
+// Сумма по датам дает общее число найденных мероприятий.
+func (s *Storage) searchFacets(ctx context.Context, where string, args []interface{}) (models.SearchFacets, int, error) {
+	dates, dateBounds := models.DateFacets(time.Now())
+	facets := models.SearchFacets{
+		Categories: []models.CategoryFacet{},
+		Prices:     models.PriceFacets(),
+		Dates:      dates,
+	}
+
+	argNum := len(args) + 1
+	query := fmt.Sprintf(`
+		SELECT category, price_bucket, date_bucket, COUNT(*)
+		FROM (
+			SELECT category,
+				width_bucket(price::float8, $%d::float8[]) AS price_bucket,
+				width_bucket(start_time, $%d::timestamptz[]) AS date_bucket
+			FROM events %s
+		) f
+		GROUP BY GROUPING SETS ((category), (price_bucket), (date_bucket))
+		ORDER BY category`, argNum, argNum+1, where)
+	args = append(args, models.PriceBounds, dateBounds)
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return facets, 0, err
+	}
+	defer rows.Close()
+
+	total := 0
+	for rows.Next() {
+		var (
+			category    *string
+			priceBucket *int
+			dateBucket  *int
+			count       int
+		)
+		if err := rows.Scan(&category, &priceBucket, &dateBucket, &count); err != nil {
+			return facets, 0, err
+		}
+
+		// width_bucket нумерует от 0 (меньше первой границы)
+		// до len(bounds) (не меньше последней), как и фасеты
+		switch {
+		case category != nil:
+			facets.Categories = append(facets.Categories, models.CategoryFacet{Category: *category, Count: count})
+		case priceBucket != nil:
+			facets.Prices[*priceBucket].Count = count
+		case dateBucket != nil:
+			facets.Dates[*dateBucket].Count = count
+			total += count
+		}
+	}
+
+	return facets, total, rows.Err()
+}
+
+// prefixQuery строит tsquery, где каждое слово запроса ищется как начало
+// слова: "вечер дж" -> "вечер:* & дж:*". Пустая строка, если слов нет.
+func prefixQuery(q string) string {
+	words := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+	})
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// SuggestEvents возвращает до limit подсказок каждого вида по началу слов
+// запроса среди предстоящих мероприятий. Названия и площадки ищутся по
+// индексам idx_events_title_words и idx_events_venue_words, все три вида
+// подсказок читаются одним запросом.
+func (s *Storage) SuggestEvents(ctx context.Context, prefix string, limit int) (*models.Suggestions, error) {
+	const op = "storage.postgres.SuggestEvents"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	suggestions := &models.Suggestions{
+		Titles:     []models.Suggestion{},
+		Venues:     []models.Suggestion{},
+		Categories: []models.Suggestion{},
+	}
+
+	query := prefixQuery(prefix)
+	categories := models.MatchCategories(prefix)
+	if query == "" && len(categories) == 0 {
+		return suggestions, nil
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		(SELECT 'title', title, COUNT(*), SUM(capacity - available_tickets) AS sold
+		 FROM events
+		 WHERE $1 <> '' AND to_tsvector('simple', title) @@ to_tsquery('simple', $1) AND end_time > now()
+		 GROUP BY title ORDER BY sold DESC, COUNT(*) DESC, title LIMIT $3)
+		UNION ALL
+		(SELECT 'venue', venue, COUNT(*), SUM(capacity - available_tickets) AS sold
+		 FROM events
+		 WHERE $1 <> '' AND to_tsvector('simple', venue) @@ to_tsquery('simple', $1) AND end_time > now()
+		 GROUP BY venue ORDER BY sold DESC, COUNT(*) DESC, venue LIMIT $3)
+		UNION ALL
+		(SELECT 'category', category, COUNT(*), SUM(capacity - available_tickets) AS sold
+		 FROM events
+		 WHERE category = ANY($2) AND end_time > now()
+		 GROUP BY category ORDER BY sold DESC, COUNT(*) DESC, category LIMIT $3)`,
+		query, categories, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			kind       string
+			suggestion models.Suggestion
+			sold       int
+		)
+		if err := rows.Scan(&kind, &suggestion.Text, &suggestion.Events, &sold); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+
+		switch kind {
+		case "title":
+			suggestions.Titles = append(suggestions.Titles, suggestion)
+		case "venue":
+			suggestions.Venues = append(suggestions.Venues, suggestion)
+		case "category":
+			suggestion.Label = models.CategoryLabel(suggestion.Text)
+			suggestions.Categories = append(suggestions.Categories, suggestion)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	return suggestions, nil
+}
+
+// ==================== Saved Search Methods ====================
+
+// savedSearchColumns колонки сохраненного поиска в порядке scanSavedSearch
+const savedSearchColumns = `id, user_id, name, query, params, created_at`
+
+func scanSavedSearch(row pgx.Row, search *models.SavedSearch) error {
+	return row.Scan(&search.ID, &search.UserID, &search.Name, &search.Query, &search.Params, &search.CreatedAt)
+}
+
+// SaveSearch сохраняет поиск пользователя, если у него меньше maxPerUser поисков
+func (s *Storage) SaveSearch(ctx context.Context, search *models.SavedSearch, maxPerUser int) (*models.SavedSearch, error) {
+	const op = "storage.postgres.SaveSearch"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: begin tx: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Блокировка пользователя не дает параллельным запросам превысить лимит
+	err = tx.QueryRow(ctx, `SELECT id FROM users WHERE id = $1 FOR UPDATE`, search.UserID).Scan(&search.UserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: lock user: %w", op, err)
+	}
+
+	var count int
+	err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM saved_searches WHERE user_id = $1`, search.UserID).Scan(&count)
+	if err != nil {
+		return nil, fmt.Errorf("%s: count: %w", op, err)
+	}
+	if count >= maxPerUser {
+		return nil, storage.ErrSavedSearchLimit
+	}
+
+	err = scanSavedSearch(tx.QueryRow(ctx,
+		`INSERT INTO saved_searches(user_id, name, query, params) VALUES($1, $2, $3, $4)
+		 RETURNING `+savedSearchColumns,
+		search.UserID, search.Name, search.Query, search.Params,
+	), search)
+	if err != nil {
+		return nil, fmt.Errorf("%s: insert: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	return search, nil
+}
+
+// GetSavedSearches возвращает сохраненные поиски пользователя, новые первыми
+func (s *Storage) GetSavedSearches(ctx context.Context, userID int64) ([]*models.SavedSearch, error) {
+	const op = "storage.postgres.GetSavedSearches"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+savedSearchColumns+` FROM saved_searches WHERE user_id = $1 ORDER BY created_at DESC, id DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	searches := []*models.SavedSearch{}
+	for rows.Next() {
+		var search models.SavedSearch
+		if err := scanSavedSearch(rows, &search); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		searches = append(searches, &search)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	return searches, nil
+}
+
+// DeleteSavedSearch удаляет сохраненный поиск пользователя
+func (s *Storage) DeleteSavedSearch(ctx context.Context, userID, id int64) error {
+	const op = "storage.postgres.DeleteSavedSearch"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tag, err := s.pool.Exec(ctx, `DELETE FROM saved_searches WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrSavedSearchNotFound
+	}
+
+	return nil
+}
+
+// SavedSearchesForEvent возвращает чужие для автора мероприятия сохраненные
+// поиски, которые не исключают его категорию
+func (s *Storage) SavedSearchesForEvent(ctx context.Context, event *models.Event) ([]models.SavedSearch, error) {
+	const op = "storage.postgres.SavedSearchesForEvent"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+savedSearchColumns+` FROM saved_searches
+		 WHERE user_id <> $1 AND (NOT params ? 'categories' OR params->'categories' ? $2)`,
+		event.CreatorID, event.Category,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var searches []models.SavedSearch
+	for rows.Next() {
+		var search models.SavedSearch
+		if err := scanSavedSearch(rows, &search); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		searches = append(searches, search)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	return searches, nil
+}
+
+// MatchEvent проверяет, находит ли поиск с params мероприятие eventID.
+// Условия те же, что в SearchEvents.
+func (s *Storage) MatchEvent(ctx context.Context, eventID int64, params models.EventSearch) (bool, error) {
+	const op = "storage.postgres.MatchEvent"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	where, args, _ := eventSearchWhere(params)
+	args = append(args, eventID)
+
+	var found bool
+	err := s.pool.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM events `+where+fmt.Sprintf(` AND id = $%d)`, len(args)),
+		args...,
+	).Scan(&found)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return found, nil
+}
+
+// ==================== Booking Methods ====================
+
+// generateBookingCode генерирует уникальный код бронирования
+func generateBookingCode() string {
+	bytes := make([]byte, 8)
+	rand.Read(bytes)
+	return "BK-" + hex.EncodeToString(bytes)
+}
+
+// bookingSeats места брони b в формате JSON, пустой массив без мест
+const bookingSeats = `COALESCE((
+	SELECT json_agg(json_build_object('id', s.id, 'section', s.section, 'row', s.row_name,
+	                                  'number', s.number, 'price_category', s.price_category) ORDER BY s.id)
+	FROM booking_seats bs JOIN seats s ON s.id = bs.seat_id
+	WHERE bs.booking_id = b.id), '[]')`
+
+// CreateBooking создает бронирование с транзакцией. На мероприятие
+// со схемой зала билеты продаются только с выбором мест.
+func (s *Storage) CreateBooking(ctx context.Context, userID, eventID int64, quantity int) (*models.Booking, error) {
+	return s.book(ctx, "storage.postgres.CreateBooking", userID, eventID, quantity, nil, nil)
+}
+
+// CreateSeatBooking бронирует места seatIDs на мероприятии со схемой зала.
+// Места занимаются вставкой в booking_seats с первичным ключом
+// (event_id, seat_id): если хотя бы одно место уже занято, транзакция
+// откатывается целиком и деньги не списываются.
+func (s *Storage) CreateSeatBooking(ctx context.Context, userID, eventID int64, seatIDs []int64) (*models.Booking, error) {
+	return s.book(ctx, "storage.postgres.CreateSeatBooking", userID, eventID, 0, seatIDs, nil)
+}
+
+// HoldTickets удерживает quantity билетов или места seatIDs до expiresAt,
+// не списывая деньги. Бронь создается со статусом pending; до expiresAt ее
+// нужно подтвердить через ConfirmBooking, иначе ReleaseExpiredHolds вернет
+// билеты в продажу.
+func (s *Storage) HoldTickets(ctx context.Context, userID, eventID int64, quantity int, seatIDs []int64, expiresAt time.Time) (*models.Booking, error) {
+	return s.book(ctx, "storage.postgres.HoldTickets", userID, eventID, quantity, seatIDs, &expiresAt)
+}
+
+// book резервирует билеты и создает бронь. Без holdUntil бронь сразу
+// оплачивается и подтверждается, с ним — только удерживается.
+func (s *Storage) book(ctx context.Context, op string, userID, eventID int64, quantity int, seatIDs []int64, holdUntil *time.Time) (*models.Booking, error) {
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: begin tx: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	res, err := reserveTickets(ctx, tx, eventID, quantity, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Вернувшиеся в продажу билеты сначала достаются листу ожидания
+	ahead, err := waitlistAhead(ctx, tx, userID, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: check waitlist: %w", op, err)
+	}
+	if ahead {
+		return nil, storage.ErrNoTickets
+	}
+
+	status := models.BookingStatusPending
+	if holdUntil == nil {
+		status = models.BookingStatusConfirmed
+		if err := chargeBalance(ctx, tx, userID, res.totalPrice); err != nil {
+			return nil, err
+		}
+	}
+
+	booking, err := insertBooking(ctx, tx, userID, eventID, res, status, holdUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(seatIDs) > 0 {
+		if err := takeSeats(ctx, tx, booking.ID, eventID, seatIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	booking.Seats = res.seats
+	booking.EventCategory = res.category
+
+	return booking, nil
+}
+
+// reservation билеты, которые бронь забирает у мероприятия
+type reservation struct {
+	category   string
+	quantity   int
+	totalPrice float64
+	seats      []models.Seat
+}
+
+// reserveTickets блокирует мероприятие и списывает из доступных quantity
+// билетов или, на мероприятии со схемой зала, места seatIDs. Сами места
+// закрепляет за бронью takeSeats.
+func reserveTickets(ctx context.Context, tx pgx.Tx, eventID int64, quantity int, seatIDs []int64) (*reservation, error) {
+	const op = "storage.postgres.reserveTickets"
+
+	var availableTickets int
+	var price float64
+	var seatMapID *int64
+	var prices map[string]float64
+	res := &reservation{}
+	err := tx.QueryRow(ctx,
+		`SELECT available_tickets, price, category, seat_map_id, seat_prices FROM events WHERE id = $1 FOR UPDATE`,
+		eventID,
+	).Scan(&availableTickets, &price, &res.category, &seatMapID, &prices)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: get event: %w", op, err)
+	}
+
+	if len(seatIDs) == 0 {
+		if seatMapID != nil {
+			return nil, storage.ErrSeatsRequired
+		}
+		if availableTickets < quantity {
+			return nil, storage.ErrNoTickets
+		}
+		res.quantity = quantity
+		res.totalPrice = price * float64(quantity)
+	} else {
+		if seatMapID == nil {
+			return nil, storage.ErrSeatNotFound
+		}
+		res.seats, err = seatsOfMap(ctx, tx, *seatMapID, seatIDs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: get seats: %w", op, err)
+		}
+		if len(res.seats) != len(seatIDs) {
+			return nil, storage.ErrSeatNotFound
+		}
+		res.quantity = len(res.seats)
+		for _, seat := range res.seats {
+			res.totalPrice += prices[seat.PriceCategory]
+		}
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE events SET available_tickets = available_tickets - $1 WHERE id = $2`, res.quantity, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: update tickets: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// seatsOfMap возвращает места seatIDs, которые есть в схеме зала
+func seatsOfMap(ctx context.Context, tx pgx.Tx, seatMapID int64, seatIDs []int64) ([]models.Seat, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT id, section, row_name, number, price_category FROM seats
+		 WHERE seat_map_id = $1 AND id = ANY($2)
+		 ORDER BY id`,
+		seatMapID, seatIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var seats []models.Seat
+	for rows.Next() {
+		var seat models.Seat
+		if err := rows.Scan(&seat.ID, &seat.Section, &seat.Row, &seat.Number, &seat.PriceCategory); err != nil {
+			return nil, err
+		}
+		seats = append(seats, seat)
+	}
+
+	return seats, rows.Err()
+}
+
+// takeSeats закрепляет места за бронью. Возвращает ErrSeatTaken, если
+// какое-то место уже занято на этом мероприятии.
+func takeSeats(ctx context.Context, tx pgx.Tx, bookingID, eventID int64, seatIDs []int64) error {
+	const op = "storage.postgres.takeSeats"
+
+	tag, err := tx.Exec(ctx,
+		`INSERT INTO booking_seats(booking_id, event_id, seat_id)
+		 SELECT $1, $2, unnest($3::bigint[])
+		 ON CONFLICT (event_id, seat_id) DO NOTHING`,
+		bookingID, eventID, seatIDs,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() != int64(len(seatIDs)) {
+		return storage.ErrSeatTaken
+	}
+
+	return nil
+}
+
+// chargeBalance списывает amount с баланса пользователя
+func chargeBalance(ctx context.Context, tx pgx.Tx, userID int64, amount float64) error {
+	const op = "storage.postgres.chargeBalance"
+
+	var balance float64
+	err := tx.QueryRow(ctx, `SELECT balance FROM users WHERE id = $1 FOR UPDATE`, userID).Scan(&balance)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return storage.ErrUserNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: get balance: %w", op, err)
+	}
+
+	if balance < amount {
+		return storage.ErrInsufficientBalance
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE users SET balance = balance - $1 WHERE id = $2`, amount, userID)
+	if err != nil {
+		return fmt.Errorf("%s: deduct balance: %w", op, err)
+	}
+
+	return nil
+}
+
+// insertBooking создает бронь на зарезервированные билеты. expiresAt
+// задается только удержанию со статусом pending.
+func insertBooking(ctx context.Context, tx pgx.Tx, userID, eventID int64, res *reservation, status models.BookingStatus, expiresAt *time.Time) (*models.Booking, error) {
+	const op = "storage.postgres.insertBooking"
+
+	bookingCode := generateBookingCode()
+	var booking models.Booking
+	err := tx.QueryRow(ctx,
+		`INSERT INTO bookings(user_id, event_id, quantity, total_price, status, booking_code, created_at, expires_at) 
+		 VALUES($1, $2, $3, $4, $5, $6, $7, $8) 
+		 RETURNING id, user_id, event_id, quantity, total_price, status, booking_code, created_at, expires_at`,
+		userID, eventID, res.quantity, res.totalPrice, status, bookingCode, time.Now(), expiresAt,
+	).Scan(
+		&booking.ID, &booking.UserID, &booking.EventID, &booking.Quantity,
+		&booking.TotalPrice, &booking.Status, &booking.BookingCode, &booking.CreatedAt, &booking.ExpiresAt,
+	)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, storage.ErrBookingExists
+		}
+		return nil, fmt.Errorf("%s: insert booking: %w", op, err)
+	}
+
+	return &booking, nil
+}
+
+// ConfirmBooking оплачивает удержание: списывает стоимость с баланса и
+// переводит бронь из pending в confirmed. Удержание, срок которого
+// прошел, подтвердить нельзя, даже если билеты еще не вернулись в продажу.
+func (s *Storage) ConfirmBooking(ctx context.Context, bookingID, userID int64) (*models.Booking, error) {
+	const op = "storage.postgres.ConfirmBooking"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: begin tx: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var b models.Booking
+	err = tx.QueryRow(ctx,
+		`SELECT b.id, b.user_id, b.event_id, b.quantity, b.total_price, b.status, b.booking_code, b.created_at,
+		        b.expires_at, e.category
+		 FROM bookings b
+		 JOIN events e ON b.event_id = e.id
+		 WHERE b.id = $1 AND b.user_id = $2
+		 FOR UPDATE OF b`,
+		bookingID, userID,
+	).Scan(
+		&b.ID, &b.UserID, &b.EventID, &b.Quantity, &b.TotalPrice, &b.Status, &b.BookingCode, &b.CreatedAt,
+		&b.ExpiresAt, &b.EventCategory,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrBookingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: get booking: %w", op, err)
+	}
+
+	switch b.Status {
+	case models.BookingStatusPending:
+		if b.ExpiresAt != nil && !time.Now().Before(*b.ExpiresAt) {
+			return nil, storage.ErrHoldExpired
+		}
+	case models.BookingStatusExpired:
+		return nil, storage.ErrHoldExpired
+	case models.BookingStatusCancelled:
+		return nil, storage.ErrBookingCancelled
+	default:
+		return nil, storage.ErrBookingNotPending
+	}
+
+	if err := chargeBalance(ctx, tx, userID, b.TotalPrice); err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx,
+		`UPDATE bookings b SET status = $1, expires_at = NULL
+		 WHERE b.id = $2
+		 RETURNING b.status, `+bookingSeats,
+		models.BookingStatusConfirmed, bookingID,
+	).Scan(&b.Status, &b.Seats)
+	if err != nil {
+		return nil, fmt.Errorf("%s: update status: %w", op, err)
+	}
+	b.ExpiresAt = nil
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	return &b, nil
+}
+
+// ReleaseExpiredHolds переводит удержания, срок которых истек к now, в
+// статус expired, освобождает их места и возвращает билеты в продажу.
+// Возвращает число истекших удержаний. Подтверждение и истечение
+// блокируют строку брони, поэтому оплаченное удержание не вернется в продажу.
+func (s *Storage) ReleaseExpiredHolds(ctx context.Context, now time.Time) (int64, error) {
+	const op = "storage.postgres.ReleaseExpiredHolds"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	var released int64
+	err := s.pool.QueryRow(ctx,
+		`WITH expired AS (
+			UPDATE bookings SET status = $1
+			WHERE status = $2 AND expires_at <= $3
+			RETURNING id, event_id, quantity
+		), seats AS (
+			DELETE FROM booking_seats WHERE booking_id IN (SELECT id FROM expired)
+		), tickets AS (
+			UPDATE events e SET available_tickets = e.available_tickets + x.quantity
+			FROM (SELECT event_id, SUM(quantity) AS quantity FROM expired GROUP BY event_id) x
+			WHERE e.id = x.event_id
+		)
+		SELECT COUNT(*) FROM expired`,
+		models.BookingStatusExpired, models.BookingStatusPending, now,
+	).Scan(&released)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return released, nil
+}
+
+// GetBookingsByUserID возвращает все бронирования пользователя
+func (s *Storage) GetBookingsByUserID(ctx context.Context, userID int64, page models.Page) ([]*models.BookingWithEvent, *models.Cursor, error) {
+	const op = "storage.postgres.GetBookingsByUserID"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	query := `SELECT b.id, b.user_id, b.event_id, b.quantity, b.total_price, b.status, b.booking_code, b.created_at,
+		       b.expires_at, e.title, e.start_time, e.venue, ` + bookingSeats + `
+		FROM bookings b
+		JOIN events e ON b.event_id = e.id
+		WHERE b.user_id = $1`
+	args := []interface{}{userID}
+	if page.After != nil {
+		query += ` AND (b.created_at, b.id) < ($2, $3)`
+		args = append(args, page.After.Time, page.After.ID)
+	}
+	query += ` ORDER BY b.created_at DESC, b.id DESC`
+	if !page.All() {
+		query += fmt.Sprintf(` LIMIT $%d`, len(args)+1)
+		args = append(args, page.Fetch())
+	}
+	query += fmt.Sprintf(` OFFSET $%d`, len(args)+1)
+	args = append(args, page.SkipRows())
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var bookings []*models.BookingWithEvent
+	for rows.Next() {
+		var b models.BookingWithEvent
+		err := rows.Scan(
+			&b.ID, &b.UserID, &b.EventID, &b.Quantity, &b.TotalPrice, &b.Status, &b.BookingCode, &b.CreatedAt,
+			&b.ExpiresAt, &b.EventTitle, &b.EventDate, &b.Venue, &b.Seats,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		bookings = append(bookings, &b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	bookings, next := models.NextPage(bookings, page, func(b *models.BookingWithEvent) models.Cursor {
+		return models.NewestCursor(b.CreatedAt, b.ID)
+	})
+
+	return bookings, next, nil
+}
+
+// GetBookingByID возвращает бронирование по ID
+func (s *Storage) GetBookingByID(ctx context.Context, bookingID, userID int64) (*models.BookingWithEvent, error) {
+	const op = "storage.postgres.GetBookingByID"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	var b models.BookingWithEvent
+	err := s.pool.QueryRow(
+		ctx,
+		`SELECT b.id, b.user_id, b.event_id, b.quantity, b.total_price, b.status, b.booking_code, b.created_at,
+		        b.expires_at, e.title, e.start_time, e.venue, `+bookingSeats+`
+		 FROM bookings b
+		 JOIN events e ON b.event_id = e.id
+		 WHERE b.id = $1 AND b.user_id = $2`,
+		bookingID, userID,
+	).Scan(
+		&b.ID, &b.UserID, &b.EventID, &b.Quantity, &b.TotalPrice, &b.Status, &b.BookingCode, &b.CreatedAt,
+		&b.ExpiresAt, &b.EventTitle, &b.EventDate, &b.Venue, &b.Seats,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrBookingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &b, nil
+}
+
+// GetEventBooking возвращает бронирование на мероприятие организатора
+// organizerID. Чужие мероприятия не отличаются от несуществующей брони.
+func (s *Storage) GetEventBooking(ctx context.Context, bookingID, organizerID int64) (*models.BookingWithEvent, error) {
+	const op = "storage.postgres.GetEventBooking"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	var b models.BookingWithEvent
+	err := s.pool.QueryRow(
+		ctx,
+		`SELECT b.id, b.user_id, b.event_id, b.quantity, b.total_price, b.status, b.booking_code, b.created_at,
+		        b.expires_at, e.title, e.start_time, e.venue, `+bookingSeats+`
+		 FROM bookings b
+		 JOIN events e ON b.event_id = e.id
+		 WHERE b.id = $1 AND e.creator_id = $2`,
+		bookingID, organizerID,
+	).Scan(
+		&b.ID, &b.UserID, &b.EventID, &b.Quantity, &b.TotalPrice, &b.Status, &b.BookingCode, &b.CreatedAt,
+		&b.ExpiresAt, &b.EventTitle, &b.EventDate, &b.Venue, &b.Seats,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrBookingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &b, nil
+}
+
+// CancelBooking отменяет бронирование и возвращает деньги. Отмена
+// удержания только возвращает билеты: деньги за него еще не списаны.
+// Возвращает отмененную бронь, чтобы вернувшиеся билеты можно было сразу
+// предложить листу ожидания.
+func (s *Storage) CancelBooking(ctx context.Context, bookingID, userID int64) (*models.Booking, error) {
+	const op = "storage.postgres.CancelBooking"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: begin tx: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Получаем бронирование
+	b := models.Booking{ID: bookingID, UserID: userID}
+	err = tx.QueryRow(ctx,
+		`SELECT event_id, quantity, total_price, status, booking_code, created_at
+		 FROM bookings WHERE id = $1 AND user_id = $2 FOR UPDATE`,
+		bookingID, userID,
+	).Scan(&b.EventID, &b.Quantity, &b.TotalPrice, &b.Status, &b.BookingCode, &b.CreatedAt)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrBookingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: get booking: %w", op, err)
+	}
+
+	if b.Status == models.BookingStatusCancelled {
+		return nil, storage.ErrBookingCancelled
+	}
+	if b.Status == models.BookingStatusExpired {
+		return nil, storage.ErrHoldExpired
+	}
+
+	// Обновляем статус бронирования
+	_, err = tx.Exec(ctx, `UPDATE bookings SET status = $1 WHERE id = $2`, models.BookingStatusCancelled, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: update status: %w", op, err)
+	}
+
+	// Ожидающая передача отменяется вместе с бронью
+	_, err = tx.Exec(ctx,
+		`UPDATE booking_transfers SET status = $1, resolved_at = $2 WHERE booking_id = $3 AND status = $4`,
+		models.TransferCancelled, time.Now(), bookingID, models.TransferPending,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: cancel transfers: %w", op, err)
+	}
+
+	// Освобождаем места и возвращаем билеты
+	_, err = tx.Exec(ctx, `DELETE FROM booking_seats WHERE booking_id = $1`, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: release seats: %w", op, err)
+	}
+	_, err = tx.Exec(ctx, `UPDATE events SET available_tickets = available_tickets + $1 WHERE id = $2`, b.Quantity, b.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: return tickets: %w", op, err)
+	}
+
+	// Возвращаем деньги
+	if b.Status != models.BookingStatusPending {
+		_, err = tx.Exec(ctx, `UPDATE users SET balance = balance + $1 WHERE id = $2`, b.TotalPrice, userID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: refund: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	b.Status = models.BookingStatusCancelled
+	return &b, nil
+}
+
+// ==================== Waitlist Methods ====================
+
+// activeBookingStatuses статусы брони, которая держит билеты пользователя
+const activeBookingStatuses = `('pending', 'confirmed', 'used')`
+
+// waitlistAhead есть ли в листе ожидания другой пользователь, еще не
+// получивший предложения. Очередь строгая: пока она не пуста, вернувшиеся
+// билеты копятся для первого в ней и в обычную продажу не идут. Вызывается
+// в транзакции после reserveTickets.
+func waitlistAhead(ctx context.Context, tx pgx.Tx, userID, eventID int64) (bool, error) {
+	var ahead bool
+	err := tx.QueryRow(ctx,
+		`SELECT EXISTS(
+			SELECT 1 FROM waitlist
+			WHERE event_id = $1 AND user_id <> $2 AND booking_id IS NULL
+		)`,
+		eventID, userID,
+	).Scan(&ahead)
+
+	return ahead, err
+}
+
+// JoinWaitlist ставит пользователя в лист ожидания мероприятия на quantity
+// билетов. Встать можно, если столько билетов сейчас нет или они уже
+// копятся для очереди, и у пользователя нет действующей брони на мероприятие.
+// Мероприятие блокируется до конца транзакции, как при бронировании, поэтому
+// проверка свободных билетов и запись в очередь не расходятся с продажей.
+func (s *Storage) JoinWaitlist(ctx context.Context, userID, eventID int64, quantity int) (*models.WaitlistEntry, error) {
+	const op = "storage.postgres.JoinWaitlist"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tx, err := s.pool.Begin(ctx)
 	if err != nil {
-		return nil, 0, fmt.Errorf("%s: count: %w", op, err)
+		return nil, fmt.Errorf("%s: begin tx: %w", op, err)
 	}
+	defer tx.Rollback(ctx)
 
-	// Получаем записи с пагинацией
-	selectQuery := `SELECT id, title, description, category, image_url, venue, address, price, capacity, available_tickets, start_time, end_time, creator_id, created_at, updated_at ` + baseQuery
-	selectQuery += fmt.Sprintf(` ORDER BY start_time ASC LIMIT $%d OFFSET $%d`, argNum, argNum+1)
-	args = append(args, limit, offset)
+	var available int
+	var booked, queued bool
+	err = tx.QueryRow(ctx,
+		`SELECT e.available_tickets, EXISTS(
+			SELECT 1 FROM bookings b
+			WHERE b.event_id = e.id AND b.user_id = $2 AND b.status IN `+activeBookingStatuses+`
+		), EXISTS(
+			SELECT 1 FROM waitlist w WHERE w.event_id = e.id AND w.user_id <> $2 AND w.booking_id IS NULL
+		)
+		 FROM events e WHERE e.id = $1
+		 FOR UPDATE OF e`,
+		eventID, userID,
+	).Scan(&available, &booked, &queued)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: get event: %w", op, err)
+	}
+	if booked {
+		return nil, storage.ErrBookingExists
+	}
+	if available >= quantity && !queued {
+		return nil, storage.ErrTicketsAvailable
+	}
 
-	rows, err := s.pool.Query(context.Background(), selectQuery, args...)
+	// Запись с прошлым предложением, которое уже не ждет оплаты, не мешает встать снова
+	_, err = tx.Exec(ctx,
+		`DELETE FROM waitlist w USING bookings b
+		 WHERE w.event_id = $1 AND w.user_id = $2 AND b.id = w.booking_id AND b.status <> $3`,
+		eventID, userID, models.BookingStatusPending,
+	)
 	if err != nil {
-		return nil, 0, fmt.Errorf("%s: query: %w", op, err)
+		return nil, fmt.Errorf("%s: delete stale entry: %w", op, err)
 	}
-	defer rows.Close()
 
-	var events []*models.Event
-	for rows.Next() {
-		var event models.Event
-		err := rows.Scan(
-			&event.ID, &event.Title, &event.Description, &event.Category, &event.ImageURL,
-			&event.Venue, &event.Address, &event.Price, &event.Capacity, &event.AvailableTickets,
-			&event.StartTime, &event.EndTime, &event.CreatorID, &event.CreatedAt, &event.UpdatedAt,
-		)
-		if err != nil {
-			return nil, 0, fmt.Errorf("%s: scan: %w", op, err)
+	_, err = tx.Exec(ctx,
+		`INSERT INTO waitlist(event_id, user_id, quantity) VALUES($1, $2, $3)`,
+		eventID, userID, quantity,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505":
+				return nil, storage.ErrWaitlistExists
+			case "23503":
+				return nil, storage.ErrEventNotFound
+			}
 		}
-		events = append(events, &event)
+		return nil, fmt.Errorf("%s: insert: %w", op, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: commit: %w", op, err)
 	}
 
-	return events, total, rows.Err()
+	return s.GetWaitlistEntry(ctx, userID, eventID)
 }
 
-// ==================== Booking Methods ====================
+// GetWaitlistEntry возвращает запись пользователя в листе ожидания с
+// местом в очереди или предложенным удержанием
+func (s *Storage) GetWaitlistEntry(ctx context.Context, userID, eventID int64) (*models.WaitlistEntry, error) {
+	const op = "storage.postgres.GetWaitlistEntry"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	entry := models.WaitlistEntry{UserID: userID}
+	err := s.pool.QueryRow(ctx,
+		`SELECT w.id, w.event_id, w.quantity, w.created_at, w.booking_id, b.expires_at,
+		        (SELECT COUNT(*) FROM waitlist a
+		         WHERE a.event_id = w.event_id AND a.booking_id IS NULL AND a.id <= w.id)
+		 FROM waitlist w
+		 LEFT JOIN bookings b ON b.id = w.booking_id
+		 WHERE w.event_id = $1 AND w.user_id = $2 AND (w.booking_id IS NULL OR b.status = $3)`,
+		eventID, userID, models.BookingStatusPending,
+	).Scan(
+		&entry.ID, &entry.EventID, &entry.Quantity, &entry.CreatedAt, &entry.BookingID, &entry.OfferExpiresAt,
+		&entry.Position,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrWaitlistNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-// generateBookingCode генерирует уникальный код бронирования
-func generateBookingCode() string {
-	bytes := make([]byte, 8)
-	rand.Read(bytes)
-	return "BK-" + hex.EncodeToString(bytes)
+	entry.Status = models.WaitlistWaiting
+	if entry.BookingID != nil {
+		entry.Status = models.WaitlistOffered
+		entry.Position = 0
+	}
+
+	return &entry, nil
+}
+
+// LeaveWaitlist удаляет пользователя из листа ожидания. Уже предложенное
+// удержание остается бронью пользователя: его можно оплатить или отменить.
+func (s *Storage) LeaveWaitlist(ctx context.Context, userID, eventID int64) error {
+	const op = "storage.postgres.LeaveWaitlist"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tag, err := s.pool.Exec(ctx, `DELETE FROM waitlist WHERE event_id = $1 AND user_id = $2`, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrWaitlistNotFound
+	}
+
+	return nil
+}
+
+// WaitlistEvents возвращает еще не начавшиеся мероприятия, где свободных
+// билетов хватает первому в листе ожидания. Заодно из очереди убираются
+// предложения, которые оплатили, отменили или не оплатили вовремя.
+func (s *Storage) WaitlistEvents(ctx context.Context, now time.Time) ([]int64, error) {
+	const op = "storage.postgres.WaitlistEvents"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	_, err := s.pool.Exec(ctx,
+		`DELETE FROM waitlist w USING bookings b WHERE b.id = w.booking_id AND b.status <> $1`,
+		models.BookingStatusPending,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: delete finished offers: %w", op, err)
+	}
+
+	rows, err := s.pool.Query(ctx,
+		`SELECT h.event_id FROM (
+			SELECT DISTINCT ON (event_id) event_id, quantity FROM waitlist
+			WHERE booking_id IS NULL
+			ORDER BY event_id, id
+		 ) h
+		 JOIN events e ON e.id = h.event_id
+		 WHERE e.available_tickets >= h.quantity AND e.start_time > $1`,
+		now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	eventIDs, err := scanIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return eventIDs, nil
 }
 
-// CreateBooking создает бронирование с транзакцией
-func (s *Storage) CreateBooking(userID, eventID int64, quantity int) (*models.Booking, error) {
-	const op = "storage.postgres.CreateBooking"
+// OfferEventTickets предлагает свободные билеты мероприятия листу ожидания
+// строго по очереди: первому в ней создается удержание до now+window, и так
+// пока билетов хватает следующему. Если первому не хватает, билеты копятся
+// для него, а стоящие дальше не обгоняют его меньшими заявками. Мероприятие
+// заблокировано до конца транзакции, поэтому обычная продажа не перехватит
+// билеты. Для начавшегося мероприятия ничего не делает.
+func (s *Storage) OfferEventTickets(ctx context.Context, eventID int64, now time.Time, window time.Duration) ([]models.WaitlistOffer, error) {
+	const op = "storage.postgres.OfferEventTickets"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
 
-	ctx := context.Background()
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%s: begin tx: %w", op, err)
 	}
 	defer tx.Rollback(ctx)
 
-	// Проверяем доступность билетов и получаем цену
-	var availableTickets int
-	var price float64
+	var available int
+	var title string
+	var seatMapID *int64
+	var startTime time.Time
 	err = tx.QueryRow(ctx,
-		`SELECT available_tickets, price FROM events WHERE id = $1 FOR UPDATE`,
+		`SELECT available_tickets, title, seat_map_id, start_time FROM events WHERE id = $1 FOR UPDATE`,
 		eventID,
-	).Scan(&availableTickets, &price)
-
+	).Scan(&available, &title, &seatMapID, &startTime)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, storage.ErrEventNotFound
 	}
 	if err != nil {
 		return nil, fmt.Errorf("%s: get event: %w", op, err)
 	}
+	if !startTime.After(now) {
+		return nil, nil
+	}
+	expiresAt := now.Add(window)
 
-	if availableTickets < quantity {
-		return nil, storage.ErrNoTickets
+	// Кто уже купил билеты сам, из очереди выбывает
+	_, err = tx.Exec(ctx,
+		`DELETE FROM waitlist w USING bookings b
+		 WHERE w.event_id = $1 AND w.booking_id IS NULL
+		   AND b.event_id = w.event_id AND b.user_id = w.user_id AND b.status IN `+activeBookingStatuses,
+		eventID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: delete booked users: %w", op, err)
 	}
 
-	totalPrice := price * float64(quantity)
+	var offers []models.WaitlistOffer
+	for available > 0 {
+		var entryID, userID int64
+		var quantity int
+		err := tx.QueryRow(ctx,
+			`SELECT id, user_id, quantity FROM waitlist
+			 WHERE event_id = $1 AND booking_id IS NULL
+			 ORDER BY id LIMIT 1`,
+			eventID,
+		).Scan(&entryID, &userID, &quantity)
+		if errors.Is(err, pgx.ErrNoRows) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: get next entry: %w", op, err)
+		}
+		if quantity > available {
+			break
+		}
 
-	// Проверяем баланс пользователя
-	var balance float64
-	err = tx.QueryRow(ctx, `SELECT balance FROM users WHERE id = $1 FOR UPDATE`, userID).Scan(&balance)
-	if errors.Is(err, pgx.ErrNoRows) {
-		return nil, storage.ErrUserNotFound
+		var seatIDs []int64
+		if seatMapID != nil {
+			seatIDs, err = freeSeats(ctx, tx, eventID, *seatMapID, quantity)
+			if err != nil {
+				return nil, fmt.Errorf("%s: get free seats: %w", op, err)
+			}
+			if len(seatIDs) < quantity {
+				break
+			}
+		}
+
+		res, err := reserveTickets(ctx, tx, eventID, quantity, seatIDs)
+		if err != nil {
+			return nil, err
+		}
+		booking, err := insertBooking(ctx, tx, userID, eventID, res, models.BookingStatusPending, &expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		if len(seatIDs) > 0 {
+			if err := takeSeats(ctx, tx, booking.ID, eventID, seatIDs); err != nil {
+				return nil, err
+			}
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE waitlist SET booking_id = $1 WHERE id = $2`, booking.ID, entryID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: mark offered: %w", op, err)
+		}
+
+		available -= res.quantity
+		offers = append(offers, models.WaitlistOffer{
+			UserID:     userID,
+			EventID:    eventID,
+			EventTitle: title,
+			BookingID:  booking.ID,
+			Quantity:   res.quantity,
+			ExpiresAt:  expiresAt,
+		})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	return offers, nil
+}
+
+// freeSeats возвращает до limit незанятых мест мероприятия в порядке схемы зала
+func freeSeats(ctx context.Context, tx pgx.Tx, eventID, seatMapID int64, limit int) ([]int64, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT s.id FROM seats s
+		 WHERE s.seat_map_id = $1
+		   AND NOT EXISTS (SELECT 1 FROM booking_seats bs WHERE bs.event_id = $2 AND bs.seat_id = s.id)
+		 ORDER BY s.id
+		 LIMIT $3`,
+		seatMapID, eventID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIDs(rows)
+}
+
+// scanIDs читает ID из единственной колонки и закрывает rows
+func scanIDs(rows pgx.Rows) ([]int64, error) {
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
 	}
+
+	return ids, rows.Err()
+}
+
+// ==================== Transfer Methods ====================
+
+// transferColumns колонки передачи брони для scanTransfer, запрос строится FROM transferFrom
+const transferColumns = `t.id, t.booking_id, t.event_id, fu.email, tu.email, t.quantity, t.seat_ids, t.status,
+	t.new_booking_id, t.created_at, t.resolved_at, t.from_user_id, t.to_user_id`
+
+const transferFrom = `booking_transfers t
+	JOIN users fu ON fu.id = t.from_user_id
+	JOIN users tu ON tu.id = t.to_user_id`
+
+// scanTransfer читает передачу брони, выбранную по transferColumns
+func scanTransfer(row pgx.Row) (*models.BookingTransfer, error) {
+	var t models.BookingTransfer
+	err := row.Scan(
+		&t.ID, &t.BookingID, &t.EventID, &t.FromEmail, &t.ToEmail, &t.Quantity, &t.SeatIDs, &t.Status,
+		&t.NewBookingID, &t.CreatedAt, &t.ResolvedAt, &t.FromUserID, &t.ToUserID,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("%s: get balance: %w", op, err)
+		return nil, err
 	}
 
-	if balance < totalPrice {
-		return nil, storage.ErrInsufficientBalance
+	return &t, nil
+}
+
+// CreateTransfer предлагает передать бронь пользователю с email toEmail.
+// Передается вся бронь, quantity ее билетов или, на мероприятии со схемой
+// зала, места seatIDs. Билеты меняют владельца только после AcceptTransfer.
+func (s *Storage) CreateTransfer(ctx context.Context, bookingID, fromUserID int64, toEmail string, quantity int, seatIDs []int64) (*models.BookingTransfer, error) {
+	const op = "storage.postgres.CreateTransfer"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: begin tx: %w", op, err)
 	}
+	defer tx.Rollback(ctx)
 
-	// Списываем с баланса
-	_, err = tx.Exec(ctx, `UPDATE users SET balance = balance - $1 WHERE id = $2`, totalPrice, userID)
+	var eventID int64
+	var booked int
+	var status models.BookingStatus
+	err = tx.QueryRow(ctx,
+		`SELECT event_id, quantity, status FROM bookings WHERE id = $1 AND user_id = $2 FOR UPDATE`,
+		bookingID, fromUserID,
+	).Scan(&eventID, &booked, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrBookingNotFound
+	}
 	if err != nil {
-		return nil, fmt.Errorf("%s: deduct balance: %w", op, err)
+		return nil, fmt.Errorf("%s: get booking: %w", op, err)
+	}
+	if status != models.BookingStatusConfirmed {
+		return nil, storage.ErrNotTransferable
 	}
 
-	// Уменьшаем количество доступных билетов
-	_, err = tx.Exec(ctx, `UPDATE events SET available_tickets = available_tickets - $1 WHERE id = $2`, quantity, eventID)
+	rows, err := tx.Query(ctx, `SELECT seat_id FROM booking_seats WHERE booking_id = $1 ORDER BY seat_id`, bookingID)
 	if err != nil {
-		return nil, fmt.Errorf("%s: update tickets: %w", op, err)
+		return nil, fmt.Errorf("%s: get seats: %w", op, err)
+	}
+	bookedSeats, err := scanIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: get seats: %w", op, err)
 	}
 
-	// Создаем бронирование
-	bookingCode := generateBookingCode()
-	var booking models.Booking
+	switch {
+	case len(seatIDs) > 0:
+		for _, id := range seatIDs {
+			if !slices.Contains(bookedSeats, id) {
+				return nil, storage.ErrSeatNotFound
+			}
+		}
+		quantity = len(seatIDs)
+	case quantity > booked:
+		return nil, storage.ErrTransferQuantity
+	case quantity == 0 || quantity == booked:
+		quantity = booked
+		seatIDs = bookedSeats
+	case len(bookedSeats) > 0:
+		// Часть брони с местами передается только с выбором мест
+		return nil, storage.ErrSeatsRequired
+	}
+
+	var toUserID int64
+	var hasBooking bool
 	err = tx.QueryRow(ctx,
-		`INSERT INTO bookings(user_id, event_id, quantity, total_price, status, booking_code, created_at) 
-		 VALUES($1, $2, $3, $4, $5, $6, $7) 
-		 RETURNING id, user_id, event_id, quantity, total_price, status, booking_code, created_at`,
-		userID, eventID, quantity, totalPrice, models.BookingStatusConfirmed, bookingCode, time.Now(),
-	).Scan(
-		&booking.ID, &booking.UserID, &booking.EventID, &booking.Quantity,
-		&booking.TotalPrice, &booking.Status, &booking.BookingCode, &booking.CreatedAt,
-	)
+		`SELECT u.id, EXISTS(
+			SELECT 1 FROM bookings b
+			WHERE b.user_id = u.id AND b.event_id = $2 AND b.status IN `+activeBookingStatuses+`)
+		 FROM users u WHERE u.email = $1`,
+		toEmail, eventID,
+	).Scan(&toUserID, &hasBooking)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: get recipient: %w", op, err)
+	}
+	if toUserID == fromUserID {
+		return nil, storage.ErrTransferToSelf
+	}
+	if hasBooking {
+		return nil, storage.ErrBookingExists
+	}
 
+	var transferID int64
+	err = tx.QueryRow(ctx,
+		`INSERT INTO booking_transfers(booking_id, event_id, from_user_id, to_user_id, quantity, seat_ids, status, created_at)
+		 VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+		 RETURNING id`,
+		bookingID, eventID, fromUserID, toUserID, quantity, seatIDs, models.TransferPending, time.Now(),
+	).Scan(&transferID)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return nil, storage.ErrBookingExists
+			return nil, storage.ErrTransferExists
 		}
-		return nil, fmt.Errorf("%s: insert booking: %w", op, err)
+		return nil, fmt.Errorf("%s: insert: %w", op, err)
+	}
+
+	transfer, err := scanTransfer(tx.QueryRow(ctx,
+		`SELECT `+transferColumns+` FROM `+transferFrom+` WHERE t.id = $1`, transferID))
+	if err != nil {
+		return nil, fmt.Errorf("%s: get transfer: %w", op, err)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("%s: commit: %w", op, err)
 	}
 
-	return &booking, nil
+	return transfer, nil
 }
 
-// GetBookingsByUserID возвращает все бронирования пользователя
-func (s *Storage) GetBookingsByUserID(userID int64) ([]*models.BookingWithEvent, error) {
-	const op = "storage.postgres.GetBookingsByUserID"
+// GetTransfers возвращает входящие и исходящие передачи пользователя, новые первыми
+func (s *Storage) GetTransfers(ctx context.Context, userID int64) ([]*models.BookingTransfer, error) {
+	const op = "storage.postgres.GetTransfers"
 
-	rows, err := s.pool.Query(
-		context.Background(),
-		`SELECT b.id, b.user_id, b.event_id, b.quantity, b.total_price, b.status, b.booking_code, b.created_at,
-		        e.title, e.start_time, e.venue
-		 FROM bookings b
-		 JOIN events e ON b.event_id = e.id
-		 WHERE b.user_id = $1
-		 ORDER BY b.created_at DESC`,
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+transferColumns+` FROM `+transferFrom+`
+		 WHERE t.from_user_id = $1 OR t.to_user_id = $1
+		 ORDER BY t.created_at DESC, t.id DESC`,
 		userID,
 	)
 	if err != nil {
@@ -539,99 +2829,184 @@ func (s *Storage) GetBookingsByUserID(userID int64) ([]*models.BookingWithEvent,
 	}
 	defer rows.Close()
 
-	var bookings []*models.BookingWithEvent
+	transfers := []*models.BookingTransfer{}
 	for rows.Next() {
-		var b models.BookingWithEvent
-		err := rows.Scan(
-			&b.ID, &b.UserID, &b.EventID, &b.Quantity, &b.TotalPrice, &b.Status, &b.BookingCode, &b.CreatedAt,
-			&b.EventTitle, &b.EventDate, &b.Venue,
-		)
+		t, err := scanTransfer(rows)
 		if err != nil {
 			return nil, fmt.Errorf("%s: scan: %w", op, err)
 		}
-		bookings = append(bookings, &b)
+		transfers = append(transfers, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: rows: %w", op, err)
 	}
 
-	return bookings, rows.Err()
+	return transfers, nil
 }
 
-// GetBookingByID возвращает бронирование по ID
-func (s *Storage) GetBookingByID(bookingID, userID int64) (*models.BookingWithEvent, error) {
-	const op = "storage.postgres.GetBookingByID"
+// AcceptTransfer принимает передачу и возвращает бронь получателя. Вся
+// бронь переходит получателю целиком, часть выделяется в новую бронь с
+// долей стоимости. Коды затронутых броней перевыпускаются: подпись старого
+// билета остается верной, но онлайн-проверка по GetEventBooking его отклоняет.
+func (s *Storage) AcceptTransfer(ctx context.Context, transferID, userID int64) (*models.BookingWithEvent, error) {
+	const op = "storage.postgres.AcceptTransfer"
 
-	var b models.BookingWithEvent
-	err := s.pool.QueryRow(
-		context.Background(),
-		`SELECT b.id, b.user_id, b.event_id, b.quantity, b.total_price, b.status, b.booking_code, b.created_at,
-		        e.title, e.start_time, e.venue
-		 FROM bookings b
-		 JOIN events e ON b.event_id = e.id
-		 WHERE b.id = $1 AND b.user_id = $2`,
-		bookingID, userID,
-	).Scan(
-		&b.ID, &b.UserID, &b.EventID, &b.Quantity, &b.TotalPrice, &b.Status, &b.BookingCode, &b.CreatedAt,
-		&b.EventTitle, &b.EventDate, &b.Venue,
-	)
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
 
-	if errors.Is(err, pgx.ErrNoRows) {
-		return nil, storage.ErrBookingNotFound
-	}
+	tx, err := s.pool.Begin(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: begin tx: %w", op, err)
 	}
+	defer tx.Rollback(ctx)
 
-	return &b, nil
-}
-
-// CancelBooking отменяет бронирование и возвращает деньги
-func (s *Storage) CancelBooking(bookingID, userID int64) error {
-	const op = "storage.postgres.CancelBooking"
-
-	ctx := context.Background()
-	tx, err := s.pool.Begin(ctx)
+	t, err := scanTransfer(tx.QueryRow(ctx,
+		`SELECT `+transferColumns+` FROM `+transferFrom+`
+		 WHERE t.id = $1 AND t.to_user_id = $2
+		 FOR UPDATE OF t`,
+		transferID, userID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrTransferNotFound
+	}
 	if err != nil {
-		return fmt.Errorf("%s: begin tx: %w", op, err)
+		return nil, fmt.Errorf("%s: get transfer: %w", op, err)
+	}
+	if t.Status != models.TransferPending {
+		return nil, storage.ErrTransferNotPending
 	}
-	defer tx.Rollback(ctx)
 
-	// Получаем бронирование
-	var eventID int64
+	var ownerID int64
 	var quantity int
 	var totalPrice float64
 	var status models.BookingStatus
 	err = tx.QueryRow(ctx,
-		`SELECT event_id, quantity, total_price, status FROM bookings WHERE id = $1 AND user_id = $2 FOR UPDATE`,
-		bookingID, userID,
-	).Scan(&eventID, &quantity, &totalPrice, &status)
+		`SELECT user_id, quantity, total_price, status FROM bookings WHERE id = $1 FOR UPDATE`,
+		t.BookingID,
+	).Scan(&ownerID, &quantity, &totalPrice, &status)
+	if err != nil {
+		return nil, fmt.Errorf("%s: get booking: %w", op, err)
+	}
+	if status != models.BookingStatusConfirmed || ownerID != t.FromUserID || quantity < t.Quantity {
+		return nil, storage.ErrNotTransferable
+	}
 
-	if errors.Is(err, pgx.ErrNoRows) {
-		return storage.ErrBookingNotFound
+	newBookingID := t.BookingID
+	if t.Quantity == quantity {
+		_, err = tx.Exec(ctx,
+			`UPDATE bookings SET user_id = $1, booking_code = $2 WHERE id = $3`,
+			userID, generateBookingCode(), t.BookingID,
+		)
+	} else {
+		newBookingID, err = splitBooking(ctx, tx, t, quantity, totalPrice)
+	}
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, storage.ErrBookingExists
+		}
+		return nil, fmt.Errorf("%s: move tickets: %w", op, err)
 	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE booking_transfers SET status = $1, new_booking_id = $2, resolved_at = $3 WHERE id = $4`,
+		models.TransferAccepted, newBookingID, time.Now(), transferID,
+	)
 	if err != nil {
-		return fmt.Errorf("%s: get booking: %w", op, err)
+		return nil, fmt.Errorf("%s: update transfer: %w", op, err)
 	}
 
-	if status == models.BookingStatusCancelled {
-		return errors.New("booking already cancelled")
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: commit: %w", op, err)
 	}
 
-	// Обновляем статус бронирования
-	_, err = tx.Exec(ctx, `UPDATE bookings SET status = $1 WHERE id = $2`, models.BookingStatusCancelled, bookingID)
+	return s.GetBookingByID(ctx, newBookingID, userID)
+}
+
+// splitBooking выделяет билеты передачи t из брони в новую бронь получателя.
+// Стоимость делится по ценам мест или поровну между билетами.
+func splitBooking(ctx context.Context, tx pgx.Tx, t *models.BookingTransfer, quantity int, totalPrice float64) (int64, error) {
+	part := totalPrice * float64(t.Quantity) / float64(quantity)
+	if len(t.SeatIDs) > 0 {
+		err := tx.QueryRow(ctx,
+			`SELECT COALESCE(SUM((e.seat_prices->>s.price_category)::numeric), 0)::float8
+			 FROM seats s JOIN events e ON e.id = $1
+			 WHERE s.id = ANY($2)`,
+			t.EventID, t.SeatIDs,
+		).Scan(&part)
+		if err != nil {
+			return 0, err
+		}
+	}
+	part = math.Round(part*100) / 100
+
+	_, err := tx.Exec(ctx,
+		`UPDATE bookings SET quantity = quantity - $1, total_price = total_price - $2, booking_code = $3 WHERE id = $4`,
+		t.Quantity, part, generateBookingCode(), t.BookingID,
+	)
 	if err != nil {
-		return fmt.Errorf("%s: update status: %w", op, err)
+		return 0, err
 	}
 
-	// Возвращаем билеты
-	_, err = tx.Exec(ctx, `UPDATE events SET available_tickets = available_tickets + $1 WHERE id = $2`, quantity, eventID)
+	var bookingID int64
+	err = tx.QueryRow(ctx,
+		`INSERT INTO bookings(user_id, event_id, quantity, total_price, status, booking_code, created_at)
+		 VALUES($1, $2, $3, $4, $5, $6, $7)
+		 RETURNING id`,
+		t.ToUserID, t.EventID, t.Quantity, part, models.BookingStatusConfirmed, generateBookingCode(), time.Now(),
+	).Scan(&bookingID)
 	if err != nil {
-		return fmt.Errorf("%s: return tickets: %w", op, err)
+		return 0, err
 	}
 
-	// Возвращаем деньги
-	_, err = tx.Exec(ctx, `UPDATE users SET balance = balance + $1 WHERE id = $2`, totalPrice, userID)
+	if len(t.SeatIDs) > 0 {
+		_, err = tx.Exec(ctx,
+			`UPDATE booking_seats SET booking_id = $1 WHERE booking_id = $2 AND seat_id = ANY($3)`,
+			bookingID, t.BookingID, t.SeatIDs,
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return bookingID, nil
+}
+
+// DeclineTransfer закрывает ожидающую передачу и возвращает ее новый
+// статус: получатель отказывается от нее (declined), отправитель
+// отзывает (cancelled)
+func (s *Storage) DeclineTransfer(ctx context.Context, transferID, userID int64) (models.TransferStatus, error) {
+	const op = "storage.postgres.DeclineTransfer"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	var status models.TransferStatus
+	err := s.pool.QueryRow(ctx,
+		`UPDATE booking_transfers
+		 SET status = CASE WHEN to_user_id = $2 THEN $3 ELSE $4 END, resolved_at = $5
+		 WHERE id = $1 AND $2 IN (from_user_id, to_user_id) AND status = $6
+		 RETURNING status`,
+		transferID, userID, models.TransferDeclined, models.TransferCancelled, time.Now(), models.TransferPending,
+	).Scan(&status)
+	if err == nil {
+		return status, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	var exists bool
+	err = s.pool.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM booking_transfers WHERE id = $1 AND $2 IN (from_user_id, to_user_id))`,
+		transferID, userID,
+	).Scan(&exists)
 	if err != nil {
-		return fmt.Errorf("%s: refund: %w", op, err)
+		return "", fmt.Errorf("%s: check transfer: %w", op, err)
+	}
+	if !exists {
+		return "", storage.ErrTransferNotFound
 	}
 
-	return tx.Commit(ctx)
+	return "", storage.ErrTransferNotPending
 }
@@ -3,6 +3,7 @@ package sqlite
 import (
 	storage "API/internal/Storage"
 	"API/internal/models"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -28,7 +29,13 @@ func New(storagePath string) (*Storage, error) {
 		CREATE TABLE IF NOT EXISTS url(
 		    id INTEGER PRIMARY KEY,
 		    alias TEXT NOT NULL UNIQUE,
-		    url TEXT NOT NULL
+		    url TEXT NOT NULL,
+		    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+		    expires_at DATETIME,
+		    max_clicks INTEGER,
+		    click_count INTEGER NOT NULL DEFAULT 0,
+		    exhausted_at DATETIME,
+		    created_at DATETIME
 		);
 		CREATE INDEX IF NOT EXISTS idx_alias ON url(alias);
 	`)
@@ -36,6 +43,52 @@ func New(storagePath string) (*Storage, error) {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	// Файлы, созданные до появления владельцев ссылок, дополняем новыми колонками
+	for _, column := range []struct{ name, ddl string }{
+		{"user_id", "ALTER TABLE url ADD COLUMN user_id INTEGER REFERENCES users(id) ON DELETE CASCADE"},
+		{"created_at", "ALTER TABLE url ADD COLUMN created_at DATETIME"},
+		{"expires_at", "ALTER TABLE url ADD COLUMN expires_at DATETIME"},
+		{"max_clicks", "ALTER TABLE url ADD COLUMN max_clicks INTEGER"},
+		{"click_count", "ALTER TABLE url ADD COLUMN click_count INTEGER NOT NULL DEFAULT 0"},
+		{"exhausted_at", "ALTER TABLE url ADD COLUMN exhausted_at DATETIME"},
+	} {
+		if err := addColumnIfMissing(db, "url", column.name, column.ddl); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	// Ссылки, исчерпанные до появления exhausted_at, хранятся от первого запуска
+	_, err = db.Exec(
+		"UPDATE url SET exhausted_at = ? WHERE exhausted_at IS NULL AND click_count >= max_clicks",
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = db.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_url_user_id ON url(user_id);
+		CREATE INDEX IF NOT EXISTS idx_url_user_id_created_at ON url(user_id, created_at DESC, id DESC);
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS url_clicks(
+		    id INTEGER PRIMARY KEY AUTOINCREMENT,
+		    url_id INTEGER NOT NULL REFERENCES url(id) ON DELETE CASCADE,
+		    clicked_at DATETIME NOT NULL,
+		    referrer TEXT NOT NULL,
+		    user_agent TEXT NOT NULL,
+		    region TEXT NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_url_clicks_url_id_clicked_at ON url_clicks(url_id, clicked_at);
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	_, err = db.Exec(`
 		CREATE TABLE IF NOT EXISTS users(
 		    id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -54,17 +107,61 @@ func New(storagePath string) (*Storage, error) {
 	return &Storage{db: db}, nil
 }
 
-// SaveURL сохраняет URL с алиасом
-func (s *Storage) SaveURL(urlToSave string, alias string) (int64, error) {
+// addColumnIfMissing выполняет ddl, если в таблице еще нет колонки column
+func addColumnIfMissing(db *sql.DB, table, column, ddl string) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("table info %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return fmt.Errorf("table info %s: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("table info %s: %w", table, err)
+	}
+
+	if _, err := db.Exec(ddl); err != nil {
+		return fmt.Errorf("add column %s.%s: %w", table, column, err)
+	}
+
+	return nil
+}
+
+// SaveURL сохраняет URL с алиасом от имени пользователя.
+// expiresAt и maxClicks необязательны: nil означает отсутствие ограничения.
+func (s *Storage) SaveURL(ctx context.Context, urlToSave string, alias string, userID int64, expiresAt *time.Time, maxClicks *int) (int64, error) {
 	const op = "storage.sqlite.SaveURL"
 
-	stmt, err := s.db.Prepare("INSERT INTO url(url, alias) VALUES(?,?)")
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	stmt, err := s.db.PrepareContext(ctx, "INSERT INTO url(url, alias, user_id, expires_at, max_clicks, created_at) VALUES(?,?,?,?,?,?)")
 	if err != nil {
 		return 0, fmt.Errorf("%s: prepare statement: %w", op, err)
 	}
 	defer stmt.Close()
 
-	res, err := stmt.Exec(urlToSave, alias)
+	var expires any
+	if expiresAt != nil {
+		expires = expiresAt.UTC()
+	}
+
+	res, err := stmt.ExecContext(ctx, urlToSave, alias, userID, expires, maxClicks, time.Now().UTC())
 	if err != nil {
 		var sqliteErr sqlite3.Error
 		if errors.As(err, &sqliteErr) && errors.Is(sqliteErr.ExtendedCode, sqlite3.ErrConstraintUnique) {
@@ -80,32 +177,459 @@ func (s *Storage) SaveURL(urlToSave string, alias string) (int64, error) {
 	return id, nil
 }
 
-// GetURL возвращает URL по алиасу
-func (s *Storage) GetURL(alias string) (string, error) {
+// SaveURLs сохраняет пакет ссылок пользователя в одной транзакции.
+// В SQLite нарушение уникальности отменяет только свою вставку, поэтому
+// занятый алиас отмечается в результате элемента. При atomic любой занятый
+// алиас откатывает весь пакет и возвращается storage.ErrBatchRejected.
+func (s *Storage) SaveURLs(ctx context.Context, userID int64, urls []models.NewURL, atomic bool) ([]models.SavedURL, error) {
+	const op = "storage.sqlite.SaveURLs"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: begin: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, "INSERT INTO url(url, alias, user_id, expires_at, max_clicks, created_at) VALUES(?,?,?,?,?,?)")
+	if err != nil {
+		return nil, fmt.Errorf("%s: prepare statement: %w", op, err)
+	}
+	defer stmt.Close()
+
+	results := make([]models.SavedURL, len(urls))
+	failed := false
+	now := time.Now().UTC()
+
+	for i, u := range urls {
+		var expires any
+		if u.ExpiresAt != nil {
+			expires = u.ExpiresAt.UTC()
+		}
+
+		res, err := stmt.ExecContext(ctx, u.URL, u.Alias, userID, expires, u.MaxClicks, now)
+		if err != nil {
+			var sqliteErr sqlite3.Error
+			if errors.As(err, &sqliteErr) && errors.Is(sqliteErr.ExtendedCode, sqlite3.ErrConstraintUnique) {
+				results[i].Err = storage.ErrURLExists
+				failed = true
+				continue
+			}
+			return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+		}
+
+		if results[i].ID, err = res.LastInsertId(); err != nil {
+			return nil, fmt.Errorf("%s: failed to get last insert id: %w", op, err)
+		}
+	}
+
+	if atomic && failed {
+		return results, fmt.Errorf("%s: %w", op, storage.ErrBatchRejected)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// GetURL возвращает ссылку по алиасу. Для истекшей или исчерпавшей лимит
+// ссылки возвращает storage.ErrURLExpired. Переход не списывает: это делает
+// CountClick, когда редирект действительно выполняется.
+func (s *Storage) GetURL(ctx context.Context, alias string) (*models.URL, error) {
 	const op = "storage.sqlite.GetURL"
 
-	stmt, err := s.db.Prepare("SELECT url FROM url WHERE alias = ?")
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	u, err := s.ResolveURL(ctx, alias)
 	if err != nil {
-		return "", fmt.Errorf("%s: prepare statement: %w", op, err)
+		return nil, err
+	}
+
+	if u.Expired(time.Now()) {
+		return nil, storage.ErrURLExpired
+	}
+
+	return u, nil
+}
+
+// ResolveURL возвращает ссылку по алиасу без проверки срока и лимита
+func (s *Storage) ResolveURL(ctx context.Context, alias string) (*models.URL, error) {
+	const op = "storage.sqlite.ResolveURL"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	stmt, err := s.db.PrepareContext(ctx, "SELECT "+urlColumns+" FROM url WHERE alias = ?")
+	if err != nil {
+		return nil, fmt.Errorf("%s: prepare statement: %w", op, err)
 	}
 	defer stmt.Close()
 
-	var resURL string
-	err = stmt.QueryRow(alias).Scan(&resURL)
+	u, err := scanURL(stmt.QueryRowContext(ctx, alias))
 	if errors.Is(err, sql.ErrNoRows) {
-		return "", storage.ErrURLNotFound
+		return nil, storage.ErrURLNotFound
 	}
 	if err != nil {
-		return "", fmt.Errorf("%s: execute statement: %w", op, err)
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
 	}
-	return resURL, nil
+
+	return u, nil
+}
+
+// CountClick списывает переход у ссылки с лимитом. Последний переход
+// записывает exhausted_at, от которого считается хранение исчерпанной ссылки.
+func (s *Storage) CountClick(ctx context.Context, urlID int64) error {
+	const op = "storage.sqlite.CountClick"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE url SET click_count = click_count + 1,
+		               exhausted_at = CASE WHEN click_count + 1 >= max_clicks THEN ? END
+		WHERE id = ? AND click_count < max_clicks
+	`, time.Now().UTC(), urlID)
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: rows affected: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrURLExpired
+	}
+
+	return nil
+}
+
+// GetURLsByUserID возвращает ссылки пользователя, новые первыми
+func (s *Storage) GetURLsByUserID(ctx context.Context, userID int64, page models.Page) ([]*models.URL, *models.Cursor, error) {
+	const op = "storage.sqlite.GetURLsByUserID"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	query := `SELECT ` + urlColumns + ` FROM url WHERE user_id = ?`
+	args := []any{userID}
+	if page.After != nil {
+		// время хранится текстом в UTC, сравниваем в том же виде
+		query += ` AND (created_at, id) < (?, ?)`
+		args = append(args, page.After.Time.UTC(), page.After.ID)
+	}
+	query += ` ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+	args = append(args, page.Fetch(), page.SkipRows())
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	defer rows.Close()
+
+	var urls []*models.URL
+	for rows.Next() {
+		u, err := scanURL(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		urls = append(urls, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: rows: %w", op, err)
+	}
+
+	urls, next := models.NextPage(urls, page, func(u *models.URL) models.Cursor {
+		return models.NewestCursor(u.CreatedAt, u.ID)
+	})
+
+	return urls, next, nil
+}
+
+// UpdateURL меняет адрес ссылки владельца
+func (s *Storage) UpdateURL(ctx context.Context, alias string, userID int64, newURL string) (*models.URL, error) {
+	const op = "storage.sqlite.UpdateURL"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	res, err := s.db.ExecContext(ctx, "UPDATE url SET url = ? WHERE alias = ? AND user_id = ?", newURL, alias, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("%s: rows affected: %w", op, err)
+	}
+	if affected == 0 {
+		return nil, storage.ErrURLNotFound
+	}
+
+	row := s.db.QueryRowContext(ctx, "SELECT "+urlColumns+" FROM url WHERE alias = ?", alias)
+	u, err := scanURL(row)
+	if err != nil {
+		return nil, fmt.Errorf("%s: scan: %w", op, err)
+	}
+
+	return u, nil
+}
+
+// DeleteURL удаляет ссылку владельца
+func (s *Storage) DeleteURL(ctx context.Context, alias string, userID int64) error {
+	const op = "storage.sqlite.DeleteURL"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	res, err := s.db.ExecContext(ctx, "DELETE FROM url WHERE alias = ? AND user_id = ?", alias, userID)
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: rows affected: %w", op, err)
+	}
+	if affected == 0 {
+		return storage.ErrURLNotFound
+	}
+
+	return nil
+}
+
+// statsTopN сколько значений отдавать в разрезах статистики
+const statsTopN = 10
+
+// sqliteBucketFormats форматы strftime для интервалов статистики
+var sqliteBucketFormats = map[string]string{
+	models.StatsBucketHour: "%Y-%m-%d %H:00:00",
+	models.StatsBucketDay:  "%Y-%m-%d 00:00:00",
+}
+
+// SaveClicks записывает батч переходов в одной транзакции
+func (s *Storage) SaveClicks(ctx context.Context, clicks []models.Click) error {
+	const op = "storage.sqlite.SaveClicks"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: begin: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO url_clicks(url_id, clicked_at, referrer, user_agent, region)
+		SELECT id, ?, ?, ?, ? FROM url WHERE alias = ?
+	`)
+	if err != nil {
+		return fmt.Errorf("%s: prepare statement: %w", op, err)
+	}
+	defer stmt.Close()
+
+	for _, c := range clicks {
+		if _, err := stmt.ExecContext(ctx, c.ClickedAt.UTC(), c.Referrer, c.UserAgent, c.Region, c.Alias); err != nil {
+			return fmt.Errorf("%s: execute statement: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetURLStats возвращает статистику переходов по ссылке владельца за [from, to)
+func (s *Storage) GetURLStats(ctx context.Context, alias string, userID int64, from, to time.Time, bucket string) (*models.URLStats, error) {
+	const op = "storage.sqlite.GetURLStats"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	bucketFormat, ok := sqliteBucketFormats[bucket]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown bucket %q", op, bucket)
+	}
+
+	var urlID int64
+	err := s.db.QueryRowContext(ctx, "SELECT id FROM url WHERE alias = ? AND user_id = ?", alias, userID).Scan(&urlID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrURLNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	from, to = from.UTC(), to.UTC()
+	stats := &models.URLStats{
+		Alias:  alias,
+		From:   from,
+		To:     to,
+		Bucket: bucket,
+	}
+
+	err = s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM url_clicks WHERE url_id = ? AND clicked_at >= ? AND clicked_at < ?",
+		urlID, from, to,
+	).Scan(&stats.Total)
+	if err != nil {
+		return nil, fmt.Errorf("%s: total: %w", op, err)
+	}
+
+	// Имена колонок фиксированы и не приходят из запроса
+	for column, dst := range map[string]*[]models.ClickCount{
+		"referrer":   &stats.Referrers,
+		"user_agent": &stats.UserAgents,
+		"region":     &stats.Regions,
+	} {
+		counts, err := s.countClicksBy(ctx, column, urlID, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", op, column, err)
+		}
+		*dst = counts
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT strftime(?, clicked_at) AS bucket, COUNT(*)
+		FROM url_clicks
+		WHERE url_id = ? AND clicked_at >= ? AND clicked_at < ?
+		GROUP BY bucket
+		ORDER BY bucket
+	`, bucketFormat, urlID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: series: %w", op, err)
+	}
+	defer rows.Close()
+
+	stats.Series = []models.ClickPoint{}
+	for rows.Next() {
+		var (
+			raw string
+			p   models.ClickPoint
+		)
+		if err := rows.Scan(&raw, &p.Clicks); err != nil {
+			return nil, fmt.Errorf("%s: scan series: %w", op, err)
+		}
+		if p.Time, err = time.Parse(time.DateTime, raw); err != nil {
+			return nil, fmt.Errorf("%s: parse bucket: %w", op, err)
+		}
+		stats.Series = append(stats.Series, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: series: %w", op, err)
+	}
+
+	return stats, nil
+}
+
+func (s *Storage) countClicksBy(ctx context.Context, column string, urlID int64, from, to time.Time) ([]models.ClickCount, error) {
+	rows, err := s.db.QueryContext(ctx,
+		fmt.Sprintf(`
+			SELECT %[1]s, COUNT(*) AS clicks
+			FROM url_clicks
+			WHERE url_id = ? AND clicked_at >= ? AND clicked_at < ?
+			GROUP BY %[1]s
+			ORDER BY clicks DESC, %[1]s
+			LIMIT ?`, column),
+		urlID, from, to, statsTopN,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []models.ClickCount{}
+	for rows.Next() {
+		var c models.ClickCount
+		if err := rows.Scan(&c.Key, &c.Clicks); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+
+	return counts, rows.Err()
+}
+
+// DeleteExpiredURLs удаляет ссылки, срок действия которых истек или лимит
+// переходов исчерпан раньше before
+func (s *Storage) DeleteExpiredURLs(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.sqlite.DeleteExpiredURLs"
+
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	// Статистику удаляем явно: внешние ключи в SQLite по умолчанию выключены
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM url_clicks
+		WHERE url_id IN (SELECT id FROM url WHERE expires_at < ? OR exhausted_at < ?)
+	`, before.UTC(), before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("%s: delete clicks: %w", op, err)
+	}
+
+	res, err := s.db.ExecContext(ctx, "DELETE FROM url WHERE expires_at < ? OR exhausted_at < ?", before.UTC(), before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: rows affected: %w", op, err)
+	}
+
+	return affected, nil
+}
+
+// urlColumns колонки url в порядке, который ожидает scanURL
+const urlColumns = "id, alias, url, user_id, expires_at, max_clicks, click_count, created_at"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanURL читает ссылку; у записей старого формата created_at может быть пустым
+func scanURL(row rowScanner) (*models.URL, error) {
+	var (
+		u         models.URL
+		userID    sql.NullInt64
+		expiresAt sql.NullTime
+		maxClicks sql.NullInt64
+		createdAt sql.NullTime
+	)
+	if err := row.Scan(&u.ID, &u.Alias, &u.URL, &userID, &expiresAt, &maxClicks, &u.ClickCount, &createdAt); err != nil {
+		return nil, err
+	}
+	if userID.Valid {
+		u.UserID = &userID.Int64
+	}
+	if expiresAt.Valid {
+		u.ExpiresAt = &expiresAt.Time
+	}
+	if maxClicks.Valid {
+		limit := int(maxClicks.Int64)
+		u.MaxClicks = &limit
+	}
+	u.CreatedAt = createdAt.Time
+
+	return &u, nil
 }
 
 // CreateUser создает нового пользователя
-func (s *Storage) CreateUser(email, name, passwordHash string) (*models.User, error) {
+func (s *Storage) CreateUser(ctx context.Context, email, name, passwordHash string) (*models.User, error) {
 	const op = "storage.sqlite.CreateUser"
 
-	stmt, err := s.db.Prepare(`
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	stmt, err := s.db.PrepareContext(ctx, `
 		INSERT INTO users(email, name, password_hash, created_at, updated_at) 
 		VALUES(?, ?, ?, ?, ?)
 	`)
@@ -115,7 +639,7 @@ func (s *Storage) CreateUser(email, name, passwordHash string) (*models.User, er
 	defer stmt.Close()
 
 	now := time.Now()
-	res, err := stmt.Exec(email, name, passwordHash, now, now)
+	res, err := stmt.ExecContext(ctx, email, name, passwordHash, now, now)
 	if err != nil {
 		var sqliteErr sqlite3.Error
 		if errors.As(err, &sqliteErr) && errors.Is(sqliteErr.ExtendedCode, sqlite3.ErrConstraintUnique) {
@@ -140,10 +664,13 @@ func (s *Storage) CreateUser(email, name, passwordHash string) (*models.User, er
 }
 
 // GetUserByEmail возвращает пользователя по email
-func (s *Storage) GetUserByEmail(email string) (*models.User, error) {
+func (s *Storage) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	const op = "storage.sqlite.GetUserByEmail"
 
-	stmt, err := s.db.Prepare(`
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	stmt, err := s.db.PrepareContext(ctx, `
 		SELECT id, email, name, password_hash, created_at, updated_at 
 		FROM users WHERE email = ?
 	`)
@@ -153,7 +680,7 @@ func (s *Storage) GetUserByEmail(email string) (*models.User, error) {
 	defer stmt.Close()
 
 	var user models.User
-	err = stmt.QueryRow(email).Scan(
+	err = stmt.QueryRowContext(ctx, email).Scan(
 		&user.ID,
 		&user.Email,
 		&user.Name,
@@ -172,10 +699,13 @@ func (s *Storage) GetUserByEmail(email string) (*models.User, error) {
 }
 
 // GetUserByID возвращает пользователя по ID
-func (s *Storage) GetUserByID(id int64) (*models.User, error) {
+func (s *Storage) GetUserByID(ctx context.Context, id int64) (*models.User, error) {
 	const op = "storage.sqlite.GetUserByID"
 
-	stmt, err := s.db.Prepare(`
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
+	stmt, err := s.db.PrepareContext(ctx, `
 		SELECT id, email, name, password_hash, created_at, updated_at 
 		FROM users WHERE id = ?
 	`)
@@ -185,7 +715,7 @@ func (s *Storage) GetUserByID(id int64) (*models.User, error) {
 	defer stmt.Close()
 
 	var user models.User
-	err = stmt.QueryRow(id).Scan(
+	err = stmt.QueryRowContext(ctx, id).Scan(
 		&user.ID,
 		&user.Email,
 		&user.Name,
@@ -204,11 +734,14 @@ func (s *Storage) GetUserByID(id int64) (*models.User, error) {
 }
 
 // EmailExists проверяет существование пользователя с данным email
-func (s *Storage) EmailExists(email string) (bool, error) {
+func (s *Storage) EmailExists(ctx context.Context, email string) (bool, error) {
 	const op = "storage.sqlite.EmailExists"
 
+	ctx, span := startSpan(ctx, op)
+	defer span.End()
+
 	var exists int
-	err := s.db.QueryRow("SELECT 1 FROM users WHERE email = ? LIMIT 1", email).Scan(&exists)
+	err := s.db.QueryRowContext(ctx, "SELECT 1 FROM users WHERE email = ? LIMIT 1", email).Scan(&exists)
 	if errors.Is(err, sql.ErrNoRows) {
 		return false, nil
 	}
@@ -0,0 +1,81 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+)
+
+func newStorage(t *testing.T) *Storage {
+	t.Helper()
+
+	s, err := New(filepath.Join(t.TempDir(), "storage.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { s.db.Close() })
+
+	return s
+}
+
+func TestDeleteExpiredURLs(t *testing.T) {
+	s := newStorage(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	one, two := 1, 2
+	past := now.Add(-2 * time.Hour)
+	future := now.Add(2 * time.Hour)
+
+	links := []struct {
+		alias     string     // Алиас ссылки
+		expiresAt *time.Time // Срок действия
+		maxClicks *int       // Лимит переходов
+		clicks    int        // Сколько переходов засчитать
+		deleted   bool       // Удаляется ли ссылка после хранения
+	}{
+		{alias: "plain"},
+		{alias: "expired", expiresAt: &past, deleted: true},
+		{alias: "active", expiresAt: &future},
+		{alias: "exhausted", maxClicks: &one, clicks: 1, deleted: true},
+		{alias: "clicks-left", maxClicks: &two, clicks: 1},
+	}
+
+	for _, l := range links {
+		id, err := s.SaveURL(ctx, "https://example.com/"+l.alias, l.alias, 1, l.expiresAt, l.maxClicks)
+		require.NoError(t, err)
+		for i := 0; i < l.clicks; i++ {
+			require.NoError(t, s.CountClick(ctx, id))
+		}
+	}
+
+	// Лишний переход по исчерпанной ссылке не продлевает ее хранение
+	exhausted, err := s.ResolveURL(ctx, "exhausted")
+	require.NoError(t, err)
+	require.True(t, errors.Is(s.CountClick(ctx, exhausted.ID), storage.ErrURLExpired))
+
+	// Пока хранение не прошло, исчерпанная ссылка остается и отвечает 410
+	n, err := s.DeleteExpiredURLs(ctx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	_, err = s.ResolveURL(ctx, "exhausted")
+	require.NoError(t, err)
+
+	n, err = s.DeleteExpiredURLs(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	for _, l := range links {
+		_, err := s.ResolveURL(ctx, l.alias)
+		if l.deleted {
+			require.ErrorIs(t, err, storage.ErrURLNotFound, l.alias)
+		} else {
+			require.NoError(t, err, l.alias)
+		}
+	}
+}
@@ -5,11 +5,37 @@ import "errors"
 var (
 	ErrURLNotFound         = errors.New("url not found")
 	ErrURLExists           = errors.New("url exists")
+	ErrURLExpired          = errors.New("url expired")
+	ErrBatchRejected       = errors.New("batch rejected")
 	ErrUserNotFound        = errors.New("user not found")
 	ErrUserExists          = errors.New("user with this email already exists")
 	ErrEventNotFound       = errors.New("event not found")
+	ErrCapacityBelowSold   = errors.New("capacity is below sold tickets")
+	ErrSeatedCapacity      = errors.New("capacity of a seated event follows its seat map")
 	ErrBookingNotFound     = errors.New("booking not found")
 	ErrBookingExists       = errors.New("booking already exists")
+	ErrBookingCancelled    = errors.New("booking already cancelled")
+	ErrBookingNotPending   = errors.New("booking is not a pending hold")
+	ErrHoldExpired         = errors.New("booking hold expired")
 	ErrNoTickets           = errors.New("no available tickets")
 	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrVenueNotFound       = errors.New("venue not found")
+	ErrVenueExists         = errors.New("venue with this name already exists")
+	ErrSeatMapNotFound     = errors.New("seat map not found")
+	ErrSeatPrices          = errors.New("seat prices do not match seat map categories")
+	ErrSeatsRequired       = errors.New("event has assigned seats")
+	ErrSeatNotFound        = errors.New("seat not found")
+	ErrSeatTaken           = errors.New("seat already taken")
+	ErrWaitlistNotFound    = errors.New("waitlist entry not found")
+	ErrWaitlistExists      = errors.New("already on the waitlist")
+	ErrTicketsAvailable    = errors.New("tickets are available")
+	ErrTransferNotFound    = errors.New("transfer not found")
+	ErrTransferExists      = errors.New("booking already has a pending transfer")
+	ErrTransferNotPending  = errors.New("transfer is not pending")
+	ErrTransferToSelf      = errors.New("cannot transfer a booking to yourself")
+	ErrTransferQuantity    = errors.New("transfer quantity exceeds booking quantity")
+	ErrNotTransferable     = errors.New("only confirmed bookings can be transferred")
+	ErrSavedSearchNotFound = errors.New("saved search not found")
+	ErrSavedSearchLimit    = errors.New("saved searches limit reached")
+	ErrSchemaOutdated      = errors.New("database schema is outdated")
 )
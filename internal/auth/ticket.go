@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"API/internal/models"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ticketPrefix версия формата подписанного билета
+const ticketPrefix = "T1"
+
+var (
+	ErrInvalidTicket = errors.New("invalid ticket signature")
+	// ErrTicketRevoked подпись верна, но билет выпущен на прежнее состояние
+	// брони: после передачи код брони перевыпускается
+	ErrTicketRevoked = errors.New("ticket revoked")
+)
+
+// TicketClaims данные билета в QR коде. Поля короткие, чтобы QR
+// оставался читаемым при печати.
+type TicketClaims struct {
+	BookingID   int64  `json:"b"`
+	BookingCode string `json:"c"`
+	EventID     int64  `json:"e"`
+	Quantity    int    `json:"q"`
+	IssuedAt    int64  `json:"iat"`
+}
+
+// TicketSigner подписывает билеты ed25519. Подпись проверяется публичным
+// ключом без обращения к API, отзыв после передачи — через TicketClaims.Matches.
+type TicketSigner struct {
+	key ed25519.PrivateKey
+}
+
+// NewTicketSigner создает подписчика из seed ключа в base64 (32 байта)
+func NewTicketSigner(seedBase64 string) (*TicketSigner, error) {
+	const op = "auth.NewTicketSigner"
+
+	seed, err := base64.StdEncoding.DecodeString(seedBase64)
+	if err != nil {
+		return nil, fmt.Errorf("%s: decode seed: %w", op, err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%s: seed must be %d bytes, got %d", op, ed25519.SeedSize, len(seed))
+	}
+
+	return &TicketSigner{key: ed25519.NewKeyFromSeed(seed)}, nil
+}
+
+// PublicKey публичный ключ для проверки билетов
+func (s *TicketSigner) PublicKey() ed25519.PublicKey {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// Sign возвращает подписанный билет вида T1.<claims>.<signature>
+func (s *TicketSigner) Sign(b *models.Booking) (string, error) {
+	const op = "auth.TicketSigner.Sign"
+
+	claims, err := json.Marshal(TicketClaims{
+		BookingID:   b.ID,
+		BookingCode: b.BookingCode,
+		EventID:     b.EventID,
+		Quantity:    b.Quantity,
+		IssuedAt:    time.Now().Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	signed := ticketPrefix + "." + base64.RawURLEncoding.EncodeToString(claims)
+	sig := ed25519.Sign(s.key, []byte(signed))
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// Verify проверяет подпись билета своим публичным ключом
+func (s *TicketSigner) Verify(ticket string) (*TicketClaims, error) {
+	return VerifyTicket(s.PublicKey(), ticket)
+}
+
+// VerifyTicket проверяет подпись билета и возвращает его данные
+func VerifyTicket(publicKey ed25519.PublicKey, ticket string) (*TicketClaims, error) {
+	parts := strings.Split(ticket, ".")
+	if len(parts) != 3 || parts[0] != ticketPrefix {
+		return nil, ErrInvalidTicket
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidTicket
+	}
+
+	signed := parts[0] + "." + parts[1]
+	if !ed25519.Verify(publicKey, []byte(signed), sig) {
+		return nil, ErrInvalidTicket
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidTicket
+	}
+
+	var claims TicketClaims
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return nil, ErrInvalidTicket
+	}
+
+	return &claims, nil
+}
+
+// Matches сверяет билет с текущим состоянием брони. Подпись доказывает
+// только то, что билет когда-то выдан; передача перевыпускает код брони
+// и меняет количество билетов, поэтому старый QR код здесь не проходит.
+func (c *TicketClaims) Matches(b *models.Booking) error {
+	if c.BookingID != b.ID || c.EventID != b.EventID ||
+		c.BookingCode != b.BookingCode || c.Quantity != b.Quantity {
+		return ErrTicketRevoked
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"API/internal/models"
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSigner(t *testing.T) *TicketSigner {
+	t.Helper()
+
+	signer, err := NewTicketSigner(base64.StdEncoding.EncodeToString(make([]byte, ed25519.SeedSize)))
+	require.NoError(t, err)
+
+	return signer
+}
+
+func TestTicketSignVerify(t *testing.T) {
+	signer := newTestSigner(t)
+
+	ticket, err := signer.Sign(&models.Booking{ID: 7, EventID: 3, Quantity: 2, BookingCode: "BK-ABCDEF"})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(ticket, "T1."))
+
+	claims, err := VerifyTicket(signer.PublicKey(), ticket)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), claims.BookingID)
+	require.Equal(t, "BK-ABCDEF", claims.BookingCode)
+	require.Equal(t, 2, claims.Quantity)
+}
+
+func TestTicketTampered(t *testing.T) {
+	signer := newTestSigner(t)
+
+	ticket, err := signer.Sign(&models.Booking{ID: 7, Quantity: 1, BookingCode: "BK-ABCDEF"})
+	require.NoError(t, err)
+
+	// Подменяем данные билета, оставляя старую подпись
+	forged, err := signer.Sign(&models.Booking{ID: 7, Quantity: 10, BookingCode: "BK-ABCDEF"})
+	require.NoError(t, err)
+	parts, forgedParts := strings.Split(ticket, "."), strings.Split(forged, ".")
+	tampered := parts[0] + "." + forgedParts[1] + "." + parts[2]
+
+	_, err = VerifyTicket(signer.PublicKey(), tampered)
+	require.ErrorIs(t, err, ErrInvalidTicket)
+
+	other, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, err = VerifyTicket(other, ticket)
+	require.ErrorIs(t, err, ErrInvalidTicket)
+
+	for _, bad := range []string{"", "T1", "T2.a.b", "T1.!!.!!"} {
+		_, err = VerifyTicket(signer.PublicKey(), bad)
+		require.ErrorIs(t, err, ErrInvalidTicket, bad)
+	}
+}
+
+func TestNewTicketSignerRejectsBadSeed(t *testing.T) {
+	_, err := NewTicketSigner("not base64!")
+	require.Error(t, err)
+
+	_, err = NewTicketSigner(base64.StdEncoding.EncodeToString([]byte("short")))
+	require.Error(t, err)
+}
+
+func TestTicketRevokedAfterTransfer(t *testing.T) {
+	signer := newTestSigner(t)
+
+	booking := &models.Booking{ID: 7, EventID: 3, Quantity: 2, BookingCode: "BK-ABCDEF"}
+	ticket, err := signer.Sign(booking)
+	require.NoError(t, err)
+
+	claims, err := signer.Verify(ticket)
+	require.NoError(t, err)
+	require.NoError(t, claims.Matches(booking))
+
+	// Передача перевыпускает код: подпись старого билета верна, но он отозван
+	transferred := *booking
+	transferred.BookingCode = "BK-GHIJKL"
+	require.ErrorIs(t, claims.Matches(&transferred), ErrTicketRevoked)
+
+	// Частичная передача уменьшает количество билетов в брони
+	split := transferred
+	split.Quantity = 1
+	require.ErrorIs(t, claims.Matches(&split), ErrTicketRevoked)
+
+	// Новый QR код проходит проверку
+	reissued, err := signer.Sign(&split)
+	require.NoError(t, err)
+	claims, err = signer.Verify(reissued)
+	require.NoError(t, err)
+	require.NoError(t, claims.Matches(&split))
+}
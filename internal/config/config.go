@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"time"
@@ -9,10 +10,18 @@ import (
 )
 
 type Config struct {
-	Env        string         `yaml:"env" env-default:"development"`
-	Database   DatabaseConfig `yaml:"database"`
-	HTTPServer HTTPServer     `yaml:"http_server"`
-	JWT        JWTConfig      `yaml:"jwt"`
+	Env        string           `yaml:"env" env-default:"development"`
+	Database   DatabaseConfig   `yaml:"database"`
+	HTTPServer HTTPServer       `yaml:"http_server"`
+	Admin      AdminServer      `yaml:"admin_server"`
+	JWT        JWTConfig        `yaml:"jwt"`
+	Tickets    TicketsConfig    `yaml:"tickets"`
+	Bookings   BookingsConfig   `yaml:"bookings"`
+	Health     HealthConfig     `yaml:"health"`
+	Tracing    TracingConfig    `yaml:"tracing"`
+	Analytics  AnalyticsConfig  `yaml:"analytics"`
+	ShortLinks ShortLinksConfig `yaml:"short_links"`
+	Search     SearchConfig     `yaml:"search"`
 }
 
 type DatabaseConfig struct {
@@ -29,12 +38,160 @@ type JWTConfig struct {
 	TokenTTL time.Duration `yaml:"token_ttl" env-default:"24h"`
 }
 
+type TicketsConfig struct {
+	// SigningKey seed ключа ed25519 в base64 (32 байта) для подписи QR кодов билетов.
+	// Сгенерировать: openssl rand -base64 32
+	SigningKey string `yaml:"signing_key" env:"TICKETS_SIGNING_KEY" env-required:"true"`
+}
+
+type BookingsConfig struct {
+	// HoldTTL сколько билеты удерживаются до оплаты
+	HoldTTL time.Duration `yaml:"hold_ttl" env-default:"15m"`
+	// HoldSweepInterval как часто возвращать в продажу неоплаченные удержания
+	HoldSweepInterval time.Duration  `yaml:"hold_sweep_interval" env-default:"30s"`
+	Waitlist          WaitlistConfig `yaml:"waitlist"`
+}
+
+type WaitlistConfig struct {
+	// ClaimWindow сколько предложенные из листа ожидания билеты ждут оплаты
+	ClaimWindow time.Duration `yaml:"claim_window" env-default:"30m"`
+	// OfferInterval как часто раздавать листу ожидания вернувшиеся билеты;
+	// пока очередь ждет, обычная продажа этих билетов закрыта
+	OfferInterval time.Duration `yaml:"offer_interval" env-default:"15s"`
+}
+
 type HTTPServer struct {
 	Address     string        `yaml:"address" env-default:"0.0.0.0:8080"`
 	Timeout     time.Duration `yaml:"timeout" env-default:"5s"`
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
-	User        string        `yaml:"user" env-required:"true"`
-	Password    string        `yaml:"password" env-required:"true" env:"HTTP_SERVER_PASSWORD"`
+	// ShutdownTimeout время на завершение активных запросов при остановке
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env-default:"15s"`
+	// LegacyRedirects редиректы со старых путей без версии на /api/v1
+	LegacyRedirects LegacyRedirects `yaml:"legacy_redirects"`
+	User            string          `yaml:"user" env-required:"true"`
+	Password        string          `yaml:"password" env-required:"true" env:"HTTP_SERVER_PASSWORD"`
+}
+
+type LegacyRedirects struct {
+	Enabled bool `yaml:"enabled" env:"LEGACY_REDIRECTS_ENABLED" env-default:"true"`
+	// Sunset дата отключения редиректов (YYYY-MM-DD), отдается в заголовке Sunset
+	Sunset string `yaml:"sunset" env:"LEGACY_REDIRECTS_SUNSET"`
+}
+
+// SunsetTime возвращает дату отключения редиректов, если она задана
+func (l LegacyRedirects) SunsetTime() (time.Time, error) {
+	if l.Sunset == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.DateOnly, l.Sunset)
+}
+
+// AdminServer служебный listener для /metrics, недоступный снаружи
+type AdminServer struct {
+	Address string `yaml:"address" env:"ADMIN_SERVER_ADDRESS" env-default:"0.0.0.0:9090"`
+}
+
+type HealthConfig struct {
+	// CheckTimeout общий таймаут проверок зависимостей в /readyz
+	CheckTimeout time.Duration `yaml:"check_timeout" env-default:"2s"`
+	// DrainDelay пауза между снятием готовности и остановкой сервера,
+	// чтобы балансировщик успел перестать слать трафик
+	DrainDelay time.Duration `yaml:"drain_delay" env-default:"5s"`
+}
+
+type TracingConfig struct {
+	// Exporter куда отправлять трейсы: otlp, stdout или none
+	Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"none"`
+	Endpoint    string  `yaml:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT" env-default:"localhost:4318"`
+	Insecure    bool    `yaml:"insecure" env:"TRACING_INSECURE" env-default:"true"`
+	ServiceName string  `yaml:"service_name" env:"OTEL_SERVICE_NAME" env-default:"events-api"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+}
+
+type AnalyticsConfig struct {
+	// QueueSize размер буфера переходов; при переполнении переходы отбрасываются,
+	// чтобы не задерживать редирект
+	QueueSize int `yaml:"queue_size" env-default:"10000"`
+	// BatchSize сколько переходов записывать в базу за раз
+	BatchSize int `yaml:"batch_size" env-default:"500"`
+	// FlushInterval как часто записывать неполный батч
+	FlushInterval time.Duration `yaml:"flush_interval" env-default:"2s"`
+	// RegionHeader заголовок с кодом страны клиента, который выставляет edge-прокси
+	RegionHeader string `yaml:"region_header" env:"ANALYTICS_REGION_HEADER" env-default:"CF-IPCountry"`
+}
+
+type SearchConfig struct {
+	// SuggestTimeout бюджет подсказок /search/suggest: не успевшие
+	// подсказки отдаются пустыми, чтобы не задерживать ввод
+	SuggestTimeout time.Duration     `yaml:"suggest_timeout" env-default:"150ms"`
+	Saved          SavedSearchConfig `yaml:"saved"`
+}
+
+type SavedSearchConfig struct {
+	// MaxPerUser сколько поисков может сохранить один пользователь
+	MaxPerUser int `yaml:"max_per_user" env-default:"20"`
+	// QueueSize очередь новых мероприятий на проверку; при переполнении
+	// создание мероприятия ждет, пока очередь освободится
+	QueueSize int `yaml:"queue_size" env-default:"1000"`
+	// NotifyLimit сколько уведомлений о новых мероприятиях пользователь
+	// получает за NotifyWindow, остальные отбрасываются
+	NotifyLimit  int           `yaml:"notify_limit" env-default:"10"`
+	NotifyWindow time.Duration `yaml:"notify_window" env-default:"1h"`
+}
+
+type ShortLinksConfig struct {
+	// BaseURL публичный адрес сокращателя для QR кодов, например https://sho.rt.
+	// Если пустой, берется из запроса.
+	BaseURL string `yaml:"base_url" env:"SHORT_LINKS_BASE_URL"`
+	// SweepInterval как часто удалять истекшие ссылки
+	SweepInterval time.Duration `yaml:"sweep_interval" env-default:"1h"`
+	// ExpiredRetention сколько хранить истекшую или исчерпавшую max_clicks ссылку:
+	// пока она есть, редирект отвечает 410, а владелец видит статистику
+	ExpiredRetention time.Duration `yaml:"expired_retention" env-default:"720h"`
+	// MaxBatchSize сколько ссылок можно создать одним запросом POST /url/batch
+	MaxBatchSize int             `yaml:"max_batch_size" env-default:"1000"`
+	Alias        AliasConfig     `yaml:"alias"`
+	Cache        URLCacheConfig  `yaml:"cache"`
+	Policy       URLPolicyConfig `yaml:"policy"`
+}
+
+// URLPolicyConfig какие адреса можно сокращать. Файлы списков содержат
+// по домену на строку, домен совпадает вместе с поддоменами.
+type URLPolicyConfig struct {
+	AllowedSchemes []string `yaml:"allowed_schemes" env-default:"http,https"`
+	// OwnHosts хосты самого сокращателя, ссылки на них запрещены как петли
+	OwnHosts []string `yaml:"own_hosts" env:"URL_POLICY_OWN_HOSTS"`
+	// BlocklistFile домены, которые нельзя сокращать
+	BlocklistFile string `yaml:"blocklist_file" env:"URL_POLICY_BLOCKLIST_FILE"`
+	// AllowlistFile если задан, сокращать можно только эти домены
+	AllowlistFile string `yaml:"allowlist_file" env:"URL_POLICY_ALLOWLIST_FILE"`
+	// WarnlistFile домены, перед переходом на которые показывается предупреждение
+	WarnlistFile string `yaml:"warnlist_file" env:"URL_POLICY_WARNLIST_FILE"`
+	// BlockInternalHosts запрещает localhost, приватные и link-local адреса
+	BlockInternalHosts bool `yaml:"block_internal_hosts" env-default:"true"`
+}
+
+type URLCacheConfig struct {
+	Enabled bool `yaml:"enabled" env:"URL_CACHE_ENABLED" env-default:"true"`
+	// Size максимальное число алиасов в кэше
+	Size int           `yaml:"size" env-default:"10000"`
+	TTL  time.Duration `yaml:"ttl" env-default:"5m"`
+	// NegativeTTL сколько помнить, что алиаса нет. Держим коротким: ссылку,
+	// созданную на другом инстансе, здесь увидят не позже чем через NegativeTTL
+	NegativeTTL time.Duration `yaml:"negative_ttl" env-default:"30s"`
+	// StatsInterval как часто писать в лог долю попаданий, 0 отключает
+	StatsInterval time.Duration `yaml:"stats_interval" env-default:"5m"`
+}
+
+type AliasConfig struct {
+	// Alphabet символы сгенерированных алиасов: латиница, цифры, '-' и '_'
+	Alphabet string `yaml:"alphabet" env-default:"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"`
+	// Length начальная длина сгенерированного алиаса
+	Length int `yaml:"length" env-default:"6"`
+	// MaxLength до какой длины алиас может вырасти при частых коллизиях
+	MaxLength int `yaml:"max_length" env-default:"12"`
+	// Attempts сколько раз пробовать сохранить ссылку при коллизии алиаса
+	Attempts int `yaml:"attempts" env-default:"5"`
 }
 
 func MustLoad() *Config {
@@ -50,5 +207,29 @@ func MustLoad() *Config {
 	if err != nil {
 		log.Fatalf("error reading config file: %s", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid config: %s", err)
+	}
 	return &cfg
 }
+
+// Validate проверяет значения, которые cleanenv не проверяет сам.
+// Интервалы фоновых очисток идут в time.NewTicker, который паникует
+// на неположительном значении, поэтому ошибку показываем при старте.
+func (c *Config) Validate() error {
+	intervals := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"bookings.hold_sweep_interval", c.Bookings.HoldSweepInterval},
+		{"bookings.waitlist.offer_interval", c.Bookings.Waitlist.OfferInterval},
+		{"short_links.sweep_interval", c.ShortLinks.SweepInterval},
+	}
+	for _, i := range intervals {
+		if i.value <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", i.name, i.value)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSweepIntervals(t *testing.T) {
+	valid := func() *Config {
+		var cfg Config
+		cfg.Bookings.HoldSweepInterval = 30 * time.Second
+		cfg.Bookings.Waitlist.OfferInterval = 15 * time.Second
+		cfg.ShortLinks.SweepInterval = time.Hour
+		return &cfg
+	}
+	require.NoError(t, valid().Validate())
+
+	cases := []struct {
+		name   string        // Имя теста
+		breaks func(*Config) // Портит один из интервалов
+		field  string        // Поле, которое должно попасть в ошибку
+	}{
+		{"Zero Hold Sweep", func(c *Config) { c.Bookings.HoldSweepInterval = 0 }, "bookings.hold_sweep_interval"},
+		{"Negative Offer", func(c *Config) { c.Bookings.Waitlist.OfferInterval = -time.Second }, "bookings.waitlist.offer_interval"},
+		{"Zero URL Sweep", func(c *Config) { c.ShortLinks.SweepInterval = 0 }, "short_links.sweep_interval"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := valid()
+			tc.breaks(cfg)
+
+			err := cfg.Validate()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.field)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Prefix общий префикс версионированного JSON API
+const Prefix = "/api"
+
+// reserved первые сегменты путей, которые заняты сервисом.
+// Короткая ссылка с таким алиасом перекрыла бы маршрут или
+// помешала добавить его позже, поэтому такие алиасы запрещены.
+var reserved = map[string]struct{}{
+	"api":      {},
+	"swagger":  {},
+	"docs":     {},
+	"healthz":  {},
+	"readyz":   {},
+	"metrics":  {},
+	"admin":    {},
+	"static":   {},
+	"auth":     {},
+	"events":   {},
+	"profile":  {},
+	"bookings": {},
+	"search":   {},
+	"url":      {},
+	"venues":   {},
+	"v1":       {},
+	"v2":       {},
+}
+
+// IsReserved сообщает, занят ли сегмент пути сервисом
+func IsReserved(segment string) bool {
+	_, ok := reserved[strings.ToLower(segment)]
+	return ok
+}
+
+// LegacyPrefixes пути, по которым API раньше отвечало без версии
+var LegacyPrefixes = []string{"/auth", "/events", "/profile", "/bookings", "/search", "/url"}
+
+// NewLegacyRedirect возвращает хендлер, перенаправляющий старые пути
+// без версии на ту же ручку под /api/{version}.
+// Используется 307, чтобы клиенты сохранили метод и тело запроса.
+func NewLegacyRedirect(version string, sunset time.Time) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		target := Prefix + "/" + version + r.URL.Path
+		if r.URL.RawQuery != "" {
+			target += "?" + r.URL.RawQuery
+		}
+
+		w.Header().Set("Deprecation", "true")
+		if !sunset.IsZero() {
+			w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+		w.Header().Set("Link", "<"+target+">; rel=\"successor-version\"")
+
+		http.Redirect(w, r, target, http.StatusTemporaryRedirect)
+	}
+}
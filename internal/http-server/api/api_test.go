@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegacyRedirect(t *testing.T) {
+	sunset := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	handler := NewLegacyRedirect("v1", sunset)
+
+	req := httptest.NewRequest(http.MethodPost, "/events/42/book?dry_run=1", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+	require.Equal(t, "/api/v1/events/42/book?dry_run=1", rr.Header().Get("Location"))
+	require.Equal(t, "true", rr.Header().Get("Deprecation"))
+	require.Equal(t, "Fri, 01 Jan 2027 00:00:00 GMT", rr.Header().Get("Sunset"))
+}
+
+func TestIsReserved(t *testing.T) {
+	require.True(t, IsReserved("events"))
+	require.True(t, IsReserved("Swagger"))
+	require.False(t, IsReserved("promo2024"))
+}
@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"API/internal/models"
+	"context"
+	"time"
+)
+
+// URLCacheInvalidator сбрасывает закэшированную ссылку после ее изменения
+type URLCacheInvalidator interface {
+	Invalidate(ctx context.Context, alias string)
+}
+
+// invalidatingStorage сбрасывает кэш редиректов после успешного
+// создания, изменения и удаления ссылки. Создание тоже сбрасывает:
+// алиас мог быть закэширован как несуществующий.
+type invalidatingStorage struct {
+	Storage
+	cache URLCacheInvalidator
+}
+
+func (s invalidatingStorage) SaveURL(ctx context.Context, urlToSave, alias string, userID int64, expiresAt *time.Time, maxClicks *int) (int64, error) {
+	id, err := s.Storage.SaveURL(ctx, urlToSave, alias, userID, expiresAt, maxClicks)
+	if err == nil {
+		s.cache.Invalidate(ctx, alias)
+	}
+	return id, err
+}
+
+func (s invalidatingStorage) SaveURLs(ctx context.Context, userID int64, urls []models.NewURL, atomic bool) ([]models.SavedURL, error) {
+	saved, err := s.Storage.SaveURLs(ctx, userID, urls, atomic)
+	if err == nil {
+		for i, u := range urls {
+			if saved[i].Err == nil {
+				s.cache.Invalidate(ctx, u.Alias)
+			}
+		}
+	}
+	return saved, err
+}
+
+func (s invalidatingStorage) UpdateURL(ctx context.Context, alias string, userID int64, newURL string) (*models.URL, error) {
+	u, err := s.Storage.UpdateURL(ctx, alias, userID, newURL)
+	if err == nil {
+		s.cache.Invalidate(ctx, alias)
+	}
+	return u, err
+}
+
+func (s invalidatingStorage) DeleteURL(ctx context.Context, alias string, userID int64) error {
+	err := s.Storage.DeleteURL(ctx, alias, userID)
+	if err == nil {
+		s.cache.Invalidate(ctx, alias)
+	}
+	return err
+}
+
+// EventMatcher проверяет новые мероприятия по сохраненным поискам
+type EventMatcher interface {
+	Enqueue(ctx context.Context, event *models.Event)
+}
+
+// matchingStorage отдает созданные мероприятия на проверку по
+// сохраненным поискам. Проверка идет в фоне; ответ задерживается,
+// только пока очередь проверки полна.
+type matchingStorage struct {
+	Storage
+	matcher EventMatcher
+}
+
+func (s matchingStorage) CreateEvent(ctx context.Context, event *models.Event) (*models.Event, error) {
+	created, err := s.Storage.CreateEvent(ctx, event)
+	if err == nil {
+		s.matcher.Enqueue(ctx, created)
+	}
+	return created, err
+}
+
+// WaitlistOfferer предлагает вернувшиеся билеты листу ожидания мероприятия
+type WaitlistOfferer interface {
+	OfferEvent(ctx context.Context, eventID int64) int64
+}
+
+// offeringStorage сразу предлагает листу ожидания билеты, которые
+// вернулись после отмены брони или увеличения вместимости, не дожидаясь
+// периодического прохода.
+type offeringStorage struct {
+	Storage
+	offerer WaitlistOfferer
+}
+
+func (s offeringStorage) CancelBooking(ctx context.Context, bookingID, userID int64) (*models.Booking, error) {
+	booking, err := s.Storage.CancelBooking(ctx, bookingID, userID)
+	if err == nil {
+		s.offerer.OfferEvent(ctx, booking.EventID)
+	}
+	return booking, err
+}
+
+func (s offeringStorage) UpdateEventCapacity(ctx context.Context, eventID, creatorID int64, capacity int) (*models.Event, error) {
+	event, err := s.Storage.UpdateEventCapacity(ctx, eventID, creatorID, capacity)
+	if err == nil {
+		s.offerer.OfferEvent(ctx, eventID)
+	}
+	return event, err
+}
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/models"
+)
+
+// bookingStorageStub отменяет брони и меняет вместимость без базы.
+// Остальные методы Storage не вызываются.
+type bookingStorageStub struct {
+	Storage
+	err error
+}
+
+func (s bookingStorageStub) CancelBooking(_ context.Context, bookingID, userID int64) (*models.Booking, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &models.Booking{ID: bookingID, UserID: userID, EventID: 7, Status: models.BookingStatusCancelled}, nil
+}
+
+func (s bookingStorageStub) UpdateEventCapacity(_ context.Context, eventID, _ int64, capacity int) (*models.Event, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &models.Event{ID: eventID, Capacity: capacity}, nil
+}
+
+// offererStub запоминает мероприятия, для которых запрошены предложения
+type offererStub struct {
+	events []int64
+}
+
+func (o *offererStub) OfferEvent(_ context.Context, eventID int64) int64 {
+	o.events = append(o.events, eventID)
+	return 1
+}
+
+func TestOfferingStorage(t *testing.T) {
+	offerer := &offererStub{}
+	s := offeringStorage{Storage: bookingStorageStub{}, offerer: offerer}
+
+	_, err := s.CancelBooking(context.Background(), 1, 42)
+	require.NoError(t, err)
+	_, err = s.UpdateEventCapacity(context.Background(), 9, 42, 200)
+	require.NoError(t, err)
+
+	// Отмена предлагает билеты мероприятия брони, вместимость — своего
+	require.Equal(t, []int64{7, 9}, offerer.events)
+}
+
+func TestOfferingStorageSkipsFailures(t *testing.T) {
+	offerer := &offererStub{}
+	s := offeringStorage{Storage: bookingStorageStub{err: storage.ErrBookingCancelled}, offerer: offerer}
+
+	_, err := s.CancelBooking(context.Background(), 1, 42)
+	require.ErrorIs(t, err, storage.ErrBookingCancelled)
+	_, err = s.UpdateEventCapacity(context.Background(), 9, 42, 200)
+	require.Error(t, err)
+
+	require.Empty(t, offerer.events)
+}
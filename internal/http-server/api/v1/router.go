@@ -0,0 +1,231 @@
+// Package v1 собирает маршруты первой версии JSON API.
+//
+// @title Event Booking API
+// @version 1.0
+// @description API для бронирования мероприятий с JWT авторизацией. Включает регистрацию, профиль пользователя, создание мероприятий, бронирование билетов, полнотекстовый поиск и короткие ссылки.
+// @contact.name API Support
+// @contact.email support@example.com
+// @host localhost:8082
+// @BasePath /api/v1
+// @schemes http https
+//
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT токен в формате "Bearer {token}"
+package v1
+
+//go:generate go run github.com/swaggo/swag/cmd/swag@v1.16.3 init -g router.go -d ./,../../handlers,../../../lib/api/response,../../../models --parseInternal -o ../../../../docs/v1 --outputTypes go --instanceName v1 --packageName v1
+
+import (
+	"API/internal/auth"
+	authHandlers "API/internal/http-server/handlers/auth"
+	"API/internal/http-server/handlers/bookings"
+	"API/internal/http-server/handlers/events"
+	"API/internal/http-server/handlers/profile"
+	"API/internal/http-server/handlers/search"
+	"API/internal/http-server/handlers/seats"
+	"API/internal/http-server/handlers/transfers"
+	"API/internal/http-server/handlers/url/list"
+	"API/internal/http-server/handlers/url/qr"
+	"API/internal/http-server/handlers/url/remove"
+	"API/internal/http-server/handlers/url/save"
+	"API/internal/http-server/handlers/url/stats"
+	"API/internal/http-server/handlers/url/update"
+	"API/internal/http-server/handlers/venues"
+	"API/internal/http-server/handlers/waitlist"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/urlpolicy"
+	"time"
+
+	"github.com/go-chi/chi"
+	"golang.org/x/exp/slog"
+)
+
+// Version префикс версии, под которым монтируется роутер
+const Version = "v1"
+
+// Storage методы хранилища, которые использует API v1
+type Storage interface {
+	authHandlers.UserCreator
+	authHandlers.UserGetter
+	profile.ProfileGetter
+	profile.ProfileUpdater
+	profile.BalanceUpdater
+	events.EventCreator
+	events.EventGetter
+	events.CapacityUpdater
+	venues.VenueCreator
+	venues.VenueGetter
+	seats.SeatMapCreator
+	seats.SeatMapLister
+	seats.EventSeatsGetter
+	bookings.BookingCreator
+	bookings.BookingsLister
+	bookings.BookingCanceller
+	bookings.BookingGetter
+	bookings.TicketHolder
+	bookings.BookingConfirmer
+	bookings.EventBookingGetter
+	transfers.TransferCreator
+	transfers.TransferLister
+	transfers.TransferAccepter
+	transfers.TransferDecliner
+	waitlist.WaitlistJoiner
+	waitlist.WaitlistGetter
+	waitlist.WaitlistLeaver
+	search.EventSearcher
+	search.EventSuggester
+	search.SearchSaver
+	search.SavedSearchLister
+	search.SavedSearchDeleter
+	save.URLSaver
+	list.URLLister
+	update.URLUpdater
+	remove.URLRemover
+	stats.URLStatsGetter
+	qr.URLResolver
+}
+
+// Tickets подписывает билеты для QR кодов и проверяет их на входе
+type Tickets interface {
+	bookings.TicketSigner
+	bookings.TicketVerifier
+}
+
+// Deps зависимости и настройки роутера API v1. Новые возможности
+// добавляют поле сюда, а не параметр в NewRouter.
+type Deps struct {
+	Log        *slog.Logger
+	Storage    Storage
+	JWTManager *auth.JWTManager
+	Aliases    save.AliasGenerator
+	Policy     urlpolicy.Checker
+	Tickets    Tickets
+
+	// URLCache nil, если кэш редиректов выключен
+	URLCache URLCacheInvalidator
+	// Matcher nil, если уведомления по сохраненным поискам не нужны
+	Matcher EventMatcher
+	// Offerer nil, если лист ожидания обслуживает только периодический проход
+	Offerer WaitlistOfferer
+
+	// ShortLinkBase адрес сокращателя, от которого строятся короткие ссылки в QR
+	ShortLinkBase string
+	// MaxBatchSize сколько ссылок можно создать одним POST /url/batch
+	MaxBatchSize int
+	// SuggestTimeout сколько ждать подсказки поиска
+	SuggestTimeout time.Duration
+	// MaxSavedSearches сколько поисков может сохранить пользователь
+	MaxSavedSearches int
+	// HoldTTL сколько держится удержание билетов POST /events/{id}/hold до оплаты
+	HoldTTL time.Duration
+}
+
+// NewRouter создает роутер API v1. Роутер не знает, под каким
+// префиксом он смонтирован, поэтому v1 и v2 можно держать рядом.
+func NewRouter(deps Deps) chi.Router {
+	var (
+		log        = deps.Log
+		storage    = deps.Storage
+		jwtManager = deps.JWTManager
+		aliases    = deps.Aliases
+		policy     = deps.Policy
+		tickets    = deps.Tickets
+		urlCache   = deps.URLCache
+		matcher    = deps.Matcher
+		offerer    = deps.Offerer
+	)
+
+	router := chi.NewRouter()
+
+	if urlCache != nil {
+		storage = invalidatingStorage{Storage: storage, cache: urlCache}
+	}
+	if matcher != nil {
+		storage = matchingStorage{Storage: storage, matcher: matcher}
+	}
+	if offerer != nil {
+		storage = offeringStorage{Storage: storage, offerer: offerer}
+	}
+
+	router.Route("/auth", func(r chi.Router) {
+		r.Post("/register", authHandlers.NewRegister(log, storage, jwtManager))
+		r.Post("/login", authHandlers.NewLogin(log, storage, jwtManager))
+	})
+
+	router.Route("/events", func(r chi.Router) {
+		r.Use(authMiddleware.JWTAuth(log, jwtManager))
+		r.Post("/", events.NewCreate(log, storage))
+		r.Get("/", events.NewGetAll(log, storage))
+		r.Get("/{id}", events.NewGetByID(log, storage))
+		r.Get("/{id}/seats", seats.NewEventSeats(log, storage))
+		r.Put("/{id}/capacity", events.NewUpdateCapacity(log, storage))
+		// Бронирование на мероприятие
+		r.Post("/{id}/book", bookings.NewCreate(log, storage))
+		r.Post("/{id}/hold", bookings.NewHold(log, storage, deps.HoldTTL))
+		r.Post("/{id}/waitlist", waitlist.NewJoin(log, storage))
+		r.Get("/{id}/waitlist", waitlist.NewPosition(log, storage))
+		r.Delete("/{id}/waitlist", waitlist.NewLeave(log, storage))
+	})
+
+	router.Route("/venues", func(r chi.Router) {
+		r.Use(authMiddleware.JWTAuth(log, jwtManager))
+		r.Post("/", venues.NewCreate(log, storage))
+		r.Get("/", venues.NewList(log, storage))
+		r.Get("/{id}", venues.NewGetByID(log, storage, storage))
+		r.Post("/{id}/seat-maps", seats.NewCreateMap(log, storage))
+		r.Get("/{id}/seat-maps", seats.NewListMaps(log, storage))
+	})
+
+	router.Route("/profile", func(r chi.Router) {
+		r.Use(authMiddleware.JWTAuth(log, jwtManager))
+		r.Get("/", profile.NewGet(log, storage))
+		r.Put("/", profile.NewUpdate(log, storage))
+		r.Post("/balance", profile.NewTopUpBalance(log, storage))
+	})
+
+	router.Route("/bookings", func(r chi.Router) {
+		r.Use(authMiddleware.JWTAuth(log, jwtManager))
+		r.Get("/", bookings.NewList(log, storage))
+		r.Delete("/{id}", bookings.NewCancel(log, storage))
+		r.Post("/{id}/confirm", bookings.NewConfirm(log, storage))
+		r.Post("/{id}/transfer", transfers.NewCreate(log, storage))
+		r.Get("/{id}/qr", bookings.NewQR(log, storage, tickets))
+	})
+
+	router.Route("/transfers", func(r chi.Router) {
+		r.Use(authMiddleware.JWTAuth(log, jwtManager))
+		r.Get("/", transfers.NewList(log, storage))
+		r.Post("/{id}/accept", transfers.NewAccept(log, storage))
+		r.Post("/{id}/decline", transfers.NewDecline(log, storage))
+	})
+
+	router.Route("/tickets", func(r chi.Router) {
+		r.Use(authMiddleware.JWTAuth(log, jwtManager))
+		r.Post("/verify", bookings.NewVerify(log, tickets, storage))
+	})
+
+	router.Route("/search", func(r chi.Router) {
+		r.Use(authMiddleware.JWTAuth(log, jwtManager))
+		r.Get("/", search.NewSearch(log, storage))
+		r.Get("/suggest", search.NewSuggest(log, storage, deps.SuggestTimeout))
+		r.Post("/saved", search.NewSaveSearch(log, storage, deps.MaxSavedSearches))
+		r.Get("/saved", search.NewSavedSearches(log, storage))
+		r.Delete("/saved/{id}", search.NewDeleteSavedSearch(log, storage))
+	})
+
+	// Роуты с JWT аутентификацией для URL
+	router.Route("/url", func(r chi.Router) {
+		r.Use(authMiddleware.JWTAuth(log, jwtManager))
+		r.Post("/", save.New(log, storage, aliases, policy))
+		r.Post("/batch", save.NewBatch(log, storage, aliases, policy, deps.MaxBatchSize))
+		r.Get("/", list.New(log, storage))
+		r.Patch("/{alias}", update.New(log, storage, policy))
+		r.Delete("/{alias}", remove.New(log, storage))
+		r.Get("/{alias}/stats", stats.New(log, storage))
+		r.Get("/{alias}/qr", qr.New(log, storage, deps.ShortLinkBase))
+	})
+
+	return router
+}
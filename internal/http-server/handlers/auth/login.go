@@ -3,9 +3,12 @@ package auth
 import (
 	"API/internal/Storage"
 	"API/internal/auth"
+	"API/internal/lib/api/request"
 	resp "API/internal/lib/api/response"
 	"API/internal/lib/logger/sl"
+	"API/internal/lib/metrics"
 	"API/internal/models"
+	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -17,7 +20,7 @@ import (
 
 // UserGetter интерфейс для получения пользователя
 type UserGetter interface {
-	GetUserByEmail(email string) (*models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 }
 
 // LoginRequest запрос на авторизацию
@@ -53,58 +56,54 @@ func NewLogin(log *slog.Logger, userGetter UserGetter, jwtManager *auth.JWTManag
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
 		var req LoginRequest
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.DecodeJSON(r, &req)
 		if errors.Is(err, io.EOF) {
 			log.Error("request body is empty")
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("empty request body"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeEmptyBody, "empty request body"))
 			return
 		}
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("failed to decode request"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "failed to decode request"))
 			return
 		}
 
 		// Валидация полей
 		if req.Email == "" || req.Password == "" {
 			log.Error("missing required fields")
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("email and password are required"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeValidationFailed, "email and password are required"))
 			return
 		}
 
 		if !models.IsEmailValid(req.Email) {
 			log.Error("invalid email format")
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("invalid email format"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeValidationFailed, "invalid email format"))
 			return
 		}
 
 		// Получаем пользователя по email
-		user, err := userGetter.GetUserByEmail(req.Email)
+		user, err := userGetter.GetUserByEmail(r.Context(), req.Email)
 		if err != nil {
 			if errors.Is(err, storage.ErrUserNotFound) {
 				log.Info("user not found", slog.String("email", req.Email))
-				w.WriteHeader(http.StatusUnauthorized)
-				render.JSON(w, r, resp.Error("invalid email or password"))
+				metrics.LoginFailures.WithLabelValues("unknown_user").Inc()
+				resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeInvalidCredentials, "invalid email or password"))
 				return
 			}
 			log.Error("failed to get user", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("internal error"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "internal error"))
 			return
 		}
 
 		// Проверяем пароль
 		if !auth.CheckPassword(req.Password, user.PasswordHash) {
 			log.Info("invalid password", slog.String("email", req.Email))
-			w.WriteHeader(http.StatusUnauthorized)
-			render.JSON(w, r, resp.Error("invalid email or password"))
+			metrics.LoginFailures.WithLabelValues("wrong_password").Inc()
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeInvalidCredentials, "invalid email or password"))
 			return
 		}
 
@@ -112,8 +111,7 @@ func NewLogin(log *slog.Logger, userGetter UserGetter, jwtManager *auth.JWTManag
 		token, err := jwtManager.GenerateToken(user.ID, user.Email)
 		if err != nil {
 			log.Error("failed to generate token", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("failed to generate token"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to generate token"))
 			return
 		}
 
@@ -3,9 +3,11 @@ package auth
 import (
 	"API/internal/Storage"
 	"API/internal/auth"
+	"API/internal/lib/api/request"
 	resp "API/internal/lib/api/response"
 	"API/internal/lib/logger/sl"
 	"API/internal/models"
+	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -17,8 +19,8 @@ import (
 
 // UserCreator интерфейс для создания пользователей
 type UserCreator interface {
-	CreateUser(email, name, passwordHash string) (*models.User, error)
-	EmailExists(email string) (bool, error)
+	CreateUser(ctx context.Context, email, name, passwordHash string) (*models.User, error)
+	EmailExists(ctx context.Context, email string) (bool, error)
 }
 
 // RegisterRequest запрос на регистрацию
@@ -56,57 +58,51 @@ func NewRegister(log *slog.Logger, userCreator UserCreator, jwtManager *auth.JWT
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
 		var req RegisterRequest
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.DecodeJSON(r, &req)
 		if errors.Is(err, io.EOF) {
 			log.Error("request body is empty")
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("empty request body"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeEmptyBody, "empty request body"))
 			return
 		}
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("failed to decode request"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "failed to decode request"))
 			return
 		}
 
 		// Валидация полей
 		if req.Email == "" || req.Name == "" || req.Password == "" {
 			log.Error("missing required fields")
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("email, name and password are required"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeValidationFailed, "email, name and password are required"))
 			return
 		}
 
 		if !models.IsEmailValid(req.Email) {
 			log.Error("invalid email format", slog.String("email", req.Email))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("invalid email format"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeValidationFailed, "invalid email format"))
 			return
 		}
 
 		if !models.IsPasswordValid(req.Password) {
 			log.Error("password too short")
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("password must be at least 8 characters"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeValidationFailed, "password must be at least 8 characters"))
 			return
 		}
 
 		// Проверяем, не занят ли email
-		exists, err := userCreator.EmailExists(req.Email)
+		exists, err := userCreator.EmailExists(r.Context(), req.Email)
 		if err != nil {
 			log.Error("failed to check email existence", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("internal error"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "internal error"))
 			return
 		}
 		if exists {
 			log.Info("email already exists", slog.String("email", req.Email))
-			w.WriteHeader(http.StatusConflict)
-			render.JSON(w, r, resp.Error("user with this email already exists"))
+			resp.Render(w, r, http.StatusConflict, resp.Error(resp.CodeUserExists, "user with this email already exists"))
 			return
 		}
 
@@ -114,23 +110,20 @@ func NewRegister(log *slog.Logger, userCreator UserCreator, jwtManager *auth.JWT
 		passwordHash, err := auth.HashPassword(req.Password)
 		if err != nil {
 			log.Error("failed to hash password", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("internal error"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "internal error"))
 			return
 		}
 
 		// Создаем пользователя
-		user, err := userCreator.CreateUser(req.Email, req.Name, passwordHash)
+		user, err := userCreator.CreateUser(r.Context(), req.Email, req.Name, passwordHash)
 		if err != nil {
 			if errors.Is(err, storage.ErrUserExists) {
 				log.Info("user already exists", slog.String("email", req.Email))
-				w.WriteHeader(http.StatusConflict)
-				render.JSON(w, r, resp.Error("user with this email already exists"))
+				resp.Render(w, r, http.StatusConflict, resp.Error(resp.CodeUserExists, "user with this email already exists"))
 				return
 			}
 			log.Error("failed to create user", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("failed to create user"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to create user"))
 			return
 		}
 
@@ -138,14 +131,13 @@ func NewRegister(log *slog.Logger, userCreator UserCreator, jwtManager *auth.JWT
 		token, err := jwtManager.GenerateToken(user.ID, user.Email)
 		if err != nil {
 			log.Error("failed to generate token", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("failed to generate token"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to generate token"))
 			return
 		}
 
 		log.Info("user registered successfully", slog.Int64("user_id", user.ID))
 
-		w.WriteHeader(http.StatusCreated)
+		render.Status(r, http.StatusCreated)
 		render.JSON(w, r, RegisterResponse{
 			Response: resp.OK(),
 			User:     user.ToResponse(),
@@ -1,11 +1,12 @@
 package bookings
 
 import (
-	storage "API/internal/Storage"
 	authMiddleware "API/internal/http-server/middleware/auth"
 	resp "API/internal/lib/api/response"
 	"API/internal/lib/logger/sl"
-	"errors"
+	"API/internal/lib/metrics"
+	"API/internal/models"
+	"context"
 	"net/http"
 	"strconv"
 
@@ -17,12 +18,13 @@ import (
 
 // BookingCanceller интерфейс для отмены бронирования
 type BookingCanceller interface {
-	CancelBooking(bookingID, userID int64) error
+	CancelBooking(ctx context.Context, bookingID, userID int64) (*models.Booking, error)
 }
 
 // NewCancel возвращает хендлер для отмены бронирования
 // @Summary Отменить бронь
-// @Description Отменяет бронирование и возвращает деньги на баланс
+// @Description Отменяет бронирование и возвращает деньги на баланс. Отмена неоплаченного удержания
+// @Description только возвращает билеты в продажу. Ожидающая передача брони отзывается
 // @Tags bookings
 // @Security BearerAuth
 // @Produce json
@@ -31,6 +33,7 @@ type BookingCanceller interface {
 // @Failure 400 {object} resp.Response
 // @Failure 401 {object} resp.Response
 // @Failure 404 {object} resp.Response
+// @Failure 410 {object} resp.Response "Удержание истекло, билеты уже вернулись в продажу"
 // @Failure 500 {object} resp.Response
 // @Router /bookings/{id} [delete]
 func NewCancel(log *slog.Logger, canceller BookingCanceller) http.HandlerFunc {
@@ -40,13 +43,13 @@ func NewCancel(log *slog.Logger, canceller BookingCanceller) http.HandlerFunc {
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
 		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
 		if !ok {
 			log.Error("user_id not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
-			render.JSON(w, r, resp.Error("unauthorized"))
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
 			return
 		}
 
@@ -54,27 +57,24 @@ func NewCancel(log *slog.Logger, canceller BookingCanceller) http.HandlerFunc {
 		bookingID, err := strconv.ParseInt(bookingIDStr, 10, 64)
 		if err != nil {
 			log.Error("invalid booking id", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("invalid booking id"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid booking id"))
 			return
 		}
 
-		err = canceller.CancelBooking(bookingID, userID)
+		booking, err := canceller.CancelBooking(r.Context(), bookingID, userID)
 		if err != nil {
-			if errors.Is(err, storage.ErrBookingNotFound) {
-				log.Error("booking not found", slog.Int64("booking_id", bookingID))
-				w.WriteHeader(http.StatusNotFound)
-				render.JSON(w, r, resp.Error("booking not found"))
-				return
+			if resp.IsKnownStorageError(err) {
+				log.Info("booking not cancelled", sl.Err(err), slog.Int64("booking_id", bookingID))
+			} else {
+				log.Error("failed to cancel booking", sl.Err(err))
 			}
-
-			log.Error("failed to cancel booking", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("failed to cancel booking"))
+			resp.RenderStorageError(w, r, err)
 			return
 		}
 
-		log.Info("booking cancelled", slog.Int64("booking_id", bookingID))
+		metrics.BookingsCancelled.Inc()
+
+		log.Info("booking cancelled", slog.Int64("booking_id", bookingID), slog.Int64("event_id", booking.EventID))
 		render.JSON(w, r, resp.OK())
 	}
 }
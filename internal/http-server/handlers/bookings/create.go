@@ -1,12 +1,13 @@
 package bookings
 
 import (
-	storage "API/internal/Storage"
 	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
 	resp "API/internal/lib/api/response"
 	"API/internal/lib/logger/sl"
+	"API/internal/lib/metrics"
 	"API/internal/models"
-	"errors"
+	"context"
 	"net/http"
 	"strconv"
 
@@ -18,13 +19,18 @@ import (
 )
 
 // BookingCreator интерфейс для создания бронирования
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --all
 type BookingCreator interface {
-	CreateBooking(userID, eventID int64, quantity int) (*models.Booking, error)
+	CreateBooking(ctx context.Context, userID, eventID int64, quantity int) (*models.Booking, error)
+	CreateSeatBooking(ctx context.Context, userID, eventID int64, seatIDs []int64) (*models.Booking, error)
 }
 
-// CreateBookingRequest запрос на создание бронирования
+// CreateBookingRequest запрос на создание бронирования: количество билетов
+// или, на мероприятие со схемой зала, конкретные места
 type CreateBookingRequest struct {
-	Quantity int `json:"quantity" validate:"required,min=1,max=10"`
+	Quantity int     `json:"quantity,omitempty" validate:"required_without=SeatIDs,omitempty,min=1,max=10" example:"2"`
+	SeatIDs  []int64 `json:"seat_ids,omitempty" validate:"omitempty,max=10,unique,dive,min=1" example:"101,102"`
 }
 
 // CreateBookingResponse ответ с данными бронирования
@@ -35,19 +41,22 @@ type CreateBookingResponse struct {
 
 // NewCreate возвращает хендлер для создания бронирования
 // @Summary Забронировать билет
-// @Description Бронирует билет на мероприятие
+// @Description Бронирует билеты на мероприятие. На мероприятие со схемой зала (seat_map_id) бронируются
+// @Description места seat_ids из GET /events/{id}/seats: все сразу или ни одного, если какое-то уже занято
+// @Description Вернувшиеся в продажу билеты сначала предлагаются листу ожидания; если билетов нет,
+// @Description можно встать в очередь через POST /events/{id}/waitlist
 // @Tags bookings
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path int true "ID мероприятия"
-// @Param request body CreateBookingRequest true "Количество билетов"
+// @Param request body CreateBookingRequest true "Количество билетов или места"
 // @Success 201 {object} CreateBookingResponse
 // @Failure 400 {object} resp.Response
 // @Failure 401 {object} resp.Response
 // @Failure 404 {object} resp.Response
-// @Failure 409 {object} resp.Response "Бронирование уже существует"
-// @Failure 422 {object} resp.Response "Недостаточно билетов или баланса"
+// @Failure 409 {object} resp.Response "Бронирование уже существует или место занято"
+// @Failure 422 {object} resp.Response "Недостаточно билетов или баланса; на мероприятие нужно выбрать места"
 // @Failure 500 {object} resp.Response
 // @Router /events/{id}/book [post]
 func NewCreate(log *slog.Logger, creator BookingCreator) http.HandlerFunc {
@@ -57,13 +66,13 @@ func NewCreate(log *slog.Logger, creator BookingCreator) http.HandlerFunc {
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", chimiddleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
 		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
 		if !ok {
 			log.Error("user_id not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
-			render.JSON(w, r, resp.Error("unauthorized"))
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
 			return
 		}
 
@@ -71,67 +80,52 @@ func NewCreate(log *slog.Logger, creator BookingCreator) http.HandlerFunc {
 		eventID, err := strconv.ParseInt(eventIDStr, 10, 64)
 		if err != nil {
 			log.Error("invalid event id", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("invalid event id"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid event id"))
 			return
 		}
 
 		var req CreateBookingRequest
-		if err := render.DecodeJSON(r.Body, &req); err != nil {
+		if err := request.DecodeJSON(r, &req); err != nil {
 			log.Error("failed to decode request", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("invalid request body"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "invalid request body"))
 			return
 		}
 
-		validate := validator.New()
-		if err := validate.Struct(req); err != nil {
+		if err := request.Validate(req); err != nil {
 			validateErr := err.(validator.ValidationErrors)
 			log.Error("validation failed", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.ValidationError(validateErr))
+			resp.Render(w, r, http.StatusBadRequest, resp.ValidationError(validateErr))
 			return
 		}
 
-		booking, err := creator.CreateBooking(userID, eventID, req.Quantity)
+		var booking *models.Booking
+		if len(req.SeatIDs) > 0 {
+			booking, err = creator.CreateSeatBooking(r.Context(), userID, eventID, req.SeatIDs)
+		} else {
+			booking, err = creator.CreateBooking(r.Context(), userID, eventID, req.Quantity)
+		}
 		if err != nil {
-			if errors.Is(err, storage.ErrEventNotFound) {
-				log.Error("event not found", slog.Int64("event_id", eventID))
-				w.WriteHeader(http.StatusNotFound)
-				render.JSON(w, r, resp.Error("event not found"))
-				return
-			}
-			if errors.Is(err, storage.ErrNoTickets) {
-				log.Error("no available tickets", slog.Int64("event_id", eventID))
-				w.WriteHeader(http.StatusUnprocessableEntity)
-				render.JSON(w, r, resp.Error("not enough available tickets"))
-				return
-			}
-			if errors.Is(err, storage.ErrInsufficientBalance) {
-				log.Error("insufficient balance", slog.Int64("user_id", userID))
-				w.WriteHeader(http.StatusUnprocessableEntity)
-				render.JSON(w, r, resp.Error("insufficient balance"))
-				return
+			if resp.IsKnownStorageError(err) {
+				log.Info("booking rejected", sl.Err(err),
+					slog.Int64("user_id", userID),
+					slog.Int64("event_id", eventID),
+				)
+			} else {
+				log.Error("failed to create booking", sl.Err(err))
 			}
-			if errors.Is(err, storage.ErrBookingExists) {
-				log.Error("booking already exists", slog.Int64("user_id", userID), slog.Int64("event_id", eventID))
-				w.WriteHeader(http.StatusConflict)
-				render.JSON(w, r, resp.Error("you already have a booking for this event"))
-				return
-			}
-
-			log.Error("failed to create booking", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("failed to create booking"))
+			resp.RenderStorageError(w, r, err)
 			return
 		}
 
+		metrics.BookingsCreated.Inc()
+		metrics.TicketsSold.WithLabelValues(booking.EventCategory).Add(float64(booking.Quantity))
+
 		log.Info("booking created",
 			slog.Int64("booking_id", booking.ID),
 			slog.String("booking_code", booking.BookingCode),
 		)
 
-		w.WriteHeader(http.StatusCreated)
+		render.Status(r, http.StatusCreated)
 		render.JSON(w, r, CreateBookingResponse{
 			Response: resp.OK(),
 			Booking:  *booking,
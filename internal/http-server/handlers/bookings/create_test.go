@@ -0,0 +1,144 @@
+package bookings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/http-server/handlers/bookings/mocks"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+func TestCreateHandler(t *testing.T) {
+	cases := []struct {
+		name       string  // Имя теста
+		id         string  // ID мероприятия в пути
+		body       string  // Тело запроса
+		quantity   int     // Количество, которое хэндлер передает в сторадж
+		seatIDs    []int64 // Места, которые хэндлер передает в сторадж
+		respCode   string  // Указываем какой код ошибки хотим получить
+		respStatus int     // Ожидаемый HTTP статус
+		mockError  error   // Ошибка которую выдает mock
+		callsMock  bool    // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Quantity",
+			id:         "5",
+			body:       `{"quantity": 2}`,
+			quantity:   2,
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Seats",
+			id:         "5",
+			body:       `{"seat_ids": [101, 102]}`,
+			seatIDs:    []int64{101, 102},
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Invalid Event ID",
+			id:         "abc",
+			body:       `{"quantity": 2}`,
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Duplicate Seats",
+			id:         "5",
+			body:       `{"seat_ids": [101, 101]}`,
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Too Many Seats",
+			id:         "5",
+			body:       `{"seat_ids": [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11]}`,
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			// на мероприятие со схемой зала нельзя купить билеты без мест
+			name:       "Seats Required",
+			id:         "5",
+			body:       `{"quantity": 2}`,
+			quantity:   2,
+			respCode:   resp.CodeSeatsRequired,
+			respStatus: http.StatusUnprocessableEntity,
+			mockError:  storage.ErrSeatsRequired,
+			callsMock:  true,
+		}, {
+			name:       "Seat Taken",
+			id:         "5",
+			body:       `{"seat_ids": [101, 102]}`,
+			seatIDs:    []int64{101, 102},
+			respCode:   resp.CodeSeatTaken,
+			respStatus: http.StatusConflict,
+			mockError:  storage.ErrSeatTaken,
+			callsMock:  true,
+		}, {
+			name:       "Seat Not Found",
+			id:         "5",
+			body:       `{"seat_ids": [999]}`,
+			seatIDs:    []int64{999},
+			respCode:   resp.CodeSeatNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrSeatNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Storage Error",
+			id:         "5",
+			body:       `{"seat_ids": [101]}`,
+			seatIDs:    []int64{101},
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("unexpected error"),
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			creatorMock := mocks.NewBookingCreator(t)
+			if tc.callsMock {
+				var booking *models.Booking
+				if tc.mockError == nil {
+					booking = &models.Booking{
+						ID:       1,
+						UserID:   42,
+						EventID:  5,
+						Quantity: max(tc.quantity, len(tc.seatIDs)),
+						Status:   models.BookingStatusConfirmed,
+					}
+				}
+				// С seat_ids бронируются места, без них — количество билетов
+				if len(tc.seatIDs) > 0 {
+					creatorMock.On("CreateSeatBooking", mock.Anything, int64(42), int64(5), tc.seatIDs).
+						Return(booking, tc.mockError).
+						Once()
+				} else {
+					creatorMock.On("CreateBooking", mock.Anything, int64(42), int64(5), tc.quantity).
+						Return(booking, tc.mockError).
+						Once()
+				}
+			}
+
+			rr := httptest.NewRecorder()
+			NewCreate(slogdiscard.NewDiscardLogger(), creatorMock).ServeHTTP(rr, newBookingRequest(t, http.MethodPost, tc.id, tc.body))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body CreateBookingResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respStatus == http.StatusCreated {
+				require.Equal(t, int64(1), body.Booking.ID)
+				require.Equal(t, max(tc.quantity, len(tc.seatIDs)), body.Booking.Quantity)
+			}
+		})
+	}
+}
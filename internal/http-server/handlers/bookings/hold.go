@@ -0,0 +1,178 @@
+package bookings
+
+import (
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/lib/metrics"
+	"API/internal/models"
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	chimiddleware "github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/exp/slog"
+)
+
+// TicketHolder интерфейс для удержания билетов до оплаты
+type TicketHolder interface {
+	HoldTickets(ctx context.Context, userID, eventID int64, quantity int, seatIDs []int64, expiresAt time.Time) (*models.Booking, error)
+}
+
+// BookingConfirmer интерфейс для оплаты удержания
+type BookingConfirmer interface {
+	ConfirmBooking(ctx context.Context, bookingID, userID int64) (*models.Booking, error)
+}
+
+// NewHold возвращает хендлер удержания билетов на время ttl
+// @Summary Удержать билеты
+// @Description Резервирует билеты или места seat_ids без оплаты: создает бронь со статусом pending,
+// @Description которую нужно подтвердить через POST /bookings/{id}/confirm до expires_at. Неоплаченные
+// @Description вовремя билеты возвращаются в продажу, бронь получает статус expired
+// @Tags bookings
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID мероприятия"
+// @Param request body CreateBookingRequest true "Количество билетов или места"
+// @Success 201 {object} CreateBookingResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response
+// @Failure 409 {object} resp.Response "Бронирование уже существует или место занято"
+// @Failure 422 {object} resp.Response "Недостаточно билетов; на мероприятие нужно выбрать места"
+// @Failure 500 {object} resp.Response
+// @Router /events/{id}/hold [post]
+func NewHold(log *slog.Logger, holder TicketHolder, ttl time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.bookings.Hold"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", chimiddleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid event id"))
+			return
+		}
+
+		var req CreateBookingRequest
+		if err := request.DecodeJSON(r, &req); err != nil {
+			log.Info("failed to decode request", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "invalid request body"))
+			return
+		}
+
+		if err := request.Validate(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("validation failed", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.ValidationError(validateErr))
+			return
+		}
+
+		booking, err := holder.HoldTickets(r.Context(), userID, eventID, req.Quantity, req.SeatIDs, time.Now().Add(ttl))
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("hold rejected", sl.Err(err),
+					slog.Int64("user_id", userID),
+					slog.Int64("event_id", eventID),
+				)
+			} else {
+				log.Error("failed to hold tickets", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		metrics.BookingHolds.Inc()
+
+		log.Info("tickets held",
+			slog.Int64("booking_id", booking.ID),
+			slog.Int("quantity", booking.Quantity),
+			slog.Time("expires_at", *booking.ExpiresAt),
+		)
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, CreateBookingResponse{
+			Response: resp.OK(),
+			Booking:  *booking,
+		})
+	}
+}
+
+// NewConfirm возвращает хендлер оплаты удержания
+// @Summary Оплатить удержание
+// @Description Списывает стоимость удержанных билетов с баланса и подтверждает бронь
+// @Tags bookings
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID бронирования"
+// @Success 200 {object} CreateBookingResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response
+// @Failure 409 {object} resp.Response "Бронь не является удержанием или отменена"
+// @Failure 410 {object} resp.Response "Удержание истекло"
+// @Failure 422 {object} resp.Response "Недостаточно средств на балансе"
+// @Failure 500 {object} resp.Response
+// @Router /bookings/{id}/confirm [post]
+func NewConfirm(log *slog.Logger, confirmer BookingConfirmer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.bookings.Confirm"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", chimiddleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		bookingID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid booking id"))
+			return
+		}
+
+		booking, err := confirmer.ConfirmBooking(r.Context(), bookingID, userID)
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("hold not confirmed", sl.Err(err), slog.Int64("booking_id", bookingID))
+			} else {
+				log.Error("failed to confirm hold", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		metrics.BookingsCreated.Inc()
+		metrics.TicketsSold.WithLabelValues(booking.EventCategory).Add(float64(booking.Quantity))
+
+		log.Info("hold confirmed", slog.Int64("booking_id", booking.ID))
+
+		render.JSON(w, r, CreateBookingResponse{
+			Response: resp.OK(),
+			Booking:  *booking,
+		})
+	}
+}
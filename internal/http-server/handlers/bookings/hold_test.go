@@ -0,0 +1,241 @@
+package bookings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/http-server/handlers/bookings/mocks"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+// newBookingRequest создает запрос пользователя с ID в пути
+func newBookingRequest(t *testing.T, method, id, body string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(method, "/", bytes.NewReader([]byte(body)))
+	require.NoError(t, err)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+
+	return req.WithContext(context.WithValue(ctx, authMiddleware.UserIDKey, int64(42)))
+}
+
+func TestHoldHandler(t *testing.T) {
+	const ttl = 15 * time.Minute
+
+	cases := []struct {
+		name       string  // Имя теста
+		id         string  // ID мероприятия в пути
+		body       string  // Тело запроса
+		quantity   int     // Количество, которое хэндлер передает в сторадж
+		seatIDs    []int64 // Места, которые хэндлер передает в сторадж
+		respCode   string  // Указываем какой код ошибки хотим получить
+		respStatus int     // Ожидаемый HTTP статус
+		mockError  error   // Ошибка которую выдает mock
+		callsMock  bool    // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Quantity",
+			id:         "5",
+			body:       `{"quantity": 2}`,
+			quantity:   2,
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Seats",
+			id:         "5",
+			body:       `{"seat_ids": [101, 102]}`,
+			seatIDs:    []int64{101, 102},
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Invalid Event ID",
+			id:         "abc",
+			body:       `{"quantity": 2}`,
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Invalid Body",
+			id:         "5",
+			body:       `{"quantity":`,
+			respCode:   resp.CodeInvalidBody,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "No Quantity",
+			id:         "5",
+			body:       `{}`,
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Sold Out",
+			id:         "5",
+			body:       `{"quantity": 2}`,
+			quantity:   2,
+			respCode:   resp.CodeNoTickets,
+			respStatus: http.StatusUnprocessableEntity,
+			mockError:  storage.ErrNoTickets,
+			callsMock:  true,
+		}, {
+			name:       "Seat Taken",
+			id:         "5",
+			body:       `{"seat_ids": [7]}`,
+			seatIDs:    []int64{7},
+			respCode:   resp.CodeSeatTaken,
+			respStatus: http.StatusConflict,
+			mockError:  storage.ErrSeatTaken,
+			callsMock:  true,
+		}, {
+			name:       "Storage Error",
+			id:         "5",
+			body:       `{"quantity": 2}`,
+			quantity:   2,
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("unexpected error"),
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			holderMock := mocks.NewTicketHolder(t)
+			before := time.Now()
+			if tc.callsMock {
+				var booking *models.Booking
+				if tc.mockError == nil {
+					expiresAt := before.Add(ttl)
+					booking = &models.Booking{
+						ID:        1,
+						UserID:    42,
+						EventID:   5,
+						Quantity:  max(tc.quantity, len(tc.seatIDs)),
+						Status:    models.BookingStatusPending,
+						ExpiresAt: &expiresAt,
+					}
+				}
+				// Удержание истекает через ttl от момента запроса
+				expiresAt := mock.MatchedBy(func(at time.Time) bool {
+					return !at.Before(before.Add(ttl)) && at.Before(before.Add(ttl+time.Second))
+				})
+				holderMock.On("HoldTickets", mock.Anything, int64(42), int64(5), tc.quantity, tc.seatIDs, expiresAt).
+					Return(booking, tc.mockError).
+					Once()
+			}
+
+			rr := httptest.NewRecorder()
+			NewHold(slogdiscard.NewDiscardLogger(), holderMock, ttl).ServeHTTP(rr, newBookingRequest(t, http.MethodPost, tc.id, tc.body))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body CreateBookingResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respStatus == http.StatusCreated {
+				require.Equal(t, models.BookingStatusPending, body.Booking.Status)
+				require.NotNil(t, body.Booking.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestConfirmHandler(t *testing.T) {
+	cases := []struct {
+		name       string // Имя теста
+		id         string // ID брони в пути
+		respCode   string // Указываем какой код ошибки хотим получить
+		respStatus int    // Ожидаемый HTTP статус
+		mockError  error  // Ошибка которую выдает mock
+		callsMock  bool   // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Confirmed",
+			id:         "3",
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Invalid ID",
+			id:         "abc",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			// Удержание истекло или его уже вернул в продажу ReleaseExpiredHolds
+			name:       "Hold Expired",
+			id:         "3",
+			respCode:   resp.CodeHoldExpired,
+			respStatus: http.StatusGone,
+			mockError:  storage.ErrHoldExpired,
+			callsMock:  true,
+		}, {
+			name:       "Not Pending",
+			id:         "3",
+			respCode:   resp.CodeBookingNotPending,
+			respStatus: http.StatusConflict,
+			mockError:  storage.ErrBookingNotPending,
+			callsMock:  true,
+		}, {
+			name:       "No Money",
+			id:         "3",
+			respCode:   resp.CodeInsufficientBalance,
+			respStatus: http.StatusUnprocessableEntity,
+			mockError:  storage.ErrInsufficientBalance,
+			callsMock:  true,
+		}, {
+			name:       "Not Found",
+			id:         "3",
+			respCode:   resp.CodeBookingNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrBookingNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Storage Error",
+			id:         "3",
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("unexpected error"),
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			confirmerMock := mocks.NewBookingConfirmer(t)
+			if tc.callsMock {
+				var booking *models.Booking
+				if tc.mockError == nil {
+					booking = &models.Booking{ID: 3, UserID: 42, Quantity: 2, Status: models.BookingStatusConfirmed}
+				}
+				confirmerMock.On("ConfirmBooking", mock.Anything, int64(3), int64(42)).
+					Return(booking, tc.mockError).
+					Once()
+			}
+
+			rr := httptest.NewRecorder()
+			NewConfirm(slogdiscard.NewDiscardLogger(), confirmerMock).ServeHTTP(rr, newBookingRequest(t, http.MethodPost, tc.id, ""))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body CreateBookingResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respStatus == http.StatusOK {
+				require.Equal(t, models.BookingStatusConfirmed, body.Booking.Status)
+			}
+		})
+	}
+}
@@ -2,9 +2,12 @@ package bookings
 
 import (
 	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/cursor"
+	"API/internal/lib/api/request"
 	resp "API/internal/lib/api/response"
 	"API/internal/lib/logger/sl"
 	"API/internal/models"
+	"context"
 	"net/http"
 
 	"github.com/go-chi/chi/middleware"
@@ -14,22 +17,29 @@ import (
 
 // BookingsLister интерфейс для получения списка бронирований
 type BookingsLister interface {
-	GetBookingsByUserID(userID int64) ([]*models.BookingWithEvent, error)
+	GetBookingsByUserID(ctx context.Context, userID int64, page models.Page) ([]*models.BookingWithEvent, *models.Cursor, error)
 }
 
 // ListBookingsResponse ответ со списком бронирований
 type ListBookingsResponse struct {
 	resp.Response
 	Bookings []models.BookingResponse `json:"bookings"`
+	// NextCursor передается в cursor для следующей страницы; пустой на последней
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // NewList возвращает хендлер для получения списка бронирований
 // @Summary Мои билеты
-// @Description Возвращает список всех бронирований пользователя
+// @Description Возвращает бронирования пользователя, новые первыми. Без limit и cursor возвращаются все
+// @Description бронирования, как до появления пагинации; с limit или cursor список отдается постранично
 // @Tags bookings
 // @Security BearerAuth
 // @Produce json
+// @Param limit query int false "Количество записей (максимум 100); без limit и cursor возвращаются все"
+// @Param offset query int false "Смещение (по умолчанию 0), игнорируется вместе с cursor"
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
 // @Success 200 {object} ListBookingsResponse
+// @Failure 400 {object} resp.Response
 // @Failure 401 {object} resp.Response
 // @Failure 500 {object} resp.Response
 // @Router /bookings [get]
@@ -40,21 +50,27 @@ func NewList(log *slog.Logger, lister BookingsLister) http.HandlerFunc {
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
 		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
 		if !ok {
 			log.Error("user_id not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
-			render.JSON(w, r, resp.Error("unauthorized"))
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
 			return
 		}
 
-		bookings, err := lister.GetBookingsByUserID(userID)
+		page, err := request.PageOrAll(r, models.OrderNewest)
+		if err != nil {
+			log.Info("invalid cursor", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid cursor"))
+			return
+		}
+
+		bookings, next, err := lister.GetBookingsByUserID(r.Context(), userID, page)
 		if err != nil {
 			log.Error("failed to get bookings", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("failed to get bookings"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to get bookings"))
 			return
 		}
 
@@ -64,8 +80,9 @@ func NewList(log *slog.Logger, lister BookingsLister) http.HandlerFunc {
 		}
 
 		render.JSON(w, r, ListBookingsResponse{
-			Response: resp.OK(),
-			Bookings: response,
+			Response:   resp.OK(),
+			Bookings:   response,
+			NextCursor: cursor.Encode(next),
 		})
 	}
 }
@@ -0,0 +1,120 @@
+package bookings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"API/internal/http-server/handlers/bookings/mocks"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/cursor"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+func TestListHandler(t *testing.T) {
+	after := models.NewestCursor(time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC), 10)
+	next := models.NewestCursor(time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC), 8)
+	foreign := models.Cursor{Order: models.SortDate, Time: after.Time, ID: after.ID}
+
+	cases := []struct {
+		name       string         // Имя теста
+		query      string         // Строка запроса
+		page       models.Page    // Страница, которую хэндлер передает в сторадж
+		next       *models.Cursor // Курсор следующей страницы из стораджа
+		respCode   string         // Указываем какой код ошибки хотим получить
+		respStatus int            // Ожидаемый HTTP статус
+		mockError  error          // Ошибка которую выдает mock
+		callsMock  bool           // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "All Without Limit And Cursor",
+			page:       models.Page{},
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Offset Without Limit",
+			query:      "?offset=5",
+			page:       models.Page{Offset: 5},
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Limit",
+			query:      "?limit=2",
+			page:       models.Page{Limit: 2},
+			next:       &next,
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Limit Above Max",
+			query:      "?limit=1000",
+			page:       models.Page{Limit: request.DefaultLimit},
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Cursor Without Limit",
+			query:      "?cursor=" + cursor.Encode(&after),
+			page:       models.Page{Limit: request.DefaultLimit, After: &after},
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Broken Cursor",
+			query:      "?cursor=not-a-cursor",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Cursor Of Other Order",
+			query:      "?cursor=" + cursor.Encode(&foreign),
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Storage Error",
+			page:       models.Page{},
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("unexpected error"),
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			listerMock := mocks.NewBookingsLister(t)
+			if tc.callsMock {
+				var bookings []*models.BookingWithEvent
+				if tc.mockError == nil {
+					bookings = []*models.BookingWithEvent{{Booking: models.Booking{ID: 10, UserID: 42, Quantity: 1}}}
+				}
+				listerMock.On("GetBookingsByUserID", mock.Anything, int64(42), tc.page).
+					Return(bookings, tc.next, tc.mockError).
+					Once()
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/bookings"+tc.query, nil)
+			require.NoError(t, err)
+			req = req.WithContext(context.WithValue(req.Context(), authMiddleware.UserIDKey, int64(42)))
+
+			rr := httptest.NewRecorder()
+			NewList(slogdiscard.NewDiscardLogger(), listerMock).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body ListBookingsResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respStatus == http.StatusOK {
+				require.Len(t, body.Bookings, 1)
+				require.Equal(t, cursor.Encode(tc.next), body.NextCursor)
+			}
+		})
+	}
+}
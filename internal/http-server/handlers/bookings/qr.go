@@ -0,0 +1,117 @@
+package bookings
+
+import (
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	qrlib "API/internal/lib/qr"
+	"API/internal/models"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"golang.org/x/exp/slog"
+)
+
+// BookingGetter интерфейс для получения бронирования пользователя
+type BookingGetter interface {
+	GetBookingByID(ctx context.Context, bookingID, userID int64) (*models.BookingWithEvent, error)
+}
+
+// TicketSigner интерфейс для подписи билета
+type TicketSigner interface {
+	Sign(b *models.Booking) (string, error)
+}
+
+// NewQR возвращает хендлер QR кода билета
+// @Summary QR код билета
+// @Description Возвращает QR код с подписанным билетом (T1.<данные>.<подпись ed25519>). Подпись проверяется публичным
+// @Description ключом без обращения к API, а перевыпуск после передачи брони виден только в POST /tickets/verify
+// @Tags bookings
+// @Security BearerAuth
+// @Produce png
+// @Produce image/svg+xml
+// @Param id path int true "ID бронирования"
+// @Param format query string false "Формат изображения" Enums(png, svg) default(png)
+// @Param size query int false "Размер в пикселях, от 64 до 2048" default(256)
+// @Param level query string false "Уровень коррекции ошибок" Enums(L, M, Q, H) default(M)
+// @Success 200 {file} binary
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response
+// @Failure 409 {object} resp.Response "Бронирование отменено или удержание не оплачено"
+// @Failure 500 {object} resp.Response
+// @Router /bookings/{id}/qr [get]
+func NewQR(log *slog.Logger, getter BookingGetter, signer TicketSigner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.bookings.QR"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		bookingID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid booking id", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid booking id"))
+			return
+		}
+
+		opts, err := qrlib.ParseOptions(r.URL.Query())
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, err.Error()))
+			return
+		}
+
+		booking, err := getter.GetBookingByID(r.Context(), bookingID, userID)
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("booking not found", slog.Int64("booking_id", bookingID))
+			} else {
+				log.Error("failed to get booking", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		// Билет выдается только на действующее бронирование
+		if booking.Status == models.BookingStatusCancelled {
+			resp.Render(w, r, http.StatusConflict, resp.Error(resp.CodeBookingCancelled, "booking is cancelled"))
+			return
+		}
+		if booking.Status == models.BookingStatusPending || booking.Status == models.BookingStatusExpired {
+			resp.Render(w, r, http.StatusConflict, resp.Error(resp.CodeBookingNotConfirmed, "booking is not confirmed"))
+			return
+		}
+
+		ticket, err := signer.Sign(&booking.Booking)
+		if err != nil {
+			log.Error("failed to sign ticket", sl.Err(err))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to sign ticket"))
+			return
+		}
+
+		image, contentType, err := qrlib.Render(ticket, opts)
+		if err != nil {
+			log.Error("failed to render qr", sl.Err(err))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to render qr code"))
+			return
+		}
+
+		// Подпись содержит время выдачи, кэшировать на стороне клиента незачем
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", "private, no-store")
+		_, _ = w.Write(image)
+	}
+}
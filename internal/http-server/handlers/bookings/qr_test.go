@@ -0,0 +1,156 @@
+package bookings
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/http-server/handlers/bookings/mocks"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+var pngMagic = []byte("\x89PNG\r\n\x1a\n")
+
+func TestQRHandler(t *testing.T) {
+	cases := []struct {
+		name        string               // Имя теста
+		id          string               // ID брони в пути
+		query       string               // Строка запроса
+		status      models.BookingStatus // Статус брони в хранилище
+		contentType string               // Ожидаемый тип изображения
+		signError   error                // Ошибка подписи билета
+		respCode    string               // Указываем какой код ошибки хотим получить
+		respStatus  int                  // Ожидаемый HTTP статус
+		mockError   error                // Ошибка которую выдает mock
+		callsMock   bool                 // Должен ли хэндлер дойти до стораджа
+		signs       bool                 // Должен ли хэндлер подписать билет
+	}{
+		{
+			name:        "PNG",
+			id:          "7",
+			status:      models.BookingStatusConfirmed,
+			contentType: "image/png",
+			respStatus:  http.StatusOK,
+			callsMock:   true,
+			signs:       true,
+		}, {
+			name:        "SVG",
+			id:          "7",
+			query:       "format=svg",
+			status:      models.BookingStatusConfirmed,
+			contentType: "image/svg+xml",
+			respStatus:  http.StatusOK,
+			callsMock:   true,
+			signs:       true,
+		}, {
+			name:       "Invalid ID",
+			id:         "abc",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Invalid Level",
+			id:         "7",
+			query:      "level=X",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Not Found",
+			id:         "7",
+			respCode:   resp.CodeBookingNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrBookingNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Cancelled",
+			id:         "7",
+			status:     models.BookingStatusCancelled,
+			respCode:   resp.CodeBookingCancelled,
+			respStatus: http.StatusConflict,
+			callsMock:  true,
+		}, {
+			// Неоплаченное удержание еще не билет
+			name:       "Pending Hold",
+			id:         "7",
+			status:     models.BookingStatusPending,
+			respCode:   resp.CodeBookingNotConfirmed,
+			respStatus: http.StatusConflict,
+			callsMock:  true,
+		}, {
+			name:       "Expired Hold",
+			id:         "7",
+			status:     models.BookingStatusExpired,
+			respCode:   resp.CodeBookingNotConfirmed,
+			respStatus: http.StatusConflict,
+			callsMock:  true,
+		}, {
+			name:       "Sign Error",
+			id:         "7",
+			status:     models.BookingStatusConfirmed,
+			signError:  errors.New("signer is broken"),
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			callsMock:  true,
+			signs:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			getterMock := mocks.NewBookingGetter(t)
+			signerMock := mocks.NewTicketSigner(t)
+
+			booking := &models.BookingWithEvent{Booking: models.Booking{ID: 7, UserID: 42, Status: tc.status}}
+			if tc.callsMock {
+				found := booking
+				if tc.mockError != nil {
+					found = nil
+				}
+				getterMock.On("GetBookingByID", mock.Anything, int64(7), int64(42)).
+					Return(found, tc.mockError).
+					Once()
+			}
+			if tc.signs {
+				var ticket string
+				if tc.signError == nil {
+					ticket = "T1.eyJiIjo3fQ.c2ln"
+				}
+				signerMock.On("Sign", &booking.Booking).
+					Return(ticket, tc.signError).
+					Once()
+			}
+
+			req := newBookingRequest(t, http.MethodGet, tc.id, "")
+			req.URL.RawQuery = tc.query
+
+			rr := httptest.NewRecorder()
+			NewQR(slogdiscard.NewDiscardLogger(), getterMock, signerMock).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			if tc.respStatus != http.StatusOK {
+				var body resp.Response
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				require.Equal(t, tc.respCode, body.Code)
+				return
+			}
+
+			// Подпись содержит время выдачи, поэтому билет не кэшируется
+			require.Equal(t, tc.contentType, rr.Header().Get("Content-Type"))
+			require.Equal(t, "private, no-store", rr.Header().Get("Cache-Control"))
+			if tc.contentType == "image/png" {
+				require.True(t, bytes.HasPrefix(rr.Body.Bytes(), pngMagic))
+			} else {
+				require.Contains(t, rr.Body.String(), "<svg")
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package bookings
+
+import (
+	"API/internal/auth"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/models"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/exp/slog"
+)
+
+// TicketVerifier интерфейс для проверки подписи билета
+type TicketVerifier interface {
+	Verify(ticket string) (*auth.TicketClaims, error)
+}
+
+// EventBookingGetter интерфейс для получения брони на мероприятие организатора
+type EventBookingGetter interface {
+	GetEventBooking(ctx context.Context, bookingID, organizerID int64) (*models.BookingWithEvent, error)
+}
+
+// VerifyRequest структура запроса на проверку билета
+type VerifyRequest struct {
+	// Ticket содержимое QR кода билета
+	Ticket string `json:"ticket" validate:"required" example:"T1.eyJiIjo3fQ.c2ln"`
+}
+
+// VerifyResponse структура ответа при проверке билета
+type VerifyResponse struct {
+	resp.Response
+	Booking models.BookingResponse `json:"booking"`
+}
+
+// NewVerify возвращает хендлер онлайн-проверки билета на входе
+// @Summary Проверить билет
+// @Description Проверяет подпись билета и сверяет его с текущей бронью. Билет, выпущенный до передачи,
+// @Description сохраняет верную подпись, но его код брони уже перевыпущен, поэтому он отклоняется с TICKET_REVOKED.
+// @Description Проверять билеты может только организатор мероприятия
+// @Tags bookings
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body VerifyRequest true "Содержимое QR кода"
+// @Success 200 {object} VerifyResponse
+// @Failure 400 {object} resp.Response "Подпись билета неверна"
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response "Бронь не найдена или мероприятие другого организатора"
+// @Failure 409 {object} resp.Response "Билет перевыпущен, бронь отменена или не оплачена"
+// @Failure 500 {object} resp.Response
+// @Router /tickets/verify [post]
+func NewVerify(log *slog.Logger, verifier TicketVerifier, getter EventBookingGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.bookings.Verify"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		var req VerifyRequest
+		if err := request.DecodeJSON(r, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "invalid request body"))
+			return
+		}
+
+		if err := request.Validate(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("validation failed", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.ValidationError(validateErr))
+			return
+		}
+
+		claims, err := verifier.Verify(req.Ticket)
+		if err != nil {
+			log.Info("invalid ticket", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeTicketInvalid, "invalid ticket signature"))
+			return
+		}
+
+		booking, err := getter.GetEventBooking(r.Context(), claims.BookingID, userID)
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("booking not found", slog.Int64("booking_id", claims.BookingID))
+			} else {
+				log.Error("failed to get booking", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		if err := claims.Matches(&booking.Booking); errors.Is(err, auth.ErrTicketRevoked) {
+			log.Info("ticket revoked", slog.Int64("booking_id", booking.ID))
+			resp.Render(w, r, http.StatusConflict, resp.Error(resp.CodeTicketRevoked, "ticket was reissued, ask the holder for the current qr code"))
+			return
+		}
+
+		if booking.Status == models.BookingStatusCancelled {
+			resp.Render(w, r, http.StatusConflict, resp.Error(resp.CodeBookingCancelled, "booking is cancelled"))
+			return
+		}
+		if booking.Status == models.BookingStatusPending || booking.Status == models.BookingStatusExpired {
+			resp.Render(w, r, http.StatusConflict, resp.Error(resp.CodeBookingNotConfirmed, "booking is not confirmed"))
+			return
+		}
+
+		log.Info("ticket verified", slog.Int64("booking_id", booking.ID))
+
+		render.JSON(w, r, VerifyResponse{
+			Response: resp.OK(),
+			Booking:  booking.ToResponse(),
+		})
+	}
+}
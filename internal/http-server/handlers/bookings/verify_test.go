@@ -0,0 +1,137 @@
+package bookings
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/auth"
+	"API/internal/http-server/handlers/bookings/mocks"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+func newTicketSigner(t *testing.T) *auth.TicketSigner {
+	t.Helper()
+
+	signer, err := auth.NewTicketSigner(base64.StdEncoding.EncodeToString(make([]byte, ed25519.SeedSize)))
+	require.NoError(t, err)
+
+	return signer
+}
+
+func newVerifyRequest(t *testing.T, ticket string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/tickets/verify", bytes.NewReader([]byte(fmt.Sprintf(`{"ticket": %q}`, ticket))))
+	require.NoError(t, err)
+
+	// Организатор мероприятия проходит JWTAuth
+	return req.WithContext(context.WithValue(req.Context(), authMiddleware.UserIDKey, int64(42)))
+}
+
+func TestVerifyHandler(t *testing.T) {
+	signer := newTicketSigner(t)
+
+	// Билет выдан до передачи: код BK-OLD, два билета
+	issued := models.Booking{ID: 7, EventID: 3, Quantity: 2, BookingCode: "BK-OLD", Status: models.BookingStatusConfirmed}
+	ticket, err := signer.Sign(&issued)
+	require.NoError(t, err)
+
+	// Передача перевыпускает код брони, частичная еще и уменьшает количество
+	transferred := issued
+	transferred.BookingCode = "BK-NEW"
+	split := issued
+	split.Quantity = 1
+	split.BookingCode = "BK-NEW"
+	cancelled := issued
+	cancelled.Status = models.BookingStatusCancelled
+
+	cases := []struct {
+		name       string          // Имя теста
+		ticket     string          // Содержимое QR кода
+		booking    *models.Booking // Бронь в хранилище на момент проверки
+		mockError  error           // Ошибка которую выдает mock
+		respCode   string          // Указываем какой код ошибки хотим получить
+		respStatus int             // Ожидаемый HTTP статус
+	}{
+		{
+			name:       "Valid",
+			ticket:     ticket,
+			booking:    &issued,
+			respStatus: http.StatusOK,
+		}, {
+			name:       "Revoked After Transfer",
+			ticket:     ticket,
+			booking:    &transferred,
+			respCode:   resp.CodeTicketRevoked,
+			respStatus: http.StatusConflict,
+		}, {
+			name:       "Revoked After Partial Transfer",
+			ticket:     ticket,
+			booking:    &split,
+			respCode:   resp.CodeTicketRevoked,
+			respStatus: http.StatusConflict,
+		}, {
+			name:       "Cancelled",
+			ticket:     ticket,
+			booking:    &cancelled,
+			respCode:   resp.CodeBookingCancelled,
+			respStatus: http.StatusConflict,
+		}, {
+			name:       "Other Organizer",
+			ticket:     ticket,
+			mockError:  storage.ErrBookingNotFound,
+			respCode:   resp.CodeBookingNotFound,
+			respStatus: http.StatusNotFound,
+		}, {
+			name:       "Bad Signature",
+			ticket:     ticket[:len(ticket)-4] + "AAAA",
+			respCode:   resp.CodeTicketInvalid,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Empty Ticket",
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			getterMock := mocks.NewEventBookingGetter(t)
+			if tc.booking != nil || tc.mockError != nil {
+				var found *models.BookingWithEvent
+				if tc.booking != nil {
+					found = &models.BookingWithEvent{Booking: *tc.booking}
+				}
+				getterMock.On("GetEventBooking", mock.Anything, issued.ID, int64(42)).
+					Return(found, tc.mockError).
+					Once()
+			}
+
+			rr := httptest.NewRecorder()
+			NewVerify(slogdiscard.NewDiscardLogger(), signer, getterMock).ServeHTTP(rr, newVerifyRequest(t, tc.ticket))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body VerifyResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respCode == "" {
+				require.Equal(t, issued.BookingCode, body.Booking.BookingCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package events
+
+import (
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/models"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/exp/slog"
+)
+
+// CapacityUpdater интерфейс для изменения вместимости мероприятия
+type CapacityUpdater interface {
+	UpdateEventCapacity(ctx context.Context, eventID, creatorID int64, capacity int) (*models.Event, error)
+}
+
+// CapacityRequest структура запроса на изменение вместимости
+type CapacityRequest struct {
+	Capacity int `json:"capacity" validate:"required,min=1" example:"250"`
+}
+
+// NewUpdateCapacity создает хендлер изменения вместимости мероприятия
+// @Summary Изменить вместимость
+// @Description Меняет вместимость мероприятия; свободные билеты меняются на ту же разницу. Новые билеты
+// @Description сразу предлагаются листу ожидания. Менять вместимость может только организатор мероприятия
+// @Tags events
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID мероприятия"
+// @Param request body CapacityRequest true "Новая вместимость"
+// @Success 200 {object} GetByIDResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response "Мероприятие не найдено или принадлежит другому организатору"
+// @Failure 409 {object} resp.Response "Вместимость ниже проданного или задается схемой зала"
+// @Failure 500 {object} resp.Response
+// @Router /events/{id}/capacity [put]
+func NewUpdateCapacity(log *slog.Logger, updater CapacityUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.events.UpdateCapacity"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid event id", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid event id"))
+			return
+		}
+
+		var req CapacityRequest
+		if err := request.DecodeJSON(r, &req); err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "invalid request body"))
+			return
+		}
+
+		if err := request.Validate(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("validation failed", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.ValidationError(validateErr))
+			return
+		}
+
+		event, err := updater.UpdateEventCapacity(r.Context(), eventID, userID, req.Capacity)
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("capacity not updated", sl.Err(err), slog.Int64("event_id", eventID))
+			} else {
+				log.Error("failed to update capacity", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		log.Info("capacity updated", slog.Int64("event_id", eventID), slog.Int("capacity", event.Capacity))
+
+		render.JSON(w, r, GetByIDResponse{
+			Response: resp.OK(),
+			Event:    event.ToResponse(),
+		})
+	}
+}
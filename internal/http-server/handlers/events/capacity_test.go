@@ -0,0 +1,129 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/http-server/handlers/events/mocks"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+// newEventRequest создает запрос организатора с ID мероприятия в пути
+func newEventRequest(t *testing.T, method, id, body string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(method, "/", bytes.NewReader([]byte(body)))
+	require.NoError(t, err)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+
+	return req.WithContext(context.WithValue(ctx, authMiddleware.UserIDKey, int64(42)))
+}
+
+func TestUpdateCapacityHandler(t *testing.T) {
+	cases := []struct {
+		name       string // Имя теста
+		id         string // ID мероприятия в пути
+		body       string // Тело запроса
+		respCode   string // Указываем какой код ошибки хотим получить
+		respStatus int    // Ожидаемый HTTP статус
+		mockError  error  // Ошибка которую выдает mock
+		callsMock  bool   // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Increase",
+			id:         "5",
+			body:       `{"capacity": 150}`,
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Invalid ID",
+			id:         "abc",
+			body:       `{"capacity": 150}`,
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Zero Capacity",
+			id:         "5",
+			body:       `{"capacity": 0}`,
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Below Sold",
+			id:         "5",
+			body:       `{"capacity": 150}`,
+			respCode:   resp.CodeCapacityBelowSold,
+			respStatus: http.StatusConflict,
+			mockError:  storage.ErrCapacityBelowSold,
+			callsMock:  true,
+		}, {
+			name:       "Seated Event",
+			id:         "5",
+			body:       `{"capacity": 150}`,
+			respCode:   resp.CodeSeatedCapacity,
+			respStatus: http.StatusConflict,
+			mockError:  storage.ErrSeatedCapacity,
+			callsMock:  true,
+		}, {
+			name:       "Other Organizer",
+			id:         "5",
+			body:       `{"capacity": 150}`,
+			respCode:   resp.CodeEventNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrEventNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Storage Error",
+			id:         "5",
+			body:       `{"capacity": 150}`,
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("database connection error"),
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			updaterMock := mocks.NewCapacityUpdater(t)
+			if tc.callsMock {
+				var event *models.Event
+				if tc.mockError == nil {
+					event = &models.Event{ID: 5, Capacity: 150, AvailableTickets: 60}
+				}
+				updaterMock.On("UpdateEventCapacity", mock.Anything, int64(5), int64(42), 150).
+					Return(event, tc.mockError).
+					Once()
+			}
+
+			rr := httptest.NewRecorder()
+			NewUpdateCapacity(slogdiscard.NewDiscardLogger(), updaterMock).
+				ServeHTTP(rr, newEventRequest(t, http.MethodPut, tc.id, tc.body))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body GetByIDResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respCode == "" {
+				require.Equal(t, 150, body.Event.Capacity)
+				require.Equal(t, 60, body.Event.AvailableTickets)
+			}
+		})
+	}
+}
@@ -1,10 +1,15 @@
 package events
 
 import (
+	storage "API/internal/Storage"
 	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
 	resp "API/internal/lib/api/response"
 	"API/internal/lib/logger/sl"
+	"API/internal/lib/textlang"
 	"API/internal/models"
+	"context"
+	"errors"
 	"net/http"
 	"time"
 
@@ -15,22 +20,37 @@ import (
 )
 
 // EventCreator интерфейс для создания мероприятий
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --all
 type EventCreator interface {
-	CreateEvent(event *models.Event) (*models.Event, error)
+	CreateEvent(ctx context.Context, event *models.Event) (*models.Event, error)
 }
 
 // CreateRequest структура запроса на создание мероприятия
 type CreateRequest struct {
 	Title       string  `json:"title" validate:"required,min=3,max=200"`
 	Description string  `json:"description" validate:"max=2000"`
-	Category    string  `json:"category" validate:"required,oneof=concert sport theater exhibition festival other"`
+	Category    string  `json:"category" validate:"required,event_category"`
 	ImageURL    *string `json:"image_url,omitempty" validate:"omitempty,url"`
-	Venue       string  `json:"venue" validate:"required,max=255"`
-	Address     string  `json:"address" validate:"required,max=500"`
-	Price       float64 `json:"price" validate:"gte=0"`
-	Capacity    int     `json:"capacity" validate:"required,min=1"`
-	StartTime   string  `json:"start_time" validate:"required"`
-	EndTime     string  `json:"end_time" validate:"required"`
+	// VenueID площадка из справочника. С ней venue, address, capacity и координаты
+	// необязательны и берутся из площадки; название площадки всегда из справочника
+	VenueID   *int64  `json:"venue_id,omitempty" validate:"required_with=SeatMapID,omitempty,min=1" example:"1"`
+	Venue     string  `json:"venue" validate:"required_without=VenueID,max=255"`
+	Address   string  `json:"address" validate:"required_without=VenueID,max=500"`
+	Price     float64 `json:"price" validate:"gte=0"`
+	Capacity  int     `json:"capacity" validate:"required_without=VenueID,omitempty,min=1"`
+	StartTime string  `json:"start_time" validate:"required"`
+	EndTime   string  `json:"end_time" validate:"required"`
+	// Language язык текста для поиска; если не указан, определяется по названию и описанию
+	Language string `json:"language,omitempty" validate:"omitempty,event_language" example:"russian"`
+	// Latitude и Longitude координаты места проведения, задаются вместе
+	Latitude  *float64 `json:"latitude,omitempty" validate:"required_with=Longitude,omitempty,latitude" example:"55.7814"`
+	Longitude *float64 `json:"longitude,omitempty" validate:"required_with=Latitude,omitempty,longitude" example:"37.6258"`
+	// SeatMapID схема зала площадки venue_id для продажи мест; capacity и price
+	// тогда считаются по местам и ценам категорий
+	SeatMapID *int64 `json:"seat_map_id,omitempty" validate:"omitempty,min=1" example:"1"`
+	// SeatPrices цена каждой ценовой категории схемы зала
+	SeatPrices map[string]float64 `json:"seat_prices,omitempty" validate:"required_with=SeatMapID,dive,gte=0"`
 }
 
 // CreateResponse структура ответа при создании мероприятия
@@ -40,6 +60,23 @@ type CreateResponse struct {
 }
 
 // NewCreate создает хендлер для создания мероприятия
+// @Summary Создать мероприятие
+// @Description Создает мероприятие от имени текущего пользователя. Площадка задается venue_id из
+// @Description справочника или названием и адресом; название, совпадающее с площадкой справочника
+// @Description без учета регистра и пунктуации, привязывается к ней. С seat_map_id билеты продаются на места
+// @Description схемы зала по ценам seat_prices
+// @Tags events
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body CreateRequest true "Данные мероприятия"
+// @Success 201 {object} CreateResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response "Площадка или схема зала не найдена"
+// @Failure 422 {object} resp.Response "seat_prices не совпадают с категориями схемы зала"
+// @Failure 500 {object} resp.Response
+// @Router /events [post]
 func NewCreate(log *slog.Logger, eventCreator EventCreator) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.events.Create"
@@ -47,62 +84,56 @@ func NewCreate(log *slog.Logger, eventCreator EventCreator) http.HandlerFunc {
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
 		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
 		if !ok {
 			log.Error("user_id not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
-			render.JSON(w, r, resp.Error("unauthorized"))
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
 			return
 		}
 
 		var req CreateRequest
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.DecodeJSON(r, &req)
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("invalid request body"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "invalid request body"))
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if err := validator.New().Struct(req); err != nil {
+		if err := request.Validate(req); err != nil {
 			validateErr := err.(validator.ValidationErrors)
 			log.Error("validation failed", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.ValidationError(validateErr))
+			resp.Render(w, r, http.StatusBadRequest, resp.ValidationError(validateErr))
 			return
 		}
 
 		startTime, err := time.Parse(time.RFC3339, req.StartTime)
 		if err != nil {
 			log.Error("invalid start_time format", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("invalid start_time format, use RFC3339"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeValidationFailed, "invalid start_time format, use RFC3339"))
 			return
 		}
 
 		endTime, err := time.Parse(time.RFC3339, req.EndTime)
 		if err != nil {
 			log.Error("invalid end_time format", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("invalid end_time format, use RFC3339"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeValidationFailed, "invalid end_time format, use RFC3339"))
 			return
 		}
 
 		if !endTime.After(startTime) {
 			log.Error("end_time must be after start_time")
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("end_time must be after start_time"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeValidationFailed, "end_time must be after start_time"))
 			return
 		}
 
 		if startTime.Before(time.Now()) {
 			log.Error("start_time must be in the future")
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("start_time must be in the future"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeValidationFailed, "start_time must be in the future"))
 			return
 		}
 
@@ -111,6 +142,7 @@ func NewCreate(log *slog.Logger, eventCreator EventCreator) http.HandlerFunc {
 			Description: req.Description,
 			Category:    req.Category,
 			ImageURL:    req.ImageURL,
+			VenueID:     req.VenueID,
 			Venue:       req.Venue,
 			Address:     req.Address,
 			Price:       req.Price,
@@ -118,19 +150,32 @@ func NewCreate(log *slog.Logger, eventCreator EventCreator) http.HandlerFunc {
 			StartTime:   startTime,
 			EndTime:     endTime,
 			CreatorID:   userID,
+			Language:    req.Language,
+			Latitude:    req.Latitude,
+			Longitude:   req.Longitude,
+			SeatMapID:   req.SeatMapID,
+			SeatPrices:  req.SeatPrices,
+		}
+		if event.Language == "" {
+			event.Language = textlang.Detect(req.Title, req.Description)
 		}
 
-		createdEvent, err := eventCreator.CreateEvent(event)
+		createdEvent, err := eventCreator.CreateEvent(r.Context(), event)
+		if errors.Is(err, storage.ErrVenueNotFound) || errors.Is(err, storage.ErrSeatMapNotFound) ||
+			errors.Is(err, storage.ErrSeatPrices) {
+			log.Info("event rejected", sl.Err(err), slog.Any("venue_id", req.VenueID), slog.Any("seat_map_id", req.SeatMapID))
+			resp.RenderStorageError(w, r, err)
+			return
+		}
 		if err != nil {
 			log.Error("failed to create event", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("failed to create event"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to create event"))
 			return
 		}
 
 		log.Info("event created", slog.Int64("event_id", createdEvent.ID))
 
-		w.WriteHeader(http.StatusCreated)
+		render.Status(r, http.StatusCreated)
 		render.JSON(w, r, CreateResponse{
 			Response: resp.OK(),
 			Event:    createdEvent.ToResponse(),
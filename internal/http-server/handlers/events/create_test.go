@@ -0,0 +1,239 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"API/internal/http-server/handlers/events/mocks"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+func newCreateRequest(t *testing.T, body string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/events", bytes.NewReader([]byte(body)))
+	require.NoError(t, err)
+
+	// Хэндлер стоит за JWTAuth, который кладет user_id в контекст
+	return req.WithContext(context.WithValue(req.Context(), authMiddleware.UserIDKey, int64(42)))
+}
+
+func TestCreateHandlerLocation(t *testing.T) {
+	cases := []struct {
+		name       string // Имя теста
+		location   string // Поля координат в JSON
+		respCode   string // Указываем какой код ошибки хотим получить
+		respStatus int    // Ожидаемый HTTP статус
+		callsMock  bool   // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Without Location",
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Valid Pair",
+			location:   `, "latitude": 55.7814, "longitude": 37.6258`,
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Latitude Without Longitude",
+			location:   `, "latitude": 55.7814`,
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Longitude Without Latitude",
+			location:   `, "longitude": 37.6258`,
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Latitude Out Of Range",
+			location:   `, "latitude": 91, "longitude": 37.6258`,
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Longitude Out Of Range",
+			location:   `, "latitude": 55.7814, "longitude": -181`,
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		},
+	}
+
+	start := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			eventCreatorMock := mocks.NewEventCreator(t)
+			if tc.callsMock {
+				eventCreatorMock.On("CreateEvent", mock.Anything, mock.AnythingOfType("*models.Event")).
+					Return(func(_ context.Context, event *models.Event) *models.Event {
+						event.ID = 1
+						return event
+					}, nil).
+					Once()
+			}
+
+			input := fmt.Sprintf(`{"title": "Концерт", "category": "concert", "venue": "Клуб", "address": "Москва",
+				"capacity": 100, "start_time": "%s", "end_time": "%s"%s}`,
+				start.Format(time.RFC3339), start.Add(2*time.Hour).Format(time.RFC3339), tc.location)
+
+			rr := httptest.NewRecorder()
+			NewCreate(slogdiscard.NewDiscardLogger(), eventCreatorMock).ServeHTTP(rr, newCreateRequest(t, input))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body CreateResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+		})
+	}
+}
+
+func TestCreateHandlerLanguage(t *testing.T) {
+	cases := []struct {
+		name       string // Имя теста
+		language   string // Поле language в JSON
+		stored     string // Язык, который хэндлер передает в сторадж
+		respCode   string // Указываем какой код ошибки хотим получить
+		respStatus int    // Ожидаемый HTTP статус
+		callsMock  bool   // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			// без языка он определяется по тексту
+			name:       "Detected",
+			stored:     models.LanguageRussian,
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Explicit",
+			language:   `, "language": "simple"`,
+			stored:     models.LanguageSimple,
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Unknown",
+			language:   `, "language": "klingon"`,
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		},
+	}
+
+	start := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			eventCreatorMock := mocks.NewEventCreator(t)
+			if tc.callsMock {
+				eventCreatorMock.On("CreateEvent", mock.Anything, mock.MatchedBy(func(event *models.Event) bool {
+					return event.Language == tc.stored
+				})).
+					Return(func(_ context.Context, event *models.Event) *models.Event {
+						event.ID = 1
+						return event
+					}, nil).
+					Once()
+			}
+
+			input := fmt.Sprintf(`{"title": "Концерт", "category": "concert", "venue": "Клуб", "address": "Москва",
+				"capacity": 100, "start_time": "%s", "end_time": "%s"%s}`,
+				start.Format(time.RFC3339), start.Add(2*time.Hour).Format(time.RFC3339), tc.language)
+
+			rr := httptest.NewRecorder()
+			NewCreate(slogdiscard.NewDiscardLogger(), eventCreatorMock).ServeHTTP(rr, newCreateRequest(t, input))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body CreateResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respStatus == http.StatusBadRequest {
+				// допустимые значения в ошибке берутся из models.EventLanguages
+				require.Equal(t, "event_language", body.Details[0].Rule)
+				require.Equal(t, "field language must be one of: russian english simple", body.Details[0].Message)
+			}
+		})
+	}
+}
+
+func TestCreateHandlerCategory(t *testing.T) {
+	cases := []struct {
+		name       string // Имя теста
+		category   string // Поле category в JSON
+		respCode   string // Указываем какой код ошибки хотим получить
+		respStatus int    // Ожидаемый HTTP статус
+		callsMock  bool   // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Known",
+			category:   "festival",
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Unknown",
+			category:   "circus",
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Empty",
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		},
+	}
+
+	start := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			eventCreatorMock := mocks.NewEventCreator(t)
+			if tc.callsMock {
+				eventCreatorMock.On("CreateEvent", mock.Anything, mock.MatchedBy(func(event *models.Event) bool {
+					return event.Category == tc.category
+				})).
+					Return(func(_ context.Context, event *models.Event) *models.Event {
+						event.ID = 1
+						return event
+					}, nil).
+					Once()
+			}
+
+			input := fmt.Sprintf(`{"title": "Концерт", "category": %q, "venue": "Клуб", "address": "Москва",
+				"capacity": 100, "start_time": "%s", "end_time": "%s"}`,
+				tc.category, start.Format(time.RFC3339), start.Add(2*time.Hour).Format(time.RFC3339))
+
+			rr := httptest.NewRecorder()
+			NewCreate(slogdiscard.NewDiscardLogger(), eventCreatorMock).ServeHTTP(rr, newCreateRequest(t, input))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body CreateResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+		})
+	}
+
+	t.Run("Error Lists Categories", func(t *testing.T) {
+		input := fmt.Sprintf(`{"title": "Концерт", "category": "circus", "venue": "Клуб", "address": "Москва",
+			"capacity": 100, "start_time": "%s", "end_time": "%s"}`,
+			start.Format(time.RFC3339), start.Add(2*time.Hour).Format(time.RFC3339))
+
+		rr := httptest.NewRecorder()
+		NewCreate(slogdiscard.NewDiscardLogger(), mocks.NewEventCreator(t)).ServeHTTP(rr, newCreateRequest(t, input))
+
+		var body CreateResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		// допустимые значения в ошибке берутся из models.EventCategories
+		require.Equal(t, "event_category", body.Details[0].Rule)
+		require.Equal(t, "field category must be one of: concert sport theater exhibition festival other", body.Details[0].Message)
+	})
+}
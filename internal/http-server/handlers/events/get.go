@@ -2,9 +2,12 @@ package events
 
 import (
 	storage "API/internal/Storage"
+	"API/internal/lib/api/cursor"
+	"API/internal/lib/api/request"
 	resp "API/internal/lib/api/response"
 	"API/internal/lib/logger/sl"
 	"API/internal/models"
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
@@ -17,8 +20,8 @@ import (
 
 // EventGetter интерфейс для получения мероприятий
 type EventGetter interface {
-	GetEventByID(id int64) (*models.Event, error)
-	GetAllEvents(limit, offset int) ([]*models.Event, error)
+	GetEventByID(ctx context.Context, id int64) (*models.Event, error)
+	GetAllEvents(ctx context.Context, filter models.EventFilter, page models.Page) ([]*models.Event, *models.Cursor, error)
 }
 
 // GetByIDResponse структура ответа при получении мероприятия по ID
@@ -34,6 +37,8 @@ type GetAllResponse struct {
 	resp.Response
 	Events []models.EventResponse `json:"events"`
 	Total  int                    `json:"total" example:"10"`
+	// NextCursor передается в cursor для следующей страницы; пустой на последней
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // NewGetByID создает хендлер для получения мероприятия по ID
@@ -41,7 +46,7 @@ type GetAllResponse struct {
 // @Description Возвращает мероприятие по его ID. Требуется JWT авторизация.
 // @Tags events
 // @Produce json
-// @Param Authorization header string true "Bearer JWT токен"
+// @Security BearerAuth
 // @Param id path int true "ID мероприятия"
 // @Success 200 {object} GetByIDResponse
 // @Failure 400 {object} resp.Response
@@ -56,35 +61,32 @@ func NewGetByID(log *slog.Logger, eventGetter EventGetter) http.HandlerFunc {
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
 		idStr := chi.URLParam(r, "id")
 		if idStr == "" {
 			log.Error("id parameter is empty")
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("id parameter is required"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "id parameter is required"))
 			return
 		}
 
 		id, err := strconv.ParseInt(idStr, 10, 64)
 		if err != nil {
 			log.Error("invalid id format", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("invalid id format"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid id format"))
 			return
 		}
 
-		event, err := eventGetter.GetEventByID(id)
+		event, err := eventGetter.GetEventByID(r.Context(), id)
 		if errors.Is(err, storage.ErrEventNotFound) {
 			log.Info("event not found", slog.Int64("id", id))
-			w.WriteHeader(http.StatusNotFound)
-			render.JSON(w, r, resp.Error("event not found"))
+			resp.Render(w, r, http.StatusNotFound, resp.Error(resp.CodeEventNotFound, "event not found"))
 			return
 		}
 		if err != nil {
 			log.Error("failed to get event", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("internal error"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "internal error"))
 			return
 		}
 
@@ -99,13 +101,23 @@ func NewGetByID(log *slog.Logger, eventGetter EventGetter) http.HandlerFunc {
 
 // NewGetAll создает хендлер для получения всех мероприятий
 // @Summary Получение списка мероприятий
-// @Description Возвращает список всех мероприятий с пагинацией. Требуется JWT авторизация.
+// @Description Возвращает мероприятия по дате начала постранично. Требуется JWT авторизация.
+// @Description По умолчанию только еще не начавшиеся мероприятия.
+// @Description Следующая страница запрашивается по next_cursor; offset оставлен для совместимости
 // @Tags events
 // @Produce json
-// @Param Authorization header string true "Bearer JWT токен"
+// @Security BearerAuth
+// @Param category query []string false "Категории (concert, sport, theater, exhibition, festival, other), несколько через запятую или повтором" collectionFormat(multi)
+// @Param creator_id query int false "Только мероприятия автора"
+// @Param venue_id query int false "Только мероприятия на площадке"
+// @Param only_available query bool false "Только с доступными билетами"
+// @Param upcoming query bool false "Только еще не начавшиеся (по умолчанию true); false добавляет идущие сейчас"
+// @Param include_past query bool false "Вместе с завершившимися, снимает ограничение upcoming"
 // @Param limit query int false "Количество записей (по умолчанию 20, максимум 100)"
-// @Param offset query int false "Смещение (по умолчанию 0)"
+// @Param offset query int false "Смещение (по умолчанию 0), игнорируется вместе с cursor"
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
 // @Success 200 {object} GetAllResponse
+// @Failure 400 {object} resp.Response
 // @Failure 401 {object} resp.Response
 // @Failure 500 {object} resp.Response
 // @Router /events [get]
@@ -116,32 +128,28 @@ func NewGetAll(log *slog.Logger, eventGetter EventGetter) http.HandlerFunc {
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
 		// Пагинация
-		limitStr := r.URL.Query().Get("limit")
-		offsetStr := r.URL.Query().Get("offset")
-
-		limit := 20 // default
-		offset := 0
-
-		if limitStr != "" {
-			if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-				limit = l
-			}
+		page, err := request.Page(r, models.SortDate)
+		if err != nil {
+			log.Info("invalid cursor", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid cursor"))
+			return
 		}
 
-		if offsetStr != "" {
-			if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
-				offset = o
-			}
+		// Фильтры
+		filter, err := request.EventFilter(r.URL.Query())
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, err.Error()))
+			return
 		}
 
-		events, err := eventGetter.GetAllEvents(limit, offset)
+		events, next, err := eventGetter.GetAllEvents(r.Context(), filter, page)
 		if err != nil {
 			log.Error("failed to get events", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("internal error"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "internal error"))
 			return
 		}
 
@@ -154,9 +162,10 @@ func NewGetAll(log *slog.Logger, eventGetter EventGetter) http.HandlerFunc {
 		log.Info("events retrieved", slog.Int("count", len(events)))
 
 		render.JSON(w, r, GetAllResponse{
-			Response: resp.OK(),
-			Events:   eventResponses,
-			Total:    len(eventResponses),
+			Response:   resp.OK(),
+			Events:     eventResponses,
+			Total:      len(eventResponses),
+			NextCursor: cursor.Encode(next),
 		})
 	}
 }
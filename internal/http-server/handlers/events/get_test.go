@@ -0,0 +1,107 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"API/internal/http-server/handlers/events/mocks"
+	"API/internal/lib/api/cursor"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+func TestGetAllHandlerPagination(t *testing.T) {
+	after := models.Cursor{Order: models.SortDate, Time: time.Date(2026, 5, 1, 19, 0, 0, 0, time.UTC), ID: 10}
+	next := models.Cursor{Order: models.SortDate, Time: time.Date(2026, 6, 1, 19, 0, 0, 0, time.UTC), ID: 12}
+	newest := models.NewestCursor(after.Time, after.ID)
+
+	cases := []struct {
+		name       string         // Имя теста
+		query      string         // Строка запроса
+		page       models.Page    // Страница, которую хэндлер передает в сторадж
+		next       *models.Cursor // Курсор следующей страницы из стораджа
+		respCode   string         // Указываем какой код ошибки хотим получить
+		respStatus int            // Ожидаемый HTTP статус
+		mockError  error          // Ошибка которую выдает mock
+		callsMock  bool           // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Default Limit",
+			page:       models.Page{Limit: request.DefaultLimit},
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Limit And Offset",
+			query:      "?limit=5&offset=10",
+			page:       models.Page{Limit: 5, Offset: 10},
+			next:       &next,
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Cursor",
+			query:      "?limit=5&cursor=" + cursor.Encode(&after),
+			page:       models.Page{Limit: 5, After: &after},
+			next:       &next,
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Broken Cursor",
+			query:      "?cursor=not-a-cursor",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Cursor Of Other Order",
+			query:      "?cursor=" + cursor.Encode(&newest),
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Storage Error",
+			page:       models.Page{Limit: request.DefaultLimit},
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("unexpected error"),
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			getterMock := mocks.NewEventGetter(t)
+			if tc.callsMock {
+				var events []*models.Event
+				if tc.mockError == nil {
+					events = []*models.Event{{ID: 11, Title: "Концерт"}}
+				}
+				getterMock.On("GetAllEvents", mock.Anything, models.DefaultEventFilter(), tc.page).
+					Return(events, tc.next, tc.mockError).
+					Once()
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/events"+tc.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			NewGetAll(slogdiscard.NewDiscardLogger(), getterMock).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body GetAllResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respStatus == http.StatusOK {
+				require.Len(t, body.Events, 1)
+				require.Equal(t, 1, body.Total)
+				require.Equal(t, cursor.Encode(tc.next), body.NextCursor)
+			}
+		})
+	}
+}
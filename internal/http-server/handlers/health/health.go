@@ -0,0 +1,157 @@
+package health
+
+import (
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"golang.org/x/exp/slog"
+)
+
+const (
+	DependencyUp   = "up"
+	DependencyDown = "down"
+)
+
+// Check описывает проверку одной зависимости сервиса
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// Status хранит признак готовности инстанса принимать трафик.
+// До окончания старта и после начала остановки инстанс не готов.
+type Status struct {
+	ready atomic.Bool
+}
+
+// NewStatus создает Status в состоянии "не готов"
+func NewStatus() *Status {
+	return &Status{}
+}
+
+// SetReady переключает готовность инстанса
+func (s *Status) SetReady(ready bool) {
+	s.ready.Store(ready)
+}
+
+// Ready сообщает, готов ли инстанс принимать трафик
+func (s *Status) Ready() bool {
+	return s.ready.Load()
+}
+
+// DependencyStatus результат проверки зависимости
+type DependencyStatus struct {
+	Status    string  `json:"status" example:"up"`
+	LatencyMs float64 `json:"latency_ms" example:"1.25"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// ReadinessResponse ответ проверки готовности
+type ReadinessResponse struct {
+	resp.Response
+	Ready        bool                        `json:"ready"`
+	Dependencies map[string]DependencyStatus `json:"dependencies,omitempty"`
+}
+
+// NewLiveness возвращает хендлер, сообщающий, что процесс жив.
+// Зависимости не проверяются, чтобы оркестратор не перезапускал
+// инстанс из-за недоступной базы.
+func NewLiveness() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, resp.OK())
+	}
+}
+
+// NewReadiness возвращает хендлер проверки готовности.
+// Отвечает 503, пока инстанс запускается или останавливается,
+// а также если хотя бы одна зависимость недоступна.
+func NewReadiness(log *slog.Logger, status *Status, timeout time.Duration, checks ...Check) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.health.Readiness"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		if !status.Ready() {
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, ReadinessResponse{
+				Response: resp.Error(resp.CodeNotReady, "service is not ready"),
+				Ready:    false,
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		dependencies := runChecks(ctx, checks)
+
+		ready := true
+		for name, dep := range dependencies {
+			if dep.Status != DependencyUp {
+				ready = false
+				log.Warn("dependency check failed", slog.String("dependency", name), slog.String("error", dep.Error))
+			}
+		}
+
+		if !ready {
+			render.Status(r, http.StatusServiceUnavailable)
+			render.JSON(w, r, ReadinessResponse{
+				Response:     resp.Error(resp.CodeNotReady, "dependency check failed"),
+				Ready:        false,
+				Dependencies: dependencies,
+			})
+			return
+		}
+
+		render.JSON(w, r, ReadinessResponse{
+			Response:     resp.OK(),
+			Ready:        true,
+			Dependencies: dependencies,
+		})
+	}
+}
+
+// runChecks параллельно выполняет проверки и замеряет их время
+func runChecks(ctx context.Context, checks []Check) map[string]DependencyStatus {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		result = make(map[string]DependencyStatus, len(checks))
+	)
+
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c Check) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := c.Check(ctx)
+			dep := DependencyStatus{
+				Status:    DependencyUp,
+				LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				dep.Status = DependencyDown
+				dep.Error = err.Error()
+			}
+
+			mu.Lock()
+			result[c.Name] = dep
+			mu.Unlock()
+		}(c)
+	}
+	wg.Wait()
+
+	return result
+}
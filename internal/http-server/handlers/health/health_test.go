@@ -0,0 +1,73 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"API/internal/lib/logger/handlers/slogdiscard"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	okCheck := Check{Name: "postgres", Check: func(context.Context) error { return nil }}
+	failCheck := Check{Name: "migrations", Check: func(context.Context) error { return errors.New("schema is outdated") }}
+
+	cases := []struct {
+		name       string
+		ready      bool    // Готовность инстанса
+		checks     []Check // Проверки зависимостей
+		wantStatus int
+		wantDeps   map[string]string // Ожидаемый статус по каждой зависимости
+	}{
+		{
+			name:       "Starting",
+			ready:      false,
+			checks:     []Check{okCheck},
+			wantStatus: http.StatusServiceUnavailable,
+		}, {
+			name:       "All dependencies up",
+			ready:      true,
+			checks:     []Check{okCheck},
+			wantStatus: http.StatusOK,
+			wantDeps:   map[string]string{"postgres": DependencyUp},
+		}, {
+			name:       "Dependency down",
+			ready:      true,
+			checks:     []Check{okCheck, failCheck},
+			wantStatus: http.StatusServiceUnavailable,
+			wantDeps:   map[string]string{"postgres": DependencyUp, "migrations": DependencyDown},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := NewStatus()
+			status.SetReady(tc.ready)
+
+			handler := NewReadiness(slogdiscard.NewDiscardLogger(), status, time.Second, tc.checks...)
+
+			req, err := http.NewRequest(http.MethodGet, "/readyz", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.wantStatus, rr.Code)
+
+			var resp ReadinessResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, tc.wantStatus == http.StatusOK, resp.Ready)
+
+			require.Len(t, resp.Dependencies, len(tc.wantDeps))
+			for name, want := range tc.wantDeps {
+				require.Equal(t, want, resp.Dependencies[name].Status)
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package profile
 
 import (
 	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
 	resp "API/internal/lib/api/response"
 	"API/internal/lib/logger/sl"
+	"API/internal/lib/metrics"
+	"context"
 	"net/http"
 
 	"github.com/go-chi/chi/middleware"
@@ -14,7 +17,7 @@ import (
 
 // BalanceUpdater интерфейс для пополнения баланса
 type BalanceUpdater interface {
-	UpdateUserBalance(userID int64, amount float64) error
+	UpdateUserBalance(ctx context.Context, userID int64, amount float64) error
 }
 
 // TopUpBalanceRequest запрос на пополнение баланса
@@ -42,40 +45,39 @@ func NewTopUpBalance(log *slog.Logger, updater BalanceUpdater) http.HandlerFunc
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
 		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
 		if !ok {
 			log.Error("user_id not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
-			render.JSON(w, r, resp.Error("unauthorized"))
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
 			return
 		}
 
 		var req TopUpBalanceRequest
-		if err := render.DecodeJSON(r.Body, &req); err != nil {
+		if err := request.DecodeJSON(r, &req); err != nil {
 			log.Error("failed to decode request", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("invalid request body"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "invalid request body"))
 			return
 		}
 
-		validate := validator.New()
-		if err := validate.Struct(req); err != nil {
+		if err := request.Validate(req); err != nil {
 			validateErr := err.(validator.ValidationErrors)
 			log.Error("validation failed", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.ValidationError(validateErr))
+			resp.Render(w, r, http.StatusBadRequest, resp.ValidationError(validateErr))
 			return
 		}
 
-		if err := updater.UpdateUserBalance(userID, req.Amount); err != nil {
+		if err := updater.UpdateUserBalance(r.Context(), userID, req.Amount); err != nil {
 			log.Error("failed to top up balance", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("failed to top up balance"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to top up balance"))
 			return
 		}
 
+		metrics.BalanceTopUps.Inc()
+		metrics.BalanceTopUpAmount.Add(req.Amount)
+
 		log.Info("balance topped up", slog.Float64("amount", req.Amount), slog.Int64("user_id", userID))
 		render.JSON(w, r, resp.OK())
 	}
@@ -5,6 +5,7 @@ import (
 	resp "API/internal/lib/api/response"
 	"API/internal/lib/logger/sl"
 	"API/internal/models"
+	"context"
 	"net/http"
 
 	"github.com/go-chi/chi/middleware"
@@ -14,7 +15,7 @@ import (
 
 // ProfileGetter интерфейс для получения профиля
 type ProfileGetter interface {
-	GetUserByID(id int64) (*models.User, error)
+	GetUserByID(ctx context.Context, id int64) (*models.User, error)
 }
 
 // GetProfileResponse ответ с профилем
@@ -40,21 +41,20 @@ func NewGet(log *slog.Logger, getter ProfileGetter) http.HandlerFunc {
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
 		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
 		if !ok {
 			log.Error("user_id not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
-			render.JSON(w, r, resp.Error("unauthorized"))
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
 			return
 		}
 
-		user, err := getter.GetUserByID(userID)
+		user, err := getter.GetUserByID(r.Context(), userID)
 		if err != nil {
 			log.Error("failed to get user", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("failed to get profile"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to get profile"))
 			return
 		}
 
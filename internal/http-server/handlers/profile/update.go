@@ -2,9 +2,11 @@ package profile
 
 import (
 	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
 	resp "API/internal/lib/api/response"
 	"API/internal/lib/logger/sl"
 	"API/internal/models"
+	"context"
 	"net/http"
 
 	"github.com/go-chi/chi/middleware"
@@ -15,7 +17,7 @@ import (
 
 // ProfileUpdater интерфейс для обновления профиля
 type ProfileUpdater interface {
-	UpdateUserProfile(userID int64, name string, phone, avatarURL, bio *string) (*models.User, error)
+	UpdateUserProfile(ctx context.Context, userID int64, name string, phone, avatarURL, bio *string) (*models.User, error)
 }
 
 // UpdateProfileRequest запрос на обновление профиля
@@ -52,38 +54,34 @@ func NewUpdate(log *slog.Logger, updater ProfileUpdater) http.HandlerFunc {
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
 		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
 		if !ok {
 			log.Error("user_id not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
-			render.JSON(w, r, resp.Error("unauthorized"))
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
 			return
 		}
 
 		var req UpdateProfileRequest
-		if err := render.DecodeJSON(r.Body, &req); err != nil {
+		if err := request.DecodeJSON(r, &req); err != nil {
 			log.Error("failed to decode request", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.Error("invalid request body"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "invalid request body"))
 			return
 		}
 
-		validate := validator.New()
-		if err := validate.Struct(req); err != nil {
+		if err := request.Validate(req); err != nil {
 			validateErr := err.(validator.ValidationErrors)
 			log.Error("validation failed", sl.Err(err))
-			w.WriteHeader(http.StatusBadRequest)
-			render.JSON(w, r, resp.ValidationError(validateErr))
+			resp.Render(w, r, http.StatusBadRequest, resp.ValidationError(validateErr))
 			return
 		}
 
-		user, err := updater.UpdateUserProfile(userID, req.Name, req.Phone, req.AvatarURL, req.Bio)
+		user, err := updater.UpdateUserProfile(r.Context(), userID, req.Name, req.Phone, req.AvatarURL, req.Bio)
 		if err != nil {
 			log.Error("failed to update profile", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("failed to update profile"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to update profile"))
 			return
 		}
 
@@ -0,0 +1,125 @@
+package redirect
+
+import (
+	storage "API/internal/Storage"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/lib/metrics"
+	"API/internal/lib/urlpolicy"
+	"API/internal/models"
+	"context"
+	_ "embed"
+	"errors"
+	"html/template"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"golang.org/x/exp/slog"
+)
+
+// URLGetter интерфейс для получения URL по алиасу и списания перехода
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --all
+type URLGetter interface {
+	GetURL(ctx context.Context, alias string) (*models.URL, error)
+	CountClick(ctx context.Context, urlID int64) error
+}
+
+// ClickRecorder интерфейс для учета переходов. Record не должен блокироваться.
+type ClickRecorder interface {
+	Record(r *http.Request, alias string)
+}
+
+//go:embed interstitial.html
+var interstitialHTML string
+
+var interstitial = template.Must(template.New("interstitial").Parse(interstitialHTML))
+
+// New возвращает хендлер редиректа по короткой ссылке. Политика адресов
+// проверяется и при редиректе, чтобы новые записи в списках действовали на старые ссылки.
+func New(log *slog.Logger, urlGetter URLGetter, recorder ClickRecorder, policy urlpolicy.Checker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.redirect.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		alias := chi.URLParam(r, "alias")
+		if alias == "" {
+			log.Error("alias is empty")
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "alias is empty"))
+			return
+		}
+
+		u, err := urlGetter.GetURL(r.Context(), alias)
+		if errors.Is(err, storage.ErrURLNotFound) {
+			log.Error("url not found", slog.String("alias", alias))
+			resp.Render(w, r, http.StatusNotFound, resp.Error(resp.CodeURLNotFound, "url not found"))
+			return
+		}
+		if errors.Is(err, storage.ErrURLExpired) {
+			log.Info("url expired", slog.String("alias", alias))
+			resp.Render(w, r, http.StatusGone, resp.Error(resp.CodeURLExpired, "url expired"))
+			return
+		}
+		if err != nil {
+			log.Error("failed to get URL", sl.Err(err))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "internal error"))
+			return
+		}
+
+		resURL := u.URL
+
+		decision := policy.Check(resURL, r.Host)
+		if decision.Verdict == urlpolicy.Block {
+			log.Warn("redirect blocked by policy", slog.String("alias", alias), slog.String("reason", decision.Reason))
+			resp.Render(w, r, http.StatusForbidden, resp.Error(resp.CodeURLNotAllowed, "url is not allowed: "+decision.Reason))
+			return
+		}
+
+		// Переход списывается только после проверки политики,
+		// чтобы заблокированные редиректы не расходовали лимит ссылки
+		if u.MaxClicks != nil {
+			err := urlGetter.CountClick(r.Context(), u.ID)
+			if errors.Is(err, storage.ErrURLExpired) {
+				log.Info("url clicks exhausted", slog.String("alias", alias))
+				resp.Render(w, r, http.StatusGone, resp.Error(resp.CodeURLExpired, "url expired"))
+				return
+			}
+			if err != nil {
+				log.Error("failed to count click", sl.Err(err))
+				resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "internal error"))
+				return
+			}
+		}
+
+		recorder.Record(r, alias)
+		metrics.RedirectsServed.Inc()
+
+		if decision.Verdict == urlpolicy.Warn {
+			log.Info("showing interstitial", slog.String("alias", alias), slog.String("url", resURL))
+			renderInterstitial(w, log, resURL)
+			return
+		}
+
+		log.Info("redirecting", slog.String("alias", alias), slog.String("url", resURL))
+		http.Redirect(w, r, resURL, http.StatusFound)
+	}
+}
+
+// renderInterstitial показывает страницу с предупреждением вместо редиректа
+func renderInterstitial(w http.ResponseWriter, log *slog.Logger, target string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Robots-Tag", "noindex")
+	w.WriteHeader(http.StatusOK)
+
+	if err := interstitial.Execute(w, struct{ URL string }{URL: target}); err != nil {
+		log.Error("failed to render interstitial", sl.Err(err))
+	}
+}
@@ -0,0 +1,224 @@
+package redirect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/Storage/sqlite"
+	"API/internal/http-server/handlers/redirect/mocks"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/lib/urlpolicy"
+	policyMocks "API/internal/lib/urlpolicy/mocks"
+	"API/internal/models"
+)
+
+func TestRedirectHandler(t *testing.T) {
+	const target = "https://example.com/events/42"
+	maxClicks := 10
+
+	cases := []struct {
+		name       string            // Имя теста
+		verdict    urlpolicy.Verdict // Решение политики по адресу ссылки
+		respCode   string            // Указываем какой код ошибки хотим получить
+		respStatus int               // Ожидаемый HTTP статус
+		mockError  error             // Ошибка которую выдает mock
+		maxClicks  *int              // Лимит переходов ссылки
+		countError error             // Ошибка списания перехода
+		checks     bool              // Должен ли хэндлер дойти до политики
+		counts     bool              // Должен ли хэндлер списать переход
+		records    bool              // Должен ли переход попасть в статистику
+	}{
+		{
+			name:       "Redirect",
+			verdict:    urlpolicy.Allow,
+			respStatus: http.StatusFound,
+			checks:     true,
+			records:    true,
+		}, {
+			name:       "Interstitial",
+			verdict:    urlpolicy.Warn,
+			respStatus: http.StatusOK,
+			checks:     true,
+			records:    true,
+		}, {
+			name:       "Blocked",
+			verdict:    urlpolicy.Block,
+			respCode:   resp.CodeURLNotAllowed,
+			respStatus: http.StatusForbidden,
+			checks:     true,
+		}, {
+			name:       "Limited Redirect",
+			verdict:    urlpolicy.Allow,
+			respStatus: http.StatusFound,
+			maxClicks:  &maxClicks,
+			checks:     true,
+			counts:     true,
+			records:    true,
+		}, {
+			name:       "Limited Interstitial",
+			verdict:    urlpolicy.Warn,
+			respStatus: http.StatusOK,
+			maxClicks:  &maxClicks,
+			checks:     true,
+			counts:     true,
+			records:    true,
+		}, {
+			// заблокированный редирект не расходует лимит переходов
+			name:       "Limited Blocked",
+			verdict:    urlpolicy.Block,
+			respCode:   resp.CodeURLNotAllowed,
+			respStatus: http.StatusForbidden,
+			maxClicks:  &maxClicks,
+			checks:     true,
+		}, {
+			// последний переход успел списать другой запрос
+			name:       "Clicks Exhausted",
+			verdict:    urlpolicy.Allow,
+			respCode:   resp.CodeURLExpired,
+			respStatus: http.StatusGone,
+			maxClicks:  &maxClicks,
+			countError: storage.ErrURLExpired,
+			checks:     true,
+			counts:     true,
+		}, {
+			name:       "Count Error",
+			verdict:    urlpolicy.Allow,
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			maxClicks:  &maxClicks,
+			countError: errors.New("unexpected error"),
+			checks:     true,
+			counts:     true,
+		}, {
+			name:       "Not Found",
+			respCode:   resp.CodeURLNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrURLNotFound,
+		}, {
+			name:       "Expired",
+			respCode:   resp.CodeURLExpired,
+			respStatus: http.StatusGone,
+			mockError:  storage.ErrURLExpired,
+		}, {
+			name:       "Storage Error",
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			getterMock := mocks.NewURLGetter(t)
+			recorderMock := mocks.NewClickRecorder(t)
+			policyMock := policyMocks.NewChecker(t)
+
+			var u *models.URL
+			if tc.mockError == nil {
+				u = &models.URL{ID: 7, Alias: "promo", URL: target, MaxClicks: tc.maxClicks}
+			}
+			getterMock.On("GetURL", mock.Anything, "promo").
+				Return(u, tc.mockError).
+				Once()
+			// mock падает на неожиданном вызове, поэтому без counts
+			// проверяется, что переход не списан
+			if tc.counts {
+				getterMock.On("CountClick", mock.Anything, int64(7)).
+					Return(tc.countError).
+					Once()
+			}
+
+			if tc.checks {
+				policyMock.On("Check", target, "short.example").
+					Return(urlpolicy.Decision{Verdict: tc.verdict, Reason: urlpolicy.ReasonBlocked}).
+					Once()
+			}
+			if tc.records {
+				recorderMock.On("Record", mock.Anything, "promo").Once()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "http://short.example/promo", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("alias", "promo")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			New(slogdiscard.NewDiscardLogger(), getterMock, recorderMock, policyMock).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			switch tc.respStatus {
+			case http.StatusFound:
+				require.Equal(t, target, rr.Header().Get("Location"))
+			case http.StatusOK:
+				// Вместо редиректа страница с предупреждением и ссылкой на адрес
+				require.Empty(t, rr.Header().Get("Location"))
+				require.Contains(t, rr.Header().Get("Content-Type"), "text/html")
+				require.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+				require.Contains(t, rr.Body.String(), target)
+			default:
+				var body resp.Response
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				require.Equal(t, tc.respCode, body.Code)
+			}
+		})
+	}
+}
+
+func TestBlockedRedirectKeepsMaxClicks(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sqlite.New(filepath.Join(t.TempDir(), "storage.db"))
+	require.NoError(t, err)
+
+	// С лимитом в один переход любой списанный переход сделал бы ссылку исчерпанной
+	maxClicks := 1
+	_, err = db.SaveURL(ctx, "https://blocked.example/", "promo", 1, nil, &maxClicks)
+	require.NoError(t, err)
+
+	serve := func(verdict urlpolicy.Verdict) int {
+		policyMock := policyMocks.NewChecker(t)
+		policyMock.On("Check", "https://blocked.example/", "short.example").
+			Return(urlpolicy.Decision{Verdict: verdict, Reason: urlpolicy.ReasonBlocked}).
+			Once()
+		recorderMock := mocks.NewClickRecorder(t)
+		if verdict != urlpolicy.Block {
+			recorderMock.On("Record", mock.Anything, "promo").Once()
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "http://short.example/promo", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("alias", "promo")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		rr := httptest.NewRecorder()
+		New(slogdiscard.NewDiscardLogger(), db, recorderMock, policyMock).ServeHTTP(rr, req)
+
+		return rr.Code
+	}
+
+	require.Equal(t, http.StatusForbidden, serve(urlpolicy.Block))
+	require.Equal(t, http.StatusForbidden, serve(urlpolicy.Block))
+
+	u, err := db.ResolveURL(ctx, "promo")
+	require.NoError(t, err)
+	require.Zero(t, u.ClickCount)
+
+	// Разрешенный переход по-прежнему доступен и списывает лимит
+	require.Equal(t, http.StatusFound, serve(urlpolicy.Allow))
+
+	u, err = db.ResolveURL(ctx, "promo")
+	require.NoError(t, err)
+	require.Equal(t, 1, u.ClickCount)
+}
@@ -0,0 +1,257 @@
+package search
+
+import (
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/models"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/exp/slog"
+)
+
+// SearchSaver интерфейс для сохранения поиска
+type SearchSaver interface {
+	SaveSearch(ctx context.Context, search *models.SavedSearch, maxPerUser int) (*models.SavedSearch, error)
+}
+
+// SavedSearchLister интерфейс для списка сохраненных поисков
+type SavedSearchLister interface {
+	GetSavedSearches(ctx context.Context, userID int64) ([]*models.SavedSearch, error)
+}
+
+// SavedSearchDeleter интерфейс для удаления сохраненного поиска
+type SavedSearchDeleter interface {
+	DeleteSavedSearch(ctx context.Context, userID, id int64) error
+}
+
+// SaveRequest запрос на сохранение поиска
+type SaveRequest struct {
+	Name string `json:"name" validate:"required,max=100" example:"Концерты до 3000 рядом"`
+	// Query параметры поиска в формате строки запроса GET /search
+	Query string `json:"query" validate:"required,max=2000" example:"category=concert&price_max=3000&lat=55.75&lng=37.62&radius_km=10"`
+}
+
+// SavedSearchResponse ответ с сохраненным поиском
+type SavedSearchResponse struct {
+	resp.Response
+	SavedSearch *models.SavedSearch `json:"saved_search"`
+}
+
+// SavedSearchesResponse ответ со списком сохраненных поисков
+type SavedSearchesResponse struct {
+	resp.Response
+	SavedSearches []*models.SavedSearch `json:"saved_searches"`
+}
+
+// pageParams параметры страницы, которые в сохраненном поиске не нужны
+var pageParams = []string{"limit", "offset", "cursor"}
+
+// parseSavedQuery разбирает строку запроса сохраняемого поиска тем же
+// разбором, что и GET /search, и возвращает ее без параметров страницы
+func parseSavedQuery(raw string) (string, models.EventSearch, error) {
+	values, err := url.ParseQuery(strings.TrimPrefix(raw, "?"))
+	if err != nil {
+		return "", models.EventSearch{}, errors.New("query must be a url query string")
+	}
+	for _, p := range pageParams {
+		values.Del(p)
+	}
+
+	params, err := parseSearch(values)
+	if err != nil {
+		return "", params, err
+	}
+
+	// Поиск только с сортировкой подходит под любое мероприятие
+	criteria := len(values)
+	if values.Has("sort") {
+		criteria--
+	}
+	if criteria == 0 {
+		return "", params, errors.New("query must contain at least one search parameter")
+	}
+
+	return values.Encode(), params, nil
+}
+
+// NewSaveSearch возвращает хендлер сохранения поиска
+// @Summary Сохранить поиск
+// @Description Сохраняет параметры поиска в формате строки запроса GET /search. Когда создается
+// @Description подходящее под них мероприятие, пользователь получает уведомление
+// @Tags search
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body SaveRequest true "Название и параметры поиска"
+// @Success 201 {object} SavedSearchResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 422 {object} resp.Response "Достигнут лимит сохраненных поисков"
+// @Failure 500 {object} resp.Response
+// @Router /search/saved [post]
+func NewSaveSearch(log *slog.Logger, saver SearchSaver, maxPerUser int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.search.SaveSearch"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		var req SaveRequest
+		err := request.DecodeJSON(r, &req)
+		if errors.Is(err, io.EOF) {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeEmptyBody, "empty request body"))
+			return
+		}
+		if err != nil {
+			log.Info("failed to decode request", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "invalid request body"))
+			return
+		}
+
+		if err := request.Validate(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("validation failed", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.ValidationError(validateErr))
+			return
+		}
+
+		query, params, err := parseSavedQuery(req.Query)
+		if err != nil {
+			log.Info("invalid saved search query", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, err.Error()))
+			return
+		}
+
+		saved, err := saver.SaveSearch(r.Context(), &models.SavedSearch{
+			UserID: userID,
+			Name:   req.Name,
+			Query:  query,
+			Params: params,
+		}, maxPerUser)
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("search not saved", sl.Err(err))
+			} else {
+				log.Error("failed to save search", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		log.Info("search saved", slog.Int64("saved_search_id", saved.ID))
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, SavedSearchResponse{Response: resp.OK(), SavedSearch: saved})
+	}
+}
+
+// NewSavedSearches возвращает хендлер списка сохраненных поисков
+// @Summary Сохраненные поиски
+// @Description Сохраненные поиски текущего пользователя, новые первыми
+// @Tags search
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} SavedSearchesResponse
+// @Failure 401 {object} resp.Response
+// @Failure 500 {object} resp.Response
+// @Router /search/saved [get]
+func NewSavedSearches(log *slog.Logger, lister SavedSearchLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.search.SavedSearches"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		searches, err := lister.GetSavedSearches(r.Context(), userID)
+		if err != nil {
+			log.Error("failed to get saved searches", sl.Err(err))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to get saved searches"))
+			return
+		}
+
+		render.JSON(w, r, SavedSearchesResponse{Response: resp.OK(), SavedSearches: searches})
+	}
+}
+
+// NewDeleteSavedSearch возвращает хендлер удаления сохраненного поиска
+// @Summary Удалить сохраненный поиск
+// @Description Удаляет сохраненный поиск; уведомления по нему больше не приходят
+// @Tags search
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID сохраненного поиска"
+// @Success 200 {object} resp.Response
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response "Поиск не найден или принадлежит другому пользователю"
+// @Failure 500 {object} resp.Response
+// @Router /search/saved/{id} [delete]
+func NewDeleteSavedSearch(log *slog.Logger, deleter SavedSearchDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.search.DeleteSavedSearch"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid id format"))
+			return
+		}
+
+		if err := deleter.DeleteSavedSearch(r.Context(), userID, id); err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("saved search not deleted", sl.Err(err), slog.Int64("saved_search_id", id))
+			} else {
+				log.Error("failed to delete saved search", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		log.Info("saved search deleted", slog.Int64("saved_search_id", id))
+		render.JSON(w, r, resp.OK())
+	}
+}
@@ -0,0 +1,212 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+// saverStub запоминает сохраняемый поиск
+type saverStub struct {
+	saved *models.SavedSearch
+	err   error
+}
+
+func (s *saverStub) SaveSearch(_ context.Context, search *models.SavedSearch, _ int) (*models.SavedSearch, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.saved = search
+	search.ID = 7
+	return search, nil
+}
+
+func serveSave(t *testing.T, saver SearchSaver, body string) (int, SavedSearchResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/search/saved", strings.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), authMiddleware.UserIDKey, int64(42)))
+
+	rr := httptest.NewRecorder()
+	NewSaveSearch(slogdiscard.NewDiscardLogger(), saver, 20).ServeHTTP(rr, req)
+
+	var response SavedSearchResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+	return rr.Code, response
+}
+
+func TestSaveSearch(t *testing.T) {
+	saver := &saverStub{}
+
+	status, body := serveSave(t, saver,
+		`{"name":"Джаз","query":"?q=jazz&category=concert&price_max=3000&limit=5&cursor=abc"}`)
+
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, int64(7), body.SavedSearch.ID)
+	require.Equal(t, int64(42), saver.saved.UserID)
+	require.Equal(t, "category=concert&price_max=3000&q=jazz", saver.saved.Query)
+	require.Equal(t, "jazz", saver.saved.Params.Query)
+	require.Equal(t, []string{"concert"}, saver.saved.Params.Categories)
+	require.Equal(t, 3000.0, *saver.saved.Params.PriceMax)
+}
+
+func TestSaveSearchErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "empty body", body: "", status: http.StatusBadRequest, code: resp.CodeEmptyBody},
+		{name: "no name", body: `{"query":"q=jazz"}`, status: http.StatusBadRequest, code: resp.CodeValidationFailed},
+		{name: "only paging", body: `{"name":"x","query":"limit=5&sort=price_asc"}`, status: http.StatusBadRequest, code: resp.CodeInvalidParameter},
+		{name: "bad param", body: `{"name":"x","query":"price_min=-1"}`, status: http.StatusBadRequest, code: resp.CodeInvalidParameter},
+		{name: "limit", body: `{"name":"x","query":"q=jazz"}`, err: storage.ErrSavedSearchLimit, status: http.StatusUnprocessableEntity, code: resp.CodeSavedSearchLimit},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := serveSave(t, &saverStub{err: tc.err}, tc.body)
+
+			require.Equal(t, tc.status, status)
+			require.Equal(t, tc.code, body.Code)
+		})
+	}
+}
+
+// listerStub запоминает пользователя и отдает заданный список
+type listerStub struct {
+	userID   int64
+	searches []*models.SavedSearch
+	err      error
+}
+
+func (s *listerStub) GetSavedSearches(_ context.Context, userID int64) ([]*models.SavedSearch, error) {
+	s.userID = userID
+	return s.searches, s.err
+}
+
+func TestSavedSearches(t *testing.T) {
+	cases := []struct {
+		name       string
+		authorized bool
+		err        error
+		status     int
+		code       string
+	}{
+		{name: "ok", authorized: true, status: http.StatusOK},
+		{name: "unauthorized", status: http.StatusUnauthorized, code: resp.CodeUnauthorized},
+		{name: "storage error", authorized: true, err: errors.New("db down"), status: http.StatusInternalServerError, code: resp.CodeInternal},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lister := &listerStub{
+				searches: []*models.SavedSearch{{ID: 2, UserID: 42, Name: "Джаз"}, {ID: 1, UserID: 42, Name: "Театр"}},
+				err:      tc.err,
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/search/saved", nil)
+			if tc.authorized {
+				req = req.WithContext(context.WithValue(req.Context(), authMiddleware.UserIDKey, int64(42)))
+			}
+
+			rr := httptest.NewRecorder()
+			NewSavedSearches(slogdiscard.NewDiscardLogger(), lister).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var body SavedSearchesResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.code, body.Code)
+
+			if tc.status == http.StatusOK {
+				require.Equal(t, int64(42), lister.userID)
+				require.Len(t, body.SavedSearches, 2)
+				require.Equal(t, int64(2), body.SavedSearches[0].ID)
+			}
+		})
+	}
+}
+
+// deleterStub запоминает удаляемый поиск
+type deleterStub struct {
+	userID, id int64
+	calls      int
+	err        error
+}
+
+func (s *deleterStub) DeleteSavedSearch(_ context.Context, userID, id int64) error {
+	s.calls++
+	s.userID, s.id = userID, id
+	return s.err
+}
+
+func TestDeleteSavedSearch(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         string
+		authorized bool
+		err        error
+		status     int
+		code       string
+		callsStub  bool // Должен ли хэндлер дойти до стораджа
+	}{
+		{name: "ok", id: "7", authorized: true, status: http.StatusOK, callsStub: true},
+		{name: "unauthorized", id: "7", status: http.StatusUnauthorized, code: resp.CodeUnauthorized},
+		{name: "bad id", id: "abc", authorized: true, status: http.StatusBadRequest, code: resp.CodeInvalidParameter},
+		{
+			// чужой поиск сторадж тоже не находит
+			name: "not found", id: "7", authorized: true, err: storage.ErrSavedSearchNotFound,
+			status: http.StatusNotFound, code: resp.CodeSavedSearchNotFound, callsStub: true,
+		},
+		{name: "storage error", id: "7", authorized: true, err: errors.New("db down"), status: http.StatusInternalServerError, code: resp.CodeInternal, callsStub: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			deleter := &deleterStub{err: tc.err}
+
+			req := httptest.NewRequest(http.MethodDelete, "/search/saved/"+tc.id, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			if tc.authorized {
+				ctx = context.WithValue(ctx, authMiddleware.UserIDKey, int64(42))
+			}
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			NewDeleteSavedSearch(slogdiscard.NewDiscardLogger(), deleter).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var body resp.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.code, body.Code)
+
+			if !tc.callsStub {
+				require.Zero(t, deleter.calls)
+				return
+			}
+			require.Equal(t, 1, deleter.calls)
+			require.Equal(t, int64(42), deleter.userID)
+			require.Equal(t, int64(7), deleter.id)
+		})
+	}
+}
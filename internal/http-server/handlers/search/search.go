@@ -1,11 +1,20 @@
 package search
 
 import (
+	"API/internal/lib/api/cursor"
+	"API/internal/lib/api/request"
 	resp "API/internal/lib/api/response"
 	"API/internal/lib/logger/sl"
 	"API/internal/models"
+	"context"
+	"errors"
+	"html"
+	"math"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/middleware"
@@ -13,35 +22,93 @@ import (
 	"golang.org/x/exp/slog"
 )
 
+// MaxRadiusKm максимальный радиус поиска вокруг точки
+const MaxRadiusKm = 1000
+
 // EventSearcher интерфейс для поиска мероприятий
 type EventSearcher interface {
-	SearchEvents(query string, category string, dateFrom, dateTo *time.Time, priceMin, priceMax *float64, limit, offset int) ([]*models.Event, int, error)
+	SearchEvents(ctx context.Context, params models.EventSearch) (*models.EventSearchResult, error)
 }
 
 // SearchResponse ответ с результатами поиска
 type SearchResponse struct {
 	resp.Response
-	Events  []models.EventResponse `json:"events"`
-	Total   int                    `json:"total"`
-	Limit   int                    `json:"limit"`
-	Offset  int                    `json:"offset"`
-	HasMore bool                   `json:"has_more"`
+	Events  []SearchHit `json:"events"`
+	Total   int         `json:"total"`
+	Limit   int         `json:"limit"`
+	Offset  int         `json:"offset"`
+	HasMore bool        `json:"has_more"`
+	// NextCursor передается в cursor для следующей страницы; пустой на последней
+	NextCursor string `json:"next_cursor,omitempty"`
+	Sort       string `json:"sort" example:"date"`
+	// Facets число найденных мероприятий по категориям, ценам и датам
+	Facets models.SearchFacets `json:"facets"`
+}
+
+// SearchHit найденное мероприятие
+type SearchHit struct {
+	models.EventResponse
+	// Highlight совпадения с запросом; нет без q и при совпадении только по сходству
+	Highlight *SearchHighlight `json:"highlight,omitempty"`
+	// DistanceKm расстояние от точки поиска, только с lat и lng
+	DistanceKm *float64 `json:"distance_km,omitempty" example:"2.35"`
+}
+
+// SearchHighlight HTML-экранированный текст, совпадения обрамлены <mark></mark>
+type SearchHighlight struct {
+	Title       string `json:"title" example:"Вечер <mark>джаза</mark>"`
+	Description string `json:"description,omitempty" example:"Лучшие <mark>джазовые</mark> музыканты … в одном зале"`
+}
+
+// newHighlight переводит фрагменты из хранилища в HTML. Возвращает nil,
+// если совпадений в тексте нет.
+func newHighlight(h models.Highlight) *SearchHighlight {
+	if !strings.Contains(h.Title+h.Description, models.HighlightStart) {
+		return nil
+	}
+
+	highlight := &SearchHighlight{Title: markHTML(h.Title)}
+	if strings.Contains(h.Description, models.HighlightStart) {
+		highlight.Description = markHTML(h.Description)
+	}
+
+	return highlight
+}
+
+// markHTML экранирует текст и заменяет маркеры совпадений на <mark>
+func markHTML(s string) string {
+	return strings.NewReplacer(
+		models.HighlightStart, "<mark>",
+		models.HighlightStop, "</mark>",
+	).Replace(html.EscapeString(s))
 }
 
 // NewSearch возвращает хендлер для поиска мероприятий
 // @Summary Поиск мероприятий
-// @Description Полнотекстовый поиск мероприятий с фильтрами
+// @Description Полнотекстовый поиск мероприятий с фильтрами на русском и английском с учетом опечаток.
+// @Description Кроме страницы результатов возвращает фасеты по категориям, ценовым диапазонам и датам
+// @Description для текущих фильтров, а для каждого мероприятия — фрагменты с отмеченными совпадениями
 // @Tags search
 // @Security BearerAuth
 // @Produce json
 // @Param q query string false "Поисковый запрос"
-// @Param category query string false "Категория (concert, sport, theater, exhibition, festival, other)"
+// @Param category query []string false "Категории (concert, sport, theater, exhibition, festival, other), несколько через запятую или повтором" collectionFormat(multi)
+// @Param creator_id query int false "Только мероприятия автора"
+// @Param venue_id query int false "Только мероприятия на площадке"
+// @Param only_available query bool false "Только с доступными билетами"
+// @Param upcoming query bool false "Только еще не начавшиеся (по умолчанию true); false добавляет идущие сейчас"
+// @Param include_past query bool false "Вместе с завершившимися, снимает ограничение upcoming"
 // @Param date_from query string false "Дата от (RFC3339)"
 // @Param date_to query string false "Дата до (RFC3339)"
 // @Param price_min query number false "Минимальная цена"
 // @Param price_max query number false "Максимальная цена"
+// @Param lat query number false "Широта точки поиска, вместе с lng"
+// @Param lng query number false "Долгота точки поиска, вместе с lat. Мероприятия без координат не попадают в выдачу"
+// @Param radius_km query number false "Радиус поиска в км от точки lat, lng"
+// @Param sort query string false "Сортировка: date (по умолчанию), price_asc, price_desc, relevance (только с q), popularity, distance (только с lat, lng)" Enums(date, price_asc, price_desc, relevance, popularity, distance)
 // @Param limit query int false "Лимит (default 20, max 100)"
-// @Param offset query int false "Смещение (default 0)"
+// @Param offset query int false "Смещение (default 0), игнорируется вместе с cursor"
+// @Param cursor query string false "Курсор следующей страницы из next_cursor, выдается для конкретной сортировки"
 // @Success 200 {object} SearchResponse
 // @Failure 400 {object} resp.Response
 // @Failure 401 {object} resp.Response
@@ -54,99 +121,165 @@ func NewSearch(log *slog.Logger, searcher EventSearcher) http.HandlerFunc {
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
-		// Парсинг параметров
-		query := r.URL.Query().Get("q")
-		category := r.URL.Query().Get("category")
-
-		// Парсинг дат
-		var dateFrom, dateTo *time.Time
-		if df := r.URL.Query().Get("date_from"); df != "" {
-			t, err := time.Parse(time.RFC3339, df)
-			if err != nil {
-				log.Error("invalid date_from format", sl.Err(err))
-				w.WriteHeader(http.StatusBadRequest)
-				render.JSON(w, r, resp.Error("invalid date_from format, use RFC3339"))
-				return
-			}
-			dateFrom = &t
-		}
-		if dt := r.URL.Query().Get("date_to"); dt != "" {
-			t, err := time.Parse(time.RFC3339, dt)
-			if err != nil {
-				log.Error("invalid date_to format", sl.Err(err))
-				w.WriteHeader(http.StatusBadRequest)
-				render.JSON(w, r, resp.Error("invalid date_to format, use RFC3339"))
-				return
-			}
-			dateTo = &t
-		}
-
-		// Парсинг цен
-		var priceMin, priceMax *float64
-		if pm := r.URL.Query().Get("price_min"); pm != "" {
-			p, err := strconv.ParseFloat(pm, 64)
-			if err != nil || p < 0 {
-				log.Error("invalid price_min", sl.Err(err))
-				w.WriteHeader(http.StatusBadRequest)
-				render.JSON(w, r, resp.Error("invalid price_min"))
-				return
-			}
-			priceMin = &p
-		}
-		if pm := r.URL.Query().Get("price_max"); pm != "" {
-			p, err := strconv.ParseFloat(pm, 64)
-			if err != nil || p < 0 {
-				log.Error("invalid price_max", sl.Err(err))
-				w.WriteHeader(http.StatusBadRequest)
-				render.JSON(w, r, resp.Error("invalid price_max"))
-				return
-			}
-			priceMax = &p
+		params, err := parseSearch(r.URL.Query())
+		if err != nil {
+			log.Info("invalid search parameters", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, err.Error()))
+			return
 		}
+		query, sort := params.Query, params.Sort
 
 		// Пагинация
-		limit := 20
-		if l := r.URL.Query().Get("limit"); l != "" {
-			if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
-				limit = parsed
-			}
-		}
-
-		offset := 0
-		if o := r.URL.Query().Get("offset"); o != "" {
-			if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
-				offset = parsed
-			}
+		page, err := request.Page(r, sort)
+		if err != nil {
+			log.Info("invalid cursor", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid cursor"))
+			return
 		}
+		params.Page = page
 
-		events, total, err := searcher.SearchEvents(query, category, dateFrom, dateTo, priceMin, priceMax, limit, offset)
+		result, err := searcher.SearchEvents(r.Context(), params)
 		if err != nil {
 			log.Error("failed to search events", sl.Err(err))
-			w.WriteHeader(http.StatusInternalServerError)
-			render.JSON(w, r, resp.Error("failed to search events"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to search events"))
 			return
 		}
 
-		response := make([]models.EventResponse, 0, len(events))
-		for _, e := range events {
-			response = append(response, e.ToResponse())
+		response := make([]SearchHit, 0, len(result.Events))
+		for _, e := range result.Events {
+			hit := SearchHit{EventResponse: e.ToResponse()}
+			if h, ok := result.Highlights[e.ID]; ok {
+				hit.Highlight = newHighlight(h)
+			}
+			if km, ok := result.Distances[e.ID]; ok {
+				km = math.Round(km*100) / 100
+				hit.DistanceKm = &km
+			}
+			response = append(response, hit)
 		}
 
 		log.Info("search completed",
 			slog.String("query", query),
-			slog.Int("total", total),
+			slog.String("sort", sort),
+			slog.Int("total", result.Total),
 			slog.Int("returned", len(response)),
 		)
 
 		render.JSON(w, r, SearchResponse{
-			Response: resp.OK(),
-			Events:   response,
-			Total:    total,
-			Limit:    limit,
-			Offset:   offset,
-			HasMore:  offset+len(response) < total,
+			Response:   resp.OK(),
+			Events:     response,
+			Total:      result.Total,
+			Limit:      page.Limit,
+			Offset:     page.SkipRows(),
+			HasMore:    result.Next != nil,
+			NextCursor: cursor.Encode(result.Next),
+			Sort:       sort,
+			Facets:     result.Facets,
 		})
 	}
 }
+
+// parseSearch разбирает параметры поиска без пагинации. Ими же задаются
+// сохраненные поиски. Текст ошибки можно отдавать клиенту.
+func parseSearch(q url.Values) (models.EventSearch, error) {
+	params := models.EventSearch{Query: q.Get("q")}
+
+	filter, err := request.EventFilter(q)
+	if err != nil {
+		return params, err
+	}
+	params.EventFilter = filter
+
+	// Даты
+	if df := q.Get("date_from"); df != "" {
+		t, err := time.Parse(time.RFC3339, df)
+		if err != nil {
+			return params, errors.New("invalid date_from format, use RFC3339")
+		}
+		params.DateFrom = &t
+	}
+	if dt := q.Get("date_to"); dt != "" {
+		t, err := time.Parse(time.RFC3339, dt)
+		if err != nil {
+			return params, errors.New("invalid date_to format, use RFC3339")
+		}
+		params.DateTo = &t
+	}
+
+	// Цены
+	if pm := q.Get("price_min"); pm != "" {
+		p, err := strconv.ParseFloat(pm, 64)
+		if err != nil || !(p >= 0) {
+			return params, errors.New("invalid price_min")
+		}
+		params.PriceMin = &p
+	}
+	if pm := q.Get("price_max"); pm != "" {
+		p, err := strconv.ParseFloat(pm, 64)
+		if err != nil || !(p >= 0) {
+			return params, errors.New("invalid price_max")
+		}
+		params.PriceMax = &p
+	}
+
+	// Точка и радиус поиска
+	near, radius, errMsg := parseGeo(q)
+	if errMsg != "" {
+		return params, errors.New(errMsg)
+	}
+	params.Near, params.RadiusKm = near, radius
+
+	// Сортировка. relevance без запроса и distance без точки
+	// сортировать не по чему, такие результаты идут по дате
+	params.Sort = q.Get("sort")
+	switch {
+	case params.Sort == "":
+		params.Sort = models.SortDate
+	case !slices.Contains(models.SearchSorts, params.Sort):
+		return params, errors.New("sort must be one of: " + strings.Join(models.SearchSorts, ", "))
+	case params.Sort == models.SortRelevance && params.Query == "",
+		params.Sort == models.SortDistance && near == nil:
+		params.Sort = models.SortDate
+	}
+
+	return params, nil
+}
+
+// parseGeo разбирает точку поиска lat, lng и радиус radius_km.
+// Возвращает сообщение для клиента, если параметры неверны.
+// Проверки диапазонов записаны так, чтобы отсеивать и NaN.
+func parseGeo(q url.Values) (*models.GeoPoint, *float64, string) {
+	lat, lng, rad := q.Get("lat"), q.Get("lng"), q.Get("radius_km")
+
+	if lat == "" && lng == "" {
+		if rad != "" {
+			return nil, nil, "radius_km requires lat and lng"
+		}
+		return nil, nil, ""
+	}
+	if lat == "" || lng == "" {
+		return nil, nil, "lat and lng must be set together"
+	}
+
+	point := &models.GeoPoint{}
+	var err error
+	if point.Lat, err = strconv.ParseFloat(lat, 64); err != nil || !(point.Lat >= -90 && point.Lat <= 90) {
+		return nil, nil, "lat must be between -90 and 90"
+	}
+	if point.Lng, err = strconv.ParseFloat(lng, 64); err != nil || !(point.Lng >= -180 && point.Lng <= 180) {
+		return nil, nil, "lng must be between -180 and 180"
+	}
+
+	if rad == "" {
+		return point, nil, ""
+	}
+	radius, err := strconv.ParseFloat(rad, 64)
+	if err != nil || !(radius > 0 && radius <= MaxRadiusKm) {
+		return nil, nil, "radius_km must be greater than 0 and at most " + strconv.Itoa(MaxRadiusKm)
+	}
+
+	return point, &radius, ""
+}
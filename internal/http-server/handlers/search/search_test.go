@@ -0,0 +1,289 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"API/internal/lib/api/cursor"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+// searcherStub запоминает параметры поиска и отдает заданный результат
+type searcherStub struct {
+	params *models.EventSearch
+	result *models.EventSearchResult
+}
+
+func (s *searcherStub) SearchEvents(_ context.Context, params models.EventSearch) (*models.EventSearchResult, error) {
+	s.params = &params
+	return s.result, nil
+}
+
+func TestSearchSort(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      string
+		respStatus int
+		sort       string
+	}{
+		{name: "Default", query: "", respStatus: http.StatusOK, sort: models.SortDate},
+		{name: "Price Desc", query: "sort=price_desc", respStatus: http.StatusOK, sort: models.SortPriceDesc},
+		{name: "Relevance", query: "q=jazz&sort=relevance", respStatus: http.StatusOK, sort: models.SortRelevance},
+		{name: "Relevance Without Query", query: "sort=relevance", respStatus: http.StatusOK, sort: models.SortDate},
+		{name: "Unknown", query: "sort=random", respStatus: http.StatusBadRequest},
+		{name: "Broken Cursor", query: "cursor=not-a-cursor", respStatus: http.StatusBadRequest},
+		{
+			// курсор выдан для другой сортировки
+			name:       "Cursor Of Other Sort",
+			query:      "sort=price_asc&cursor=" + cursor.Encode(&models.Cursor{Order: models.SortDate, ID: 1}),
+			respStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			searcher := &searcherStub{result: &models.EventSearchResult{}}
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/search?"+tc.query, nil)
+			NewSearch(slogdiscard.NewDiscardLogger(), searcher).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.respStatus, rr.Code)
+			if tc.respStatus != http.StatusOK {
+				require.Nil(t, searcher.params)
+				return
+			}
+
+			require.Equal(t, tc.sort, searcher.params.Sort)
+
+			var body SearchResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.sort, body.Sort)
+		})
+	}
+}
+
+func TestSearchFacets(t *testing.T) {
+	prices := models.PriceFacets()
+	prices[2].Count = 4
+	dates, _ := models.DateFacets(time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC))
+	dates[1].Count = 4
+
+	searcher := &searcherStub{result: &models.EventSearchResult{
+		Total: 4,
+		Facets: models.SearchFacets{
+			Categories: []models.CategoryFacet{{Category: "concert", Count: 3}, {Category: "theater", Count: 1}},
+			Prices:     prices,
+			Dates:      dates,
+		},
+	}}
+
+	rr := httptest.NewRecorder()
+	NewSearch(slogdiscard.NewDiscardLogger(), searcher).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/search?q=jazz&limit=2", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var body SearchResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+
+	require.Equal(t, resp.StatusOK, body.Status)
+	require.Len(t, body.Facets.Categories, 2)
+	require.Equal(t, "1000-3000", body.Facets.Prices[2].Key)
+	require.Equal(t, 4, body.Facets.Prices[2].Count)
+	require.Equal(t, "today", body.Facets.Dates[1].Key)
+	require.Nil(t, body.Facets.Prices[0].Min)
+	require.Nil(t, body.Facets.Dates[5].To)
+}
+
+func TestSearchCursor(t *testing.T) {
+	key := 1500.0
+	next := &models.Cursor{Order: models.SortPriceAsc, Key: &key, Time: time.Date(2025, 6, 1, 19, 0, 0, 0, time.UTC), ID: 7}
+	searcher := &searcherStub{result: &models.EventSearchResult{Total: 10, Next: next}}
+
+	rr := httptest.NewRecorder()
+	NewSearch(slogdiscard.NewDiscardLogger(), searcher).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/search?sort=price_asc&limit=5", nil))
+
+	var first SearchResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &first))
+	require.True(t, first.HasMore)
+	require.NotEmpty(t, first.NextCursor)
+
+	// следующая страница продолжает с курсора, offset игнорируется
+	searcher.result = &models.EventSearchResult{Total: 10}
+	rr = httptest.NewRecorder()
+	NewSearch(slogdiscard.NewDiscardLogger(), searcher).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/search?sort=price_asc&limit=5&offset=3&cursor="+first.NextCursor, nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, int64(7), searcher.params.After.ID)
+	require.Equal(t, key, *searcher.params.After.Key)
+	require.Equal(t, 0, searcher.params.SkipRows())
+
+	var second SearchResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &second))
+	require.False(t, second.HasMore)
+	require.Empty(t, second.NextCursor)
+}
+
+func TestSearchHighlight(t *testing.T) {
+	mark := func(s string) string { return models.HighlightStart + s + models.HighlightStop }
+
+	searcher := &searcherStub{result: &models.EventSearchResult{
+		Events: []*models.Event{
+			{ID: 1, Title: "Jazz <Night>"},
+			{ID: 2, Title: "Вечер джаза"},
+			{ID: 3, Title: "Джас-клуб"},
+		},
+		Total: 3,
+		Highlights: map[int64]models.Highlight{
+			1: {Title: mark("Jazz") + " <Night>", Description: "Best " + mark("jazz") + " & blues"},
+			2: {Title: "Вечер " + mark("джаза"), Description: "Без совпадений"},
+			// найдено только по сходству
+			3: {Title: "Джас-клуб"},
+		},
+	}}
+
+	rr := httptest.NewRecorder()
+	NewSearch(slogdiscard.NewDiscardLogger(), searcher).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/search?q=jazz", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var body SearchResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	require.Len(t, body.Events, 3)
+
+	require.Equal(t, "<mark>Jazz</mark> &lt;Night&gt;", body.Events[0].Highlight.Title)
+	require.Equal(t, "Best <mark>jazz</mark> &amp; blues", body.Events[0].Highlight.Description)
+	require.Equal(t, "Jazz <Night>", body.Events[0].Title)
+
+	require.Equal(t, "Вечер <mark>джаза</mark>", body.Events[1].Highlight.Title)
+	require.Empty(t, body.Events[1].Highlight.Description)
+
+	require.Nil(t, body.Events[2].Highlight)
+}
+
+func TestSearchGeo(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      string
+		respStatus int
+		sort       string
+		point      bool
+		radius     float64
+	}{
+		{name: "Near Me", query: "lat=55.75&lng=37.62&radius_km=5&sort=distance", respStatus: http.StatusOK, sort: models.SortDistance, point: true, radius: 5},
+		{name: "Point Without Radius", query: "lat=55.75&lng=37.62", respStatus: http.StatusOK, sort: models.SortDate, point: true},
+		{name: "Distance Without Point", query: "sort=distance", respStatus: http.StatusOK, sort: models.SortDate},
+		{name: "Lat Only", query: "lat=55.75", respStatus: http.StatusBadRequest},
+		{name: "Radius Without Point", query: "radius_km=5", respStatus: http.StatusBadRequest},
+		{name: "Lat Out Of Range", query: "lat=95&lng=37.62", respStatus: http.StatusBadRequest},
+		{name: "NaN", query: "lat=NaN&lng=37.62", respStatus: http.StatusBadRequest},
+		{name: "Zero Radius", query: "lat=55.75&lng=37.62&radius_km=0", respStatus: http.StatusBadRequest},
+		{name: "Huge Radius", query: "lat=55.75&lng=37.62&radius_km=5000", respStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			searcher := &searcherStub{result: &models.EventSearchResult{}}
+
+			rr := httptest.NewRecorder()
+			NewSearch(slogdiscard.NewDiscardLogger(), searcher).
+				ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/search?"+tc.query, nil))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+			if tc.respStatus != http.StatusOK {
+				require.Nil(t, searcher.params)
+				return
+			}
+
+			require.Equal(t, tc.sort, searcher.params.Sort)
+			if !tc.point {
+				require.Nil(t, searcher.params.Near)
+				return
+			}
+			require.Equal(t, 55.75, searcher.params.Near.Lat)
+			require.Equal(t, 37.62, searcher.params.Near.Lng)
+			if tc.radius != 0 {
+				require.Equal(t, tc.radius, *searcher.params.RadiusKm)
+			} else {
+				require.Nil(t, searcher.params.RadiusKm)
+			}
+		})
+	}
+}
+
+func TestSearchDistance(t *testing.T) {
+	searcher := &searcherStub{result: &models.EventSearchResult{
+		Events:    []*models.Event{{ID: 1}, {ID: 2}},
+		Total:     2,
+		Distances: map[int64]float64{1: 3.0712},
+	}}
+
+	rr := httptest.NewRecorder()
+	NewSearch(slogdiscard.NewDiscardLogger(), searcher).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/search?lat=55.75&lng=37.62&sort=distance", nil))
+
+	var body SearchResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+
+	require.Equal(t, 3.07, *body.Events[0].DistanceKm)
+	require.Nil(t, body.Events[1].DistanceKm)
+}
+
+func TestSearchFilter(t *testing.T) {
+	creator := int64(7)
+
+	cases := []struct {
+		name       string
+		query      string
+		respStatus int
+		filter     models.EventFilter
+	}{
+		{name: "Defaults", query: "", respStatus: http.StatusOK, filter: models.EventFilter{Upcoming: true}},
+		{
+			name:       "Categories",
+			query:      "category=concert,theater&category=sport",
+			respStatus: http.StatusOK,
+			filter:     models.EventFilter{Categories: []string{"concert", "theater", "sport"}, Upcoming: true},
+		},
+		{
+			name:       "Available Now And Later",
+			query:      "only_available=true&upcoming=false&creator_id=7",
+			respStatus: http.StatusOK,
+			filter:     models.EventFilter{CreatorID: &creator, OnlyAvailable: true},
+		},
+		{name: "Include Past", query: "include_past=1", respStatus: http.StatusOK, filter: models.EventFilter{Upcoming: true, IncludePast: true}},
+		{name: "Unknown Category", query: "category=opera", respStatus: http.StatusBadRequest},
+		{name: "Invalid Creator", query: "creator_id=me", respStatus: http.StatusBadRequest},
+		{name: "Invalid Flag", query: "only_available=yes", respStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			searcher := &searcherStub{result: &models.EventSearchResult{}}
+
+			rr := httptest.NewRecorder()
+			NewSearch(slogdiscard.NewDiscardLogger(), searcher).
+				ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/search?"+tc.query, nil))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+			if tc.respStatus != http.StatusOK {
+				require.Nil(t, searcher.params)
+				return
+			}
+
+			require.Equal(t, tc.filter, searcher.params.EventFilter)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package search
+
+import (
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/models"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"golang.org/x/exp/slog"
+)
+
+const (
+	// MinSuggestPrefix с какой длины запроса искать подсказки
+	MinSuggestPrefix = 2
+	// MaxSuggestPrefix длиннее подсказки уже не нужны, запрос обрезается
+	MaxSuggestPrefix = 100
+	// DefaultSuggestLimit и MaxSuggestLimit число подсказок каждого вида
+	DefaultSuggestLimit = 5
+	MaxSuggestLimit     = 10
+)
+
+// EventSuggester интерфейс для подсказок поиска
+type EventSuggester interface {
+	SuggestEvents(ctx context.Context, prefix string, limit int) (*models.Suggestions, error)
+}
+
+// SuggestResponse ответ с подсказками
+type SuggestResponse struct {
+	resp.Response
+	models.Suggestions
+	// Partial подсказки не успели собраться за отведенное время и пустые
+	Partial bool `json:"partial,omitempty"`
+}
+
+// NewSuggest возвращает хендлер подсказок для строки поиска. На запрос
+// отводится timeout: если база не ответила, отдаются пустые подсказки,
+// чтобы ввод не ждал.
+// @Summary Подсказки поиска
+// @Description Названия, площадки и категории предстоящих мероприятий, слова которых начинаются
+// @Description со слов запроса. Популярные (по проданным билетам) первыми. Без фасетов и подсчета
+// @Description общего числа, для вызова на каждое нажатие клавиши; запрос короче 2 символов дает пустой ответ
+// @Tags search
+// @Security BearerAuth
+// @Produce json
+// @Param q query string true "Начало запроса"
+// @Param limit query int false "Подсказок каждого вида (default 5, max 10)"
+// @Success 200 {object} SuggestResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 500 {object} resp.Response
+// @Router /search/suggest [get]
+func NewSuggest(log *slog.Logger, suggester EventSuggester, timeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.search.Suggest"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		limit := DefaultSuggestLimit
+		if l := r.URL.Query().Get("limit"); l != "" {
+			n, err := strconv.Atoi(l)
+			if err != nil || n < 1 || n > MaxSuggestLimit {
+				resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter,
+					"limit must be between 1 and "+strconv.Itoa(MaxSuggestLimit)))
+				return
+			}
+			limit = n
+		}
+
+		response := SuggestResponse{
+			Response: resp.OK(),
+			Suggestions: models.Suggestions{
+				Titles:     []models.Suggestion{},
+				Venues:     []models.Suggestion{},
+				Categories: []models.Suggestion{},
+			},
+		}
+
+		// Подсказки зависят только от запроса, браузер может их переиспользовать
+		w.Header().Set("Cache-Control", "private, max-age=60")
+
+		prefix := strings.TrimSpace(r.URL.Query().Get("q"))
+		if utf8.RuneCountInString(prefix) < MinSuggestPrefix {
+			render.JSON(w, r, response)
+			return
+		}
+		if utf8.RuneCountInString(prefix) > MaxSuggestPrefix {
+			prefix = string([]rune(prefix)[:MaxSuggestPrefix])
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		suggestions, err := suggester.SuggestEvents(ctx, prefix, limit)
+		switch {
+		case err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded):
+			log.Warn("suggestions timed out", slog.Duration("timeout", timeout))
+			w.Header().Set("Cache-Control", "no-store")
+			response.Partial = true
+			render.JSON(w, r, response)
+			return
+		case err != nil:
+			log.Error("failed to suggest", sl.Err(err))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to suggest"))
+			return
+		}
+
+		response.Suggestions = *suggestions
+		render.JSON(w, r, response)
+	}
+}
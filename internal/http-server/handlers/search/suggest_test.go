@@ -0,0 +1,96 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+// suggesterStub запоминает запрос; с wait ждет отмены контекста
+type suggesterStub struct {
+	prefix string
+	limit  int
+	wait   bool
+	err    error
+}
+
+func (s *suggesterStub) SuggestEvents(ctx context.Context, prefix string, limit int) (*models.Suggestions, error) {
+	s.prefix, s.limit = prefix, limit
+	if s.wait {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &models.Suggestions{
+		Titles:     []models.Suggestion{{Text: "Вечер джаза", Events: 2}},
+		Venues:     []models.Suggestion{},
+		Categories: []models.Suggestion{},
+	}, nil
+}
+
+func serveSuggest(t *testing.T, suggester EventSuggester, query string) (int, SuggestResponse) {
+	t.Helper()
+
+	rr := httptest.NewRecorder()
+	NewSuggest(slogdiscard.NewDiscardLogger(), suggester, 20*time.Millisecond).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/search/suggest?"+query, nil))
+
+	var body SuggestResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+
+	return rr.Code, body
+}
+
+func TestSuggest(t *testing.T) {
+	suggester := &suggesterStub{}
+
+	status, body := serveSuggest(t, suggester, "q=%D0%B4%D0%B6%D0%B0&limit=3")
+
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "джа", suggester.prefix)
+	require.Equal(t, 3, suggester.limit)
+	require.Equal(t, "Вечер джаза", body.Titles[0].Text)
+	require.NotNil(t, body.Venues)
+	require.False(t, body.Partial)
+}
+
+func TestSuggestShortPrefix(t *testing.T) {
+	suggester := &suggesterStub{}
+
+	status, body := serveSuggest(t, suggester, "q=+%D0%B4+")
+
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, suggester.prefix)
+	require.NotNil(t, body.Titles)
+	require.Empty(t, body.Titles)
+}
+
+func TestSuggestTimeout(t *testing.T) {
+	status, body := serveSuggest(t, &suggesterStub{wait: true}, "q=jazz")
+
+	require.Equal(t, http.StatusOK, status)
+	require.True(t, body.Partial)
+	require.Empty(t, body.Titles)
+}
+
+func TestSuggestErrors(t *testing.T) {
+	status, body := serveSuggest(t, &suggesterStub{}, "q=jazz&limit=50")
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Equal(t, resp.CodeInvalidParameter, body.Code)
+
+	status, body = serveSuggest(t, &suggesterStub{err: errors.New("connection refused")}, "q=jazz")
+	require.Equal(t, http.StatusInternalServerError, status)
+	require.Equal(t, resp.CodeInternal, body.Code)
+}
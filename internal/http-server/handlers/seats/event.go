@@ -0,0 +1,73 @@
+package seats
+
+import (
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/models"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"golang.org/x/exp/slog"
+)
+
+// EventSeatsGetter интерфейс для мест мероприятия
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --all
+type EventSeatsGetter interface {
+	GetEventSeats(ctx context.Context, eventID int64) (*models.EventSeats, error)
+}
+
+// EventSeatsResponse ответ с местами мероприятия
+type EventSeatsResponse struct {
+	resp.Response
+	models.EventSeats
+}
+
+// NewEventSeats возвращает хендлер мест мероприятия
+// @Summary Места мероприятия
+// @Description Места мероприятия по схеме зала в порядке схемы: секция, ряд, номер, ценовая категория,
+// @Description цена и доступность. Места бронируются через POST /events/{id}/book с seat_ids
+// @Tags events
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID мероприятия"
+// @Success 200 {object} EventSeatsResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response "Мероприятие не найдено или продается без мест"
+// @Failure 500 {object} resp.Response
+// @Router /events/{id}/seats [get]
+func NewEventSeats(log *slog.Logger, getter EventSeatsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.seats.EventSeats"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid event id"))
+			return
+		}
+
+		seats, err := getter.GetEventSeats(r.Context(), eventID)
+		if err != nil {
+			if !resp.IsKnownStorageError(err) {
+				log.Error("failed to get event seats", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		// Доступность меняется с каждой бронью
+		w.Header().Set("Cache-Control", "no-store")
+		render.JSON(w, r, EventSeatsResponse{Response: resp.OK(), EventSeats: *seats})
+	}
+}
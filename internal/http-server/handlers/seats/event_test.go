@@ -0,0 +1,113 @@
+package seats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/http-server/handlers/seats/mocks"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+func TestEventSeatsHandler(t *testing.T) {
+	cases := []struct {
+		name       string // Имя теста
+		id         string // ID мероприятия в пути
+		respCode   string // Указываем какой код ошибки хотим получить
+		respStatus int    // Ожидаемый HTTP статус
+		mockError  error  // Ошибка которую выдает mock
+		callsMock  bool   // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Success",
+			id:         "5",
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Invalid Event ID",
+			id:         "abc",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Event Not Found",
+			id:         "5",
+			respCode:   resp.CodeEventNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrEventNotFound,
+			callsMock:  true,
+		}, {
+			// мероприятие продается без схемы зала
+			name:       "No Seat Map",
+			id:         "5",
+			respCode:   resp.CodeSeatMapNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrSeatMapNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Storage Error",
+			id:         "5",
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("unexpected error"),
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			getterMock := mocks.NewEventSeatsGetter(t)
+			if tc.callsMock {
+				var seats *models.EventSeats
+				if tc.mockError == nil {
+					seats = &models.EventSeats{
+						EventID:   5,
+						SeatMapID: 2,
+						Available: 1,
+						Seats: []models.EventSeat{
+							{Seat: models.Seat{ID: 101, Section: "Партер", Row: "1", Number: 1, PriceCategory: "A"}, Price: 5000, Available: true},
+							{Seat: models.Seat{ID: 102, Section: "Партер", Row: "1", Number: 2, PriceCategory: "A"}, Price: 5000},
+						},
+					}
+				}
+				getterMock.On("GetEventSeats", mock.Anything, int64(5)).
+					Return(seats, tc.mockError).
+					Once()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/events/"+tc.id+"/seats", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			NewEventSeats(slogdiscard.NewDiscardLogger(), getterMock).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body EventSeatsResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respStatus != http.StatusOK {
+				return
+			}
+
+			// доступность меняется с каждой бронью, ответ не кешируется
+			require.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+			require.Equal(t, int64(2), body.SeatMapID)
+			require.Equal(t, 1, body.Available)
+			require.Len(t, body.Seats, 2)
+			require.True(t, body.Seats[0].Available)
+			require.False(t, body.Seats[1].Available)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package seats
+
+import (
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/exp/slog"
+)
+
+// MaxSeats максимальное число мест в схеме зала
+const MaxSeats = 10000
+
+// SeatMapCreator интерфейс для создания схем зала
+type SeatMapCreator interface {
+	CreateSeatMap(ctx context.Context, seatMap *models.SeatMap) (*models.SeatMap, error)
+}
+
+// SeatMapLister интерфейс для схем зала площадки
+type SeatMapLister interface {
+	GetVenueByID(ctx context.Context, id int64) (*models.Venue, error)
+	GetSeatMaps(ctx context.Context, venueID int64) ([]*models.SeatMap, error)
+}
+
+// CreateMapRequest запрос на создание схемы зала
+type CreateMapRequest struct {
+	Name     string           `json:"name" validate:"required,max=100" example:"Партер и балкон"`
+	Sections []SectionRequest `json:"sections" validate:"required,min=1,max=50,dive"`
+}
+
+// SectionRequest секция зала
+type SectionRequest struct {
+	Name string       `json:"name" validate:"required,max=100" example:"Партер"`
+	Rows []RowRequest `json:"rows" validate:"required,min=1,max=100,dive"`
+}
+
+// RowRequest ряд секции. Места нумеруются с 1.
+type RowRequest struct {
+	Name  string `json:"name" validate:"required,max=10" example:"1"`
+	Seats int    `json:"seats" validate:"required,min=1,max=200" example:"20"`
+	// PriceCategory категория мест ряда
+	PriceCategory string `json:"price_category" validate:"required,max=20" example:"A"`
+	// SeatCategories категории отдельных мест ряда по номеру, если отличаются от ряда
+	SeatCategories map[int]string `json:"seat_categories,omitempty" validate:"omitempty,dive,required,max=20"`
+}
+
+// SeatMapResponse ответ со схемой зала
+type SeatMapResponse struct {
+	resp.Response
+	SeatMap *models.SeatMap `json:"seat_map"`
+}
+
+// SeatMapsResponse ответ со схемами зала площадки
+type SeatMapsResponse struct {
+	resp.Response
+	SeatMaps []*models.SeatMap `json:"seat_maps"`
+}
+
+// buildSeats разворачивает секции и ряды в список мест. Текст ошибки
+// можно отдавать клиенту.
+func buildSeats(sections []SectionRequest) ([]models.Seat, error) {
+	var seats []models.Seat
+	sectionNames := map[string]bool{}
+
+	for _, section := range sections {
+		name := strings.TrimSpace(section.Name)
+		if sectionNames[name] {
+			return nil, fmt.Errorf("duplicate section %q", name)
+		}
+		sectionNames[name] = true
+
+		rowNames := map[string]bool{}
+		for _, row := range section.Rows {
+			rowName := strings.TrimSpace(row.Name)
+			if rowNames[rowName] {
+				return nil, fmt.Errorf("duplicate row %q in section %q", rowName, name)
+			}
+			rowNames[rowName] = true
+
+			for number := range row.SeatCategories {
+				if number < 1 || number > row.Seats {
+					return nil, fmt.Errorf("seat %d is out of row %q in section %q", number, rowName, name)
+				}
+			}
+
+			if len(seats)+row.Seats > MaxSeats {
+				return nil, fmt.Errorf("seat map must have at most %d seats", MaxSeats)
+			}
+			for number := 1; number <= row.Seats; number++ {
+				category := row.PriceCategory
+				if c, ok := row.SeatCategories[number]; ok {
+					category = c
+				}
+				seats = append(seats, models.Seat{
+					Section:       name,
+					Row:           rowName,
+					Number:        number,
+					PriceCategory: strings.TrimSpace(category),
+				})
+			}
+		}
+	}
+
+	return seats, nil
+}
+
+// NewCreateMap возвращает хендлер создания схемы зала площадки
+// @Summary Создать схему зала
+// @Description Создает схему зала площадки: секции, ряды и места с ценовыми категориями.
+// @Description Схема не меняется после создания; мероприятие на площадке продает места по ней,
+// @Description задавая цену каждой категории в seat_prices
+// @Tags venues
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID площадки"
+// @Param request body CreateMapRequest true "Схема зала"
+// @Success 201 {object} SeatMapResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response
+// @Failure 500 {object} resp.Response
+// @Router /venues/{id}/seat-maps [post]
+func NewCreateMap(log *slog.Logger, creator SeatMapCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.seats.CreateMap"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		venueID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid id format"))
+			return
+		}
+
+		var req CreateMapRequest
+		err = request.DecodeJSON(r, &req)
+		if errors.Is(err, io.EOF) {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeEmptyBody, "empty request body"))
+			return
+		}
+		if err != nil {
+			log.Info("failed to decode request", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "invalid request body"))
+			return
+		}
+
+		if err := request.Validate(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("validation failed", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.ValidationError(validateErr))
+			return
+		}
+
+		seats, err := buildSeats(req.Sections)
+		if err != nil {
+			log.Info("invalid seat map", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeValidationFailed, err.Error()))
+			return
+		}
+
+		seatMap, err := creator.CreateSeatMap(r.Context(), &models.SeatMap{
+			VenueID:   venueID,
+			Name:      strings.TrimSpace(req.Name),
+			CreatorID: &userID,
+			Seats:     seats,
+		})
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("seat map not created", sl.Err(err))
+			} else {
+				log.Error("failed to create seat map", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		log.Info("seat map created", slog.Int64("seat_map_id", seatMap.ID), slog.Int("seats", seatMap.SeatCount))
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, SeatMapResponse{Response: resp.OK(), SeatMap: seatMap})
+	}
+}
+
+// NewListMaps возвращает хендлер схем зала площадки
+// @Summary Схемы зала площадки
+// @Description Схемы зала площадки с числом мест и ценовыми категориями, новые первыми
+// @Tags venues
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID площадки"
+// @Success 200 {object} SeatMapsResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response
+// @Failure 500 {object} resp.Response
+// @Router /venues/{id}/seat-maps [get]
+func NewListMaps(log *slog.Logger, lister SeatMapLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.seats.ListMaps"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		venueID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid id format"))
+			return
+		}
+
+		if _, err := lister.GetVenueByID(r.Context(), venueID); err != nil {
+			if !resp.IsKnownStorageError(err) {
+				log.Error("failed to get venue", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		seatMaps, err := lister.GetSeatMaps(r.Context(), venueID)
+		if err != nil {
+			log.Error("failed to get seat maps", sl.Err(err))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "internal error"))
+			return
+		}
+
+		render.JSON(w, r, SeatMapsResponse{Response: resp.OK(), SeatMaps: seatMaps})
+	}
+}
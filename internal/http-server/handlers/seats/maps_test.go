@@ -0,0 +1,87 @@
+package seats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"API/internal/models"
+)
+
+func TestBuildSeats(t *testing.T) {
+	seats, err := buildSeats([]SectionRequest{
+		{Name: "Партер", Rows: []RowRequest{
+			{Name: "1", Seats: 3, PriceCategory: "A", SeatCategories: map[int]string{2: "VIP"}},
+			{Name: "2", Seats: 2, PriceCategory: "B"},
+		}},
+		{Name: " Балкон ", Rows: []RowRequest{
+			{Name: "1", Seats: 1, PriceCategory: "C"},
+		}},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []models.Seat{
+		{Section: "Партер", Row: "1", Number: 1, PriceCategory: "A"},
+		{Section: "Партер", Row: "1", Number: 2, PriceCategory: "VIP"},
+		{Section: "Партер", Row: "1", Number: 3, PriceCategory: "A"},
+		{Section: "Партер", Row: "2", Number: 1, PriceCategory: "B"},
+		{Section: "Партер", Row: "2", Number: 2, PriceCategory: "B"},
+		{Section: "Балкон", Row: "1", Number: 1, PriceCategory: "C"},
+	}, seats)
+}
+
+func TestBuildSeatsErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		sections []SectionRequest
+		err      string
+	}{
+		{
+			name: "duplicate section",
+			sections: []SectionRequest{
+				{Name: "Партер", Rows: []RowRequest{{Name: "1", Seats: 1, PriceCategory: "A"}}},
+				{Name: "Партер ", Rows: []RowRequest{{Name: "2", Seats: 1, PriceCategory: "A"}}},
+			},
+			err: `duplicate section "Партер"`,
+		},
+		{
+			name: "duplicate row",
+			sections: []SectionRequest{
+				{Name: "Партер", Rows: []RowRequest{
+					{Name: "1", Seats: 1, PriceCategory: "A"},
+					{Name: "1", Seats: 1, PriceCategory: "A"},
+				}},
+			},
+			err: `duplicate row "1" in section "Партер"`,
+		},
+		{
+			name: "seat out of row",
+			sections: []SectionRequest{
+				{Name: "Партер", Rows: []RowRequest{
+					{Name: "1", Seats: 2, PriceCategory: "A", SeatCategories: map[int]string{3: "VIP"}},
+				}},
+			},
+			err: `seat 3 is out of row "1" in section "Партер"`,
+		},
+		{
+			name: "too many seats",
+			sections: []SectionRequest{
+				{Name: "Партер", Rows: func() []RowRequest {
+					rows := make([]RowRequest, 51)
+					for i := range rows {
+						rows[i] = RowRequest{Name: string(rune('A' + i)), Seats: 200, PriceCategory: "A"}
+					}
+					return rows
+				}()},
+			},
+			err: "seat map must have at most 10000 seats",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := buildSeats(tc.sections)
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
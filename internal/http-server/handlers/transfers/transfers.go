@@ -0,0 +1,308 @@
+package transfers
+
+import (
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/models"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/exp/slog"
+)
+
+// TransferCreator интерфейс для создания передачи брони
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --all
+type TransferCreator interface {
+	CreateTransfer(ctx context.Context, bookingID, fromUserID int64, toEmail string, quantity int, seatIDs []int64) (*models.BookingTransfer, error)
+}
+
+// TransferLister интерфейс для списка передач пользователя
+type TransferLister interface {
+	GetTransfers(ctx context.Context, userID int64) ([]*models.BookingTransfer, error)
+}
+
+// TransferAccepter интерфейс для принятия передачи
+type TransferAccepter interface {
+	AcceptTransfer(ctx context.Context, transferID, userID int64) (*models.BookingWithEvent, error)
+}
+
+// TransferDecliner интерфейс для отказа от передачи или ее отзыва
+type TransferDecliner interface {
+	DeclineTransfer(ctx context.Context, transferID, userID int64) (models.TransferStatus, error)
+}
+
+// CreateRequest запрос на передачу брони. Без quantity и seat_ids
+// передается вся бронь.
+type CreateRequest struct {
+	Email    string  `json:"email" validate:"required,email" example:"boris@example.com"`
+	Quantity int     `json:"quantity,omitempty" validate:"omitempty,min=1,excluded_with=SeatIDs" example:"1"`
+	SeatIDs  []int64 `json:"seat_ids,omitempty" validate:"omitempty,max=10,unique,dive,min=1" example:"101"`
+}
+
+// TransferResponse ответ с передачей брони
+type TransferResponse struct {
+	resp.Response
+	Transfer *models.BookingTransfer `json:"transfer"`
+}
+
+// TransfersResponse ответ со списком передач
+type TransfersResponse struct {
+	resp.Response
+	Transfers []*models.BookingTransfer `json:"transfers"`
+}
+
+// DeclineResponse ответ с итоговым статусом передачи
+type DeclineResponse struct {
+	resp.Response
+	// TransferStatus declined, если отказался получатель, cancelled — если отозвал отправитель
+	TransferStatus models.TransferStatus `json:"transfer_status" example:"declined"`
+}
+
+// AcceptResponse ответ с бронью получателя
+type AcceptResponse struct {
+	resp.Response
+	Booking models.BookingResponse `json:"booking"`
+}
+
+// NewCreate возвращает хендлер передачи брони
+// @Summary Передать бронь
+// @Description Предлагает передать бронь или часть ее билетов (quantity или места seat_ids) другому
+// @Description пользователю по email. Билеты переходят к получателю после POST /transfers/{id}/accept;
+// @Description до этого передачу можно отозвать. На бронь может быть одна ожидающая передача
+// @Tags transfers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID бронирования"
+// @Param request body CreateRequest true "Получатель и что передать"
+// @Success 201 {object} TransferResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response "Бронь, получатель или место не найдены"
+// @Failure 409 {object} resp.Response "Бронь не подтверждена, уже передается или у получателя есть бронь"
+// @Failure 422 {object} resp.Response "Передача себе, больше билетов, чем в брони, или без выбора мест"
+// @Failure 500 {object} resp.Response
+// @Router /bookings/{id}/transfer [post]
+func NewCreate(log *slog.Logger, creator TransferCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.transfers.Create"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		bookingID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid booking id"))
+			return
+		}
+
+		var req CreateRequest
+		err = request.DecodeJSON(r, &req)
+		if errors.Is(err, io.EOF) {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeEmptyBody, "empty request body"))
+			return
+		}
+		if err != nil {
+			log.Info("failed to decode request", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "invalid request body"))
+			return
+		}
+
+		if err := request.Validate(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("validation failed", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.ValidationError(validateErr))
+			return
+		}
+
+		transfer, err := creator.CreateTransfer(r.Context(), bookingID, userID, req.Email, req.Quantity, req.SeatIDs)
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("transfer rejected", sl.Err(err), slog.Int64("booking_id", bookingID))
+			} else {
+				log.Error("failed to create transfer", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		log.Info("transfer created",
+			slog.Int64("transfer_id", transfer.ID),
+			slog.Int64("booking_id", bookingID),
+			slog.Int("quantity", transfer.Quantity),
+		)
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, TransferResponse{Response: resp.OK(), Transfer: transfer})
+	}
+}
+
+// NewList возвращает хендлер списка передач
+// @Summary Мои передачи
+// @Description Входящие и исходящие передачи броней, новые первыми. Принятые передачи — история
+// @Description смены владельцев билетов
+// @Tags transfers
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} TransfersResponse
+// @Failure 401 {object} resp.Response
+// @Failure 500 {object} resp.Response
+// @Router /transfers [get]
+func NewList(log *slog.Logger, lister TransferLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.transfers.List"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		transfers, err := lister.GetTransfers(r.Context(), userID)
+		if err != nil {
+			log.Error("failed to get transfers", sl.Err(err))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to get transfers"))
+			return
+		}
+
+		render.JSON(w, r, TransfersResponse{Response: resp.OK(), Transfers: transfers})
+	}
+}
+
+// NewAccept возвращает хендлер принятия передачи
+// @Summary Принять передачу
+// @Description Переводит билеты на текущего пользователя. Коды затронутых броней перевыпускаются:
+// @Description подпись прежнего QR кода остается верной, но POST /tickets/verify отклоняет его с TICKET_REVOKED
+// @Tags transfers
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID передачи"
+// @Success 200 {object} AcceptResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response "Передача не найдена или адресована другому пользователю"
+// @Failure 409 {object} resp.Response "Передача закрыта, бронь изменилась или у получателя уже есть бронь"
+// @Failure 500 {object} resp.Response
+// @Router /transfers/{id}/accept [post]
+func NewAccept(log *slog.Logger, accepter TransferAccepter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.transfers.Accept"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		transferID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid transfer id"))
+			return
+		}
+
+		booking, err := accepter.AcceptTransfer(r.Context(), transferID, userID)
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("transfer not accepted", sl.Err(err), slog.Int64("transfer_id", transferID))
+			} else {
+				log.Error("failed to accept transfer", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		log.Info("transfer accepted",
+			slog.Int64("transfer_id", transferID),
+			slog.Int64("booking_id", booking.ID),
+		)
+
+		render.JSON(w, r, AcceptResponse{Response: resp.OK(), Booking: booking.ToResponse()})
+	}
+}
+
+// NewDecline возвращает хендлер отказа от передачи
+// @Summary Отклонить или отозвать передачу
+// @Description Получатель отказывается от передачи (status declined), отправитель отзывает ее (status cancelled)
+// @Tags transfers
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID передачи"
+// @Success 200 {object} DeclineResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response
+// @Failure 409 {object} resp.Response "Передача уже закрыта"
+// @Failure 500 {object} resp.Response
+// @Router /transfers/{id}/decline [post]
+func NewDecline(log *slog.Logger, decliner TransferDecliner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.transfers.Decline"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		transferID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid transfer id"))
+			return
+		}
+
+		status, err := decliner.DeclineTransfer(r.Context(), transferID, userID)
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("transfer not declined", sl.Err(err), slog.Int64("transfer_id", transferID))
+			} else {
+				log.Error("failed to decline transfer", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		log.Info("transfer declined", slog.Int64("transfer_id", transferID), slog.String("status", string(status)))
+		render.JSON(w, r, DeclineResponse{Response: resp.OK(), TransferStatus: status})
+	}
+}
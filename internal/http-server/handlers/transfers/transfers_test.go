@@ -0,0 +1,354 @@
+package transfers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/http-server/handlers/transfers/mocks"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+const (
+	senderID    = int64(42)
+	recipientID = int64(43)
+)
+
+// newRequest создает запрос пользователя userID с параметром маршрута id
+func newRequest(t *testing.T, method, id, body string, userID int64) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(method, "/", bytes.NewReader([]byte(body)))
+	require.NoError(t, err)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+
+	// Хэндлер стоит за JWTAuth, который кладет user_id в контекст
+	return req.WithContext(context.WithValue(ctx, authMiddleware.UserIDKey, userID))
+}
+
+func TestCreateHandler(t *testing.T) {
+	cases := []struct {
+		name       string  // Имя теста
+		body       string  // Тело запроса
+		quantity   int     // Количество билетов, которое дойдет до стораджа
+		seatIDs    []int64 // Места, которые дойдут до стораджа
+		respCode   string  // Указываем какой код ошибки хотим получить
+		respStatus int     // Ожидаемый HTTP статус
+		mockError  error   // Ошибка которую выдает mock
+		callsMock  bool    // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Whole Booking",
+			body:       `{"email": "boris@example.com"}`,
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Seats",
+			body:       `{"email": "boris@example.com", "seat_ids": [101]}`,
+			seatIDs:    []int64{101},
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Empty Body",
+			respCode:   resp.CodeEmptyBody,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Bad Email",
+			body:       `{"email": "boris"}`,
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Quantity With Seats",
+			body:       `{"email": "boris@example.com", "quantity": 1, "seat_ids": [1]}`,
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "No Recipient",
+			body:       `{"email": "boris@example.com"}`,
+			respCode:   resp.CodeUserNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrUserNotFound,
+			callsMock:  true,
+		}, {
+			name:       "To Self",
+			body:       `{"email": "boris@example.com"}`,
+			respCode:   resp.CodeTransferToSelf,
+			respStatus: http.StatusUnprocessableEntity,
+			mockError:  storage.ErrTransferToSelf,
+			callsMock:  true,
+		}, {
+			name:       "Too Many",
+			body:       `{"email": "boris@example.com", "quantity": 5}`,
+			quantity:   5,
+			respCode:   resp.CodeTransferQuantity,
+			respStatus: http.StatusUnprocessableEntity,
+			mockError:  storage.ErrTransferQuantity,
+			callsMock:  true,
+		}, {
+			name:       "Already Pending",
+			body:       `{"email": "boris@example.com"}`,
+			respCode:   resp.CodeTransferExists,
+			respStatus: http.StatusConflict,
+			mockError:  storage.ErrTransferExists,
+			callsMock:  true,
+		}, {
+			name:       "Hold",
+			body:       `{"email": "boris@example.com"}`,
+			respCode:   resp.CodeNotTransferable,
+			respStatus: http.StatusConflict,
+			mockError:  storage.ErrNotTransferable,
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			creatorMock := mocks.NewTransferCreator(t)
+			if tc.callsMock {
+				var transfer *models.BookingTransfer
+				if tc.mockError == nil {
+					transfer = &models.BookingTransfer{ID: 1, BookingID: 10, ToEmail: "boris@example.com", Status: models.TransferPending}
+				}
+				creatorMock.On("CreateTransfer", mock.Anything, int64(10), senderID, "boris@example.com", tc.quantity, tc.seatIDs).
+					Return(transfer, tc.mockError).
+					Once()
+			}
+
+			rr := httptest.NewRecorder()
+			NewCreate(slogdiscard.NewDiscardLogger(), creatorMock).
+				ServeHTTP(rr, newRequest(t, http.MethodPost, "10", tc.body, senderID))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body TransferResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respCode == "" {
+				require.Equal(t, models.TransferPending, body.Transfer.Status)
+			}
+		})
+	}
+}
+
+func TestListHandler(t *testing.T) {
+	resolved := time.Now()
+	newBookingID := int64(20)
+
+	cases := []struct {
+		name       string                    // Имя теста
+		transfers  []*models.BookingTransfer // Передачи, которые вернет mock
+		respCode   string                    // Указываем какой код ошибки хотим получить
+		respStatus int                       // Ожидаемый HTTP статус
+		mockError  error                     // Ошибка которую выдает mock
+	}{
+		{
+			name: "Incoming And History",
+			transfers: []*models.BookingTransfer{
+				{ID: 2, BookingID: 11, ToEmail: "anna@example.com", Status: models.TransferPending},
+				{ID: 1, BookingID: 10, ToEmail: "boris@example.com", Status: models.TransferAccepted, NewBookingID: &newBookingID, ResolvedAt: &resolved},
+			},
+			respStatus: http.StatusOK,
+		}, {
+			name:       "Empty",
+			transfers:  []*models.BookingTransfer{},
+			respStatus: http.StatusOK,
+		}, {
+			name:       "Storage Error",
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("database connection error"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			listerMock := mocks.NewTransferLister(t)
+			listerMock.On("GetTransfers", mock.Anything, senderID).
+				Return(tc.transfers, tc.mockError).
+				Once()
+
+			rr := httptest.NewRecorder()
+			NewList(slogdiscard.NewDiscardLogger(), listerMock).
+				ServeHTTP(rr, newRequest(t, http.MethodGet, "", "", senderID))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body TransfersResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			require.Len(t, body.Transfers, len(tc.transfers))
+			for i, transfer := range tc.transfers {
+				require.Equal(t, transfer.Status, body.Transfers[i].Status)
+				require.Equal(t, transfer.NewBookingID, body.Transfers[i].NewBookingID)
+			}
+		})
+	}
+}
+
+func TestAcceptHandler(t *testing.T) {
+	cases := []struct {
+		name       string // Имя теста
+		id         string // ID передачи в пути
+		respCode   string // Указываем какой код ошибки хотим получить
+		respStatus int    // Ожидаемый HTTP статус
+		mockError  error  // Ошибка которую выдает mock
+		callsMock  bool   // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Accepted",
+			id:         "1",
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Invalid ID",
+			id:         "abc",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Not Found",
+			id:         "1",
+			respCode:   resp.CodeTransferNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrTransferNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Resolved",
+			id:         "1",
+			respCode:   resp.CodeTransferNotPending,
+			respStatus: http.StatusConflict,
+			mockError:  storage.ErrTransferNotPending,
+			callsMock:  true,
+		}, {
+			name:       "Recipient Has Booking",
+			id:         "1",
+			respCode:   resp.CodeBookingExists,
+			respStatus: http.StatusConflict,
+			mockError:  storage.ErrBookingExists,
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			accepterMock := mocks.NewTransferAccepter(t)
+			if tc.callsMock {
+				var booking *models.BookingWithEvent
+				if tc.mockError == nil {
+					booking = &models.BookingWithEvent{Booking: models.Booking{
+						ID:          20,
+						UserID:      recipientID,
+						Quantity:    1,
+						Status:      models.BookingStatusConfirmed,
+						BookingCode: "BK-new",
+					}}
+				}
+				accepterMock.On("AcceptTransfer", mock.Anything, int64(1), recipientID).
+					Return(booking, tc.mockError).
+					Once()
+			}
+
+			rr := httptest.NewRecorder()
+			NewAccept(slogdiscard.NewDiscardLogger(), accepterMock).
+				ServeHTTP(rr, newRequest(t, http.MethodPost, tc.id, "", recipientID))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body AcceptResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respCode == "" {
+				require.Equal(t, int64(20), body.Booking.ID)
+				require.Equal(t, "BK-new", body.Booking.BookingCode)
+			}
+		})
+	}
+}
+
+func TestDeclineHandler(t *testing.T) {
+	cases := []struct {
+		name       string                // Имя теста
+		id         string                // ID передачи в пути
+		userID     int64                 // Кто закрывает передачу
+		status     models.TransferStatus // Итоговый статус передачи
+		respCode   string                // Указываем какой код ошибки хотим получить
+		respStatus int                   // Ожидаемый HTTP статус
+		mockError  error                 // Ошибка которую выдает mock
+		callsMock  bool                  // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Recipient Declines",
+			id:         "1",
+			userID:     recipientID,
+			status:     models.TransferDeclined,
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Sender Revokes",
+			id:         "1",
+			userID:     senderID,
+			status:     models.TransferCancelled,
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Invalid ID",
+			id:         "abc",
+			userID:     recipientID,
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Stranger",
+			id:         "1",
+			userID:     44,
+			respCode:   resp.CodeTransferNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrTransferNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Already Accepted",
+			id:         "1",
+			userID:     senderID,
+			respCode:   resp.CodeTransferNotPending,
+			respStatus: http.StatusConflict,
+			mockError:  storage.ErrTransferNotPending,
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			declinerMock := mocks.NewTransferDecliner(t)
+			if tc.callsMock {
+				declinerMock.On("DeclineTransfer", mock.Anything, int64(1), tc.userID).
+					Return(tc.status, tc.mockError).
+					Once()
+			}
+
+			rr := httptest.NewRecorder()
+			NewDecline(slogdiscard.NewDiscardLogger(), declinerMock).
+				ServeHTTP(rr, newRequest(t, http.MethodPost, tc.id, "", tc.userID))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body DeclineResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			require.Equal(t, tc.status, body.TransferStatus)
+		})
+	}
+}
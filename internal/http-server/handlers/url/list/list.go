@@ -0,0 +1,89 @@
+package list
+
+import (
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/cursor"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/models"
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"golang.org/x/exp/slog"
+)
+
+// URLLister интерфейс для получения ссылок пользователя
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --all
+type URLLister interface {
+	GetURLsByUserID(ctx context.Context, userID int64, page models.Page) ([]*models.URL, *models.Cursor, error)
+}
+
+// Response ответ со списком ссылок
+type Response struct {
+	resp.Response
+	URLs []models.URLResponse `json:"urls"`
+	// NextCursor передается в cursor для следующей страницы; пустой на последней
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// New возвращает хендлер для получения ссылок текущего пользователя
+// @Summary Мои короткие ссылки
+// @Description Возвращает ссылки, созданные текущим пользователем, новые первыми
+// @Tags url
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Количество записей (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение (по умолчанию 0), игнорируется вместе с cursor"
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} Response
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 500 {object} resp.Response
+// @Router /url [get]
+func New(log *slog.Logger, lister URLLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.list.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		page, err := request.Page(r, models.OrderNewest)
+		if err != nil {
+			log.Info("invalid cursor", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid cursor"))
+			return
+		}
+
+		urls, next, err := lister.GetURLsByUserID(r.Context(), userID, page)
+		if err != nil {
+			log.Error("failed to get urls", sl.Err(err))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to get urls"))
+			return
+		}
+
+		response := make([]models.URLResponse, 0, len(urls))
+		for _, u := range urls {
+			response = append(response, u.ToResponse())
+		}
+
+		render.JSON(w, r, Response{
+			Response:   resp.OK(),
+			URLs:       response,
+			NextCursor: cursor.Encode(next),
+		})
+	}
+}
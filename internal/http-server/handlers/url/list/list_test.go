@@ -0,0 +1,123 @@
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"API/internal/http-server/handlers/url/list/mocks"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/cursor"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+func TestListHandler(t *testing.T) {
+	after := models.NewestCursor(time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC), 10)
+	next := models.NewestCursor(time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC), 8)
+	foreign := models.Cursor{Order: models.SortDate, Time: after.Time, ID: after.ID}
+
+	cases := []struct {
+		name       string         // Имя теста
+		query      string         // Строка запроса
+		anonymous  bool           // Запрос без user_id в контексте
+		page       models.Page    // Страница, которую хэндлер передает в сторадж
+		next       *models.Cursor // Курсор следующей страницы из стораджа
+		respCode   string         // Указываем какой код ошибки хотим получить
+		respStatus int            // Ожидаемый HTTP статус
+		mockError  error          // Ошибка которую выдает mock
+		callsMock  bool           // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Default Limit",
+			page:       models.Page{Limit: request.DefaultLimit},
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Limit And Offset",
+			query:      "?limit=2&offset=4",
+			page:       models.Page{Limit: 2, Offset: 4},
+			next:       &next,
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Invalid Limit",
+			query:      "?limit=-1",
+			page:       models.Page{Limit: request.DefaultLimit},
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Cursor",
+			query:      "?limit=2&cursor=" + cursor.Encode(&after),
+			page:       models.Page{Limit: 2, After: &after},
+			next:       &next,
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Broken Cursor",
+			query:      "?cursor=not-a-cursor",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Cursor Of Other Order",
+			query:      "?cursor=" + cursor.Encode(&foreign),
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Storage Error",
+			page:       models.Page{Limit: request.DefaultLimit},
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("unexpected error"),
+			callsMock:  true,
+		}, {
+			name:       "Unauthorized",
+			anonymous:  true,
+			respCode:   resp.CodeUnauthorized,
+			respStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			listerMock := mocks.NewURLLister(t)
+			if tc.callsMock {
+				var urls []*models.URL
+				if tc.mockError == nil {
+					urls = []*models.URL{{ID: 10, Alias: "abc", URL: "https://example.com"}}
+				}
+				listerMock.On("GetURLsByUserID", mock.Anything, int64(42), tc.page).
+					Return(urls, tc.next, tc.mockError).
+					Once()
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/url"+tc.query, nil)
+			require.NoError(t, err)
+			if !tc.anonymous {
+				req = req.WithContext(context.WithValue(req.Context(), authMiddleware.UserIDKey, int64(42)))
+			}
+
+			rr := httptest.NewRecorder()
+			New(slogdiscard.NewDiscardLogger(), listerMock).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respStatus == http.StatusOK {
+				require.Len(t, body.URLs, 1)
+				require.Equal(t, cursor.Encode(tc.next), body.NextCursor)
+			}
+		})
+	}
+}
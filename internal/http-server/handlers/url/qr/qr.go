@@ -0,0 +1,111 @@
+package qr
+
+import (
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	qrlib "API/internal/lib/qr"
+	"API/internal/models"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"golang.org/x/exp/slog"
+)
+
+// URLResolver интерфейс для получения ссылки по алиасу
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --all
+type URLResolver interface {
+	ResolveURL(ctx context.Context, alias string) (*models.URL, error)
+}
+
+// New возвращает хендлер QR кода короткой ссылки. baseURL публичный адрес
+// сокращателя; если пустой, берется из запроса.
+// @Summary QR код короткой ссылки
+// @Description Возвращает QR код с короткой ссылкой в PNG или SVG. Доступно только владельцу
+// @Tags url
+// @Security BearerAuth
+// @Produce png
+// @Produce image/svg+xml
+// @Param alias path string true "Алиас ссылки"
+// @Param format query string false "Формат изображения" Enums(png, svg) default(png)
+// @Param size query int false "Размер в пикселях, от 64 до 2048" default(256)
+// @Param level query string false "Уровень коррекции ошибок" Enums(L, M, Q, H) default(M)
+// @Success 200 {file} binary
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response "Ссылка не найдена или принадлежит другому пользователю"
+// @Failure 500 {object} resp.Response
+// @Router /url/{alias}/qr [get]
+func New(log *slog.Logger, resolver URLResolver, baseURL string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.qr.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		opts, err := qrlib.ParseOptions(r.URL.Query())
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, err.Error()))
+			return
+		}
+
+		alias := chi.URLParam(r, "alias")
+
+		u, err := resolver.ResolveURL(r.Context(), alias)
+		if err == nil && (u.UserID == nil || *u.UserID != userID) {
+			// Чужая ссылка неотличима от несуществующей
+			u, err = nil, errNotOwner
+		}
+		if err != nil {
+			if errors.Is(err, errNotOwner) || resp.IsKnownStorageError(err) {
+				log.Info("url not found", slog.String("alias", alias))
+				resp.Render(w, r, http.StatusNotFound, resp.Error(resp.CodeURLNotFound, "url not found"))
+			} else {
+				log.Error("failed to resolve url", sl.Err(err))
+				resp.RenderStorageError(w, r, err)
+			}
+			return
+		}
+
+		image, contentType, err := qrlib.Render(shortURL(r, baseURL, u.Alias), opts)
+		if err != nil {
+			log.Error("failed to render qr", sl.Err(err))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to render qr code"))
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", "private, max-age=300")
+		_, _ = w.Write(image)
+	}
+}
+
+// shortURL собирает публичный адрес короткой ссылки
+func shortURL(r *http.Request, baseURL, alias string) string {
+	if baseURL == "" {
+		scheme := "http"
+		if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+			scheme = "https"
+		}
+		baseURL = scheme + "://" + r.Host
+	}
+
+	return strings.TrimSuffix(baseURL, "/") + "/" + alias
+}
+
+var errNotOwner = errors.New("url belongs to another user")
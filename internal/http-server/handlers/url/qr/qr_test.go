@@ -0,0 +1,138 @@
+package qr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/http-server/handlers/url/qr/mocks"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+var pngMagic = []byte("\x89PNG\r\n\x1a\n")
+
+func TestQRHandler(t *testing.T) {
+	owner, stranger := int64(42), int64(7)
+
+	cases := []struct {
+		name        string      // Имя теста
+		query       string      // Строка запроса
+		url         *models.URL // Ссылка из стораджа
+		contentType string      // Ожидаемый тип изображения
+		respCode    string      // Указываем какой код ошибки хотим получить
+		respStatus  int         // Ожидаемый HTTP статус
+		mockError   error       // Ошибка которую выдает mock
+		callsMock   bool        // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:        "PNG",
+			url:         &models.URL{Alias: "promo", UserID: &owner},
+			contentType: "image/png",
+			respStatus:  http.StatusOK,
+			callsMock:   true,
+		}, {
+			name:        "SVG",
+			query:       "?format=svg&size=128&level=H",
+			url:         &models.URL{Alias: "promo", UserID: &owner},
+			contentType: "image/svg+xml",
+			respStatus:  http.StatusOK,
+			callsMock:   true,
+		}, {
+			name:       "Invalid Size",
+			query:      "?size=10",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Invalid Format",
+			query:      "?format=gif",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			// Чужая ссылка неотличима от несуществующей
+			name:       "Other Owner",
+			url:        &models.URL{Alias: "promo", UserID: &stranger},
+			respCode:   resp.CodeURLNotFound,
+			respStatus: http.StatusNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Link Without Owner",
+			url:        &models.URL{Alias: "promo"},
+			respCode:   resp.CodeURLNotFound,
+			respStatus: http.StatusNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Not Found",
+			respCode:   resp.CodeURLNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrURLNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Storage Error",
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("unexpected error"),
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolverMock := mocks.NewURLResolver(t)
+			if tc.callsMock {
+				resolverMock.On("ResolveURL", mock.Anything, "promo").
+					Return(tc.url, tc.mockError).
+					Once()
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/url/promo/qr"+tc.query, nil)
+			require.NoError(t, err)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("alias", "promo")
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			req = req.WithContext(context.WithValue(ctx, authMiddleware.UserIDKey, owner))
+
+			rr := httptest.NewRecorder()
+			New(slogdiscard.NewDiscardLogger(), resolverMock, "https://sho.rt").ServeHTTP(rr, req)
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			if tc.respStatus != http.StatusOK {
+				var body resp.Response
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+				require.Equal(t, tc.respCode, body.Code)
+				return
+			}
+
+			require.Equal(t, tc.contentType, rr.Header().Get("Content-Type"))
+			require.Equal(t, "private, max-age=300", rr.Header().Get("Cache-Control"))
+			if tc.contentType == "image/png" {
+				require.True(t, bytes.HasPrefix(rr.Body.Bytes(), pngMagic))
+			} else {
+				require.Contains(t, rr.Body.String(), "<svg")
+			}
+		})
+	}
+}
+
+func TestShortURL(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://api.internal/url/promo/qr", nil)
+	require.Equal(t, "https://sho.rt/promo", shortURL(req, "https://sho.rt/", "promo"))
+	require.Equal(t, "http://api.internal/promo", shortURL(req, "", "promo"))
+
+	// За TLS-терминирующим прокси схема берется из X-Forwarded-Proto
+	req.Header.Set("X-Forwarded-Proto", "https")
+	require.Equal(t, "https://api.internal/promo", shortURL(req, "", "promo"))
+}
@@ -0,0 +1,67 @@
+package remove
+
+import (
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"golang.org/x/exp/slog"
+)
+
+// URLRemover интерфейс для удаления ссылки
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --all
+type URLRemover interface {
+	DeleteURL(ctx context.Context, alias string, userID int64) error
+}
+
+// New возвращает хендлер для удаления короткой ссылки
+// @Summary Удалить короткую ссылку
+// @Description Удаляет ссылку. Доступно только владельцу
+// @Tags url
+// @Security BearerAuth
+// @Produce json
+// @Param alias path string true "Алиас ссылки"
+// @Success 200 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response "Ссылка не найдена или принадлежит другому пользователю"
+// @Failure 500 {object} resp.Response
+// @Router /url/{alias} [delete]
+func New(log *slog.Logger, remover URLRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.remove.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		alias := chi.URLParam(r, "alias")
+
+		if err := remover.DeleteURL(r.Context(), alias, userID); err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("url not deleted", sl.Err(err), slog.String("alias", alias))
+			} else {
+				log.Error("failed to delete url", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		log.Info("url deleted", slog.String("alias", alias))
+		render.JSON(w, r, resp.OK())
+	}
+}
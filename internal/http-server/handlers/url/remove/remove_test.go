@@ -0,0 +1,85 @@
+package remove
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/http-server/handlers/url/remove/mocks"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+)
+
+func TestRemoveHandler(t *testing.T) {
+	cases := []struct {
+		name       string // Имя теста
+		anonymous  bool   // Запрос без user_id в контексте
+		respCode   string // Указываем какой код ошибки хотим получить
+		respStatus int    // Ожидаемый HTTP статус
+		mockError  error  // Ошибка которую выдает mock
+		callsMock  bool   // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Success",
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			// Чужая ссылка неотличима от несуществующей
+			name:       "Not Owner",
+			respCode:   resp.CodeURLNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrURLNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Storage Error",
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("unexpected error"),
+			callsMock:  true,
+		}, {
+			name:       "Unauthorized",
+			anonymous:  true,
+			respCode:   resp.CodeUnauthorized,
+			respStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			removerMock := mocks.NewURLRemover(t)
+			if tc.callsMock {
+				removerMock.On("DeleteURL", mock.Anything, "promo", int64(42)).
+					Return(tc.mockError).
+					Once()
+			}
+
+			req, err := http.NewRequest(http.MethodDelete, "/url/promo", nil)
+			require.NoError(t, err)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("alias", "promo")
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			if !tc.anonymous {
+				ctx = context.WithValue(ctx, authMiddleware.UserIDKey, int64(42))
+			}
+
+			rr := httptest.NewRecorder()
+			New(slogdiscard.NewDiscardLogger(), removerMock).ServeHTTP(rr, req.WithContext(ctx))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body resp.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+		})
+	}
+}
@@ -0,0 +1,476 @@
+package save
+
+import (
+	storage "API/internal/Storage"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/lib/metrics"
+	"API/internal/lib/urlpolicy"
+	"API/internal/models"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"golang.org/x/exp/slog"
+)
+
+// maxBatchBodyBytes ограничение тела пакетного запроса вместе с CSV файлом
+const maxBatchBodyBytes = 10 << 20
+
+// batchFileField поле multipart формы с CSV файлом
+const batchFileField = "file"
+
+var errInvalidCSV = errors.New("invalid csv")
+
+// BatchItemResult результат создания одной ссылки из пакета
+type BatchItemResult struct {
+	resp.Response
+	// Index позиция ссылки в запросе, начиная с 0
+	Index int    `json:"index" example:"0"`
+	Alias string `json:"alias,omitempty" example:"summer-sale"`
+}
+
+// BatchResponse результаты пакета в порядке ссылок в запросе
+type BatchResponse struct {
+	resp.Response
+	Created int               `json:"created" example:"2"`
+	Failed  int               `json:"failed" example:"1"`
+	Results []BatchItemResult `json:"results"`
+}
+
+// batchItem ссылка пакета в процессе сохранения
+type batchItem struct {
+	req Request
+	// alias текущий алиас; для сгенерированных пустой до генерации
+	alias     string
+	generated bool
+	done      bool
+	result    BatchItemResult
+}
+
+func (it *batchItem) succeed() {
+	it.done = true
+	it.result.Response = resp.OK()
+	it.result.Alias = it.alias
+}
+
+func (it *batchItem) fail(rejected resp.Response) {
+	it.done = true
+	it.result.Response = rejected
+}
+
+// NewBatch создает хендлер пакетного создания коротких ссылок
+// @Summary Создать короткие ссылки пакетом
+// @Description Принимает JSON массив ссылок в формате POST /url или CSV (text/csv либо поле file формы multipart/form-data)
+// @Description с заголовком url,alias,expires_at,max_clicks; обязательна только колонка url.
+// @Description Каждая ссылка проверяется отдельно, результаты возвращаются в порядке запроса.
+// @Description По умолчанию сохраняются все корректные ссылки (207 при частичном успехе).
+// @Description С atomic=true пакет сохраняется целиком или не сохраняется вовсе (422 BATCH_REJECTED)
+// @Tags url
+// @Security BearerAuth
+// @Accept json,text/csv,mpfd
+// @Produce json
+// @Param request body []Request false "Ссылки в формате POST /url"
+// @Param atomic query bool false "Сохранить все ссылки или ни одной"
+// @Success 201 {object} BatchResponse "Созданы все ссылки"
+// @Success 207 {object} BatchResponse "Созданы не все ссылки, причины в results"
+// @Failure 400 {object} resp.Response "Пустой пакет или некорректное тело"
+// @Failure 401 {object} resp.Response
+// @Failure 413 {object} resp.Response "В пакете больше ссылок, чем разрешено (BATCH_TOO_LARGE)"
+// @Failure 422 {object} BatchResponse "Не создано ни одной ссылки (BATCH_REJECTED)"
+// @Failure 500 {object} resp.Response
+// @Router /url/batch [post]
+func NewBatch(log *slog.Logger, urlSaver URLSaver, generator AliasGenerator, policy urlpolicy.Checker, maxItems int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.save.NewBatch"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+
+			return
+		}
+
+		atomic := false
+		if raw := r.URL.Query().Get("atomic"); raw != "" {
+			var err error
+			if atomic, err = strconv.ParseBool(raw); err != nil {
+				resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "atomic must be true or false"))
+
+				return
+			}
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxBatchBodyBytes)
+
+		items, err := decodeBatch(r)
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.Is(err, io.EOF):
+			log.Error("request body is empty")
+
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeEmptyBody, "empty request body"))
+
+			return
+		case errors.As(err, &tooLarge):
+			log.Info("batch body too large")
+
+			resp.Render(w, r, http.StatusRequestEntityTooLarge, resp.Error(resp.CodeBatchTooLarge,
+				fmt.Sprintf("request body must be at most %d bytes", maxBatchBodyBytes)))
+
+			return
+		case errors.Is(err, errInvalidCSV):
+			log.Info("invalid csv", sl.Err(err))
+
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, err.Error()))
+
+			return
+		case err != nil:
+			log.Error("failed to decode request body", sl.Err(err))
+
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "failed to decode request"))
+
+			return
+		}
+
+		if len(items) == 0 {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeEmptyBody, "batch is empty"))
+
+			return
+		}
+		if len(items) > maxItems {
+			log.Info("batch too large", slog.Int("items", len(items)))
+
+			resp.Render(w, r, http.StatusRequestEntityTooLarge, resp.Error(resp.CodeBatchTooLarge,
+				fmt.Sprintf("batch must contain at most %d items", maxItems)))
+
+			return
+		}
+
+		now := time.Now()
+		invalid := false
+		for _, it := range items {
+			if it.done {
+				invalid = true
+				continue
+			}
+			if rejected := check(it.req, policy, r.Host, now); rejected != nil {
+				it.fail(*rejected)
+				invalid = true
+			}
+		}
+
+		if atomic && invalid {
+			log.Info("atomic batch rejected by validation")
+
+			responseBatch(w, r, rejectRemaining(items))
+
+			return
+		}
+
+		err = saveBatch(r.Context(), log, urlSaver, generator, items, userID, atomic)
+		if errors.Is(err, storage.ErrBatchRejected) {
+			log.Info("atomic batch rejected by storage")
+
+			responseBatch(w, r, rejectRemaining(items))
+
+			return
+		}
+		if err != nil {
+			log.Error("failed to save batch", sl.Err(err))
+
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to save urls"))
+
+			return
+		}
+
+		responseBatch(w, r, items)
+	}
+}
+
+// saveBatch сохраняет прошедшие проверку ссылки. Коллизии сгенерированных
+// алиасов повторяются следующим вызовом стораджа с новыми алиасами: без
+// atomic повторяются только они, с atomic - весь откаченный пакет.
+// Занятый заданный алиас в режиме atomic возвращает storage.ErrBatchRejected.
+func saveBatch(
+	ctx context.Context,
+	log *slog.Logger,
+	urlSaver URLSaver,
+	generator AliasGenerator,
+	items []*batchItem,
+	userID int64,
+	atomic bool,
+) error {
+	var pending []*batchItem
+	for _, it := range items {
+		if !it.done {
+			pending = append(pending, it)
+		}
+	}
+
+	// saved есть ли уже закоммиченные ссылки. После этого ошибка
+	// не должна превращаться в 500: клиент не узнал бы о созданных ссылках
+	saved := false
+	fail := func(err error) error {
+		if !saved {
+			return err
+		}
+		log.Error("failed to save rest of batch", sl.Err(err), slog.Int("items", len(pending)))
+		for _, it := range pending {
+			it.fail(resp.Error(resp.CodeInternal, "failed to save url"))
+		}
+		return nil
+	}
+
+	collisions := 0
+	for attempt := 0; len(pending) > 0; attempt++ {
+		if attempt == generator.Attempts() {
+			return fail(errAliasesExhausted)
+		}
+
+		urls := make([]models.NewURL, len(pending))
+		for i, it := range pending {
+			if it.alias == "" {
+				alias, err := generator.Generate()
+				if err != nil {
+					return fail(err)
+				}
+				it.alias = alias
+			}
+
+			urls[i] = models.NewURL{
+				URL:       it.req.URL,
+				Alias:     it.alias,
+				ExpiresAt: it.req.ExpiresAt,
+				MaxClicks: it.req.MaxClicks,
+			}
+		}
+
+		results, err := urlSaver.SaveURLs(ctx, userID, urls, atomic)
+		rejected := errors.Is(err, storage.ErrBatchRejected)
+		if err != nil && !rejected {
+			return fail(err)
+		}
+
+		var retry []*batchItem
+		taken, collided := false, false
+		for i, it := range pending {
+			switch {
+			case results[i].Err == nil && rejected:
+				// пакет откачен, сохраним заново вместе с остальными
+				retry = append(retry, it)
+			case results[i].Err == nil:
+				it.succeed()
+				saved = true
+			case it.generated:
+				metrics.AliasCollisions.Inc()
+				log.Warn("generated alias collision", slog.String("alias", it.alias), slog.Int("attempt", attempt+1))
+
+				collided = true
+				it.alias = ""
+				retry = append(retry, it)
+			default:
+				_, rejectedItem := resp.StorageError(results[i].Err)
+				it.fail(rejectedItem)
+				taken = true
+			}
+		}
+
+		if rejected && taken {
+			return storage.ErrBatchRejected
+		}
+		pending = retry
+
+		if collided {
+			collisions++
+			if collisions >= collisionsBeforeGrow {
+				collisions = 0
+				if length, grown := generator.Grow(); grown {
+					log.Warn("alias length increased", slog.Int("length", length))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// rejectRemaining помечает несохраненные ссылки отклоненного atomic пакета
+func rejectRemaining(items []*batchItem) []*batchItem {
+	for _, it := range items {
+		if !it.done || it.result.Status == resp.StatusOK {
+			it.fail(resp.Error(resp.CodeBatchRejected, "not saved: other items of the batch were rejected"))
+			it.result.Alias = ""
+		}
+	}
+	return items
+}
+
+// decodeBatch разбирает тело пакетного запроса по Content-Type:
+// text/csv, multipart/form-data с CSV в поле file или JSON массив
+func decodeBatch(r *http.Request) ([]*batchItem, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	switch mediaType {
+	case "text/csv":
+		return decodeCSV(r.Body)
+	case "multipart/form-data":
+		file, _, err := r.FormFile(batchFileField)
+		if errors.Is(err, http.ErrMissingFile) {
+			return nil, fmt.Errorf("%w: form field %q is required", errInvalidCSV, batchFileField)
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		return decodeCSV(file)
+	}
+
+	var reqs []Request
+	if err := request.DecodeJSON(r, &reqs); err != nil {
+		return nil, err
+	}
+
+	items := make([]*batchItem, len(reqs))
+	for i, req := range reqs {
+		items[i] = newBatchItem(i, req)
+	}
+
+	return items, nil
+}
+
+func newBatchItem(index int, req Request) *batchItem {
+	return &batchItem{
+		req:       req,
+		alias:     req.Alias,
+		generated: req.Alias == "",
+		result:    BatchItemResult{Index: index},
+	}
+}
+
+// decodeCSV читает CSV с заголовком. Колонки могут идти в любом порядке,
+// url обязательна; ошибки формата значений относятся к своей строке.
+func decodeCSV(body io.Reader) ([]*batchItem, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidCSV, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Excel пишет в начало файла BOM
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		switch name {
+		case "url", "alias", "expires_at", "max_clicks":
+		default:
+			return nil, fmt.Errorf("%w: unknown column %q", errInvalidCSV, name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["url"]; !ok {
+		return nil, fmt.Errorf("%w: column \"url\" is required", errInvalidCSV)
+	}
+
+	var items []*batchItem
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidCSV, err)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		req := Request{URL: field("url"), Alias: field("alias")}
+		var details []resp.FieldError
+
+		if raw := field("expires_at"); raw != "" {
+			expiresAt, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				details = append(details, resp.FieldError{
+					Field: "expires_at", Rule: "datetime", Param: time.RFC3339,
+					Message: "field expires_at must be a RFC 3339 timestamp",
+				})
+			}
+			req.ExpiresAt = &expiresAt
+		}
+		if raw := field("max_clicks"); raw != "" {
+			maxClicks, err := strconv.Atoi(raw)
+			if err != nil {
+				details = append(details, resp.FieldError{
+					Field: "max_clicks", Rule: "number",
+					Message: "field max_clicks must be an integer",
+				})
+			}
+			req.MaxClicks = &maxClicks
+		}
+
+		it := newBatchItem(len(items), req)
+		if len(details) > 0 {
+			rejected := resp.Error(resp.CodeValidationFailed, details[0].Message)
+			rejected.Details = details
+			it.fail(rejected)
+		}
+		items = append(items, it)
+	}
+}
+
+func responseBatch(w http.ResponseWriter, r *http.Request, items []*batchItem) {
+	out := BatchResponse{
+		Response: resp.OK(),
+		Results:  make([]BatchItemResult, len(items)),
+	}
+	for i, it := range items {
+		out.Results[i] = it.result
+		if it.result.Status == resp.StatusOK {
+			out.Created++
+		} else {
+			out.Failed++
+		}
+	}
+
+	status := http.StatusMultiStatus
+	switch {
+	case out.Failed == 0:
+		status = http.StatusCreated
+	case out.Created == 0:
+		status = http.StatusUnprocessableEntity
+		out.Response = resp.Error(resp.CodeBatchRejected, "no urls were created")
+	}
+
+	render.Status(r, status)
+	render.JSON(w, r, out)
+}
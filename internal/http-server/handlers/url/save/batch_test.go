@@ -0,0 +1,225 @@
+package save
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/http-server/handlers/url/save/mocks"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+func serveBatch(t *testing.T, urlSaver URLSaver, req *http.Request) (int, BatchResponse) {
+	t.Helper()
+
+	rr := httptest.NewRecorder()
+	NewBatch(slogdiscard.NewDiscardLogger(), urlSaver, newGenerator(t), newPolicy(t), 4).ServeHTTP(rr, req)
+
+	var body BatchResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+
+	return rr.Code, body
+}
+
+// urlsWithAliases проверяет алиасы, переданные в сторадж
+func urlsWithAliases(aliases ...string) interface{} {
+	return mock.MatchedBy(func(urls []models.NewURL) bool {
+		if len(urls) != len(aliases) {
+			return false
+		}
+		for i, u := range urls {
+			if aliases[i] != "*" && u.Alias != aliases[i] {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestBatchHandlerPartial(t *testing.T) {
+	urlSaverMock := mocks.NewURLSaver(t)
+	urlSaverMock.On("SaveURLs", mock.Anything, int64(42), urlsWithAliases("promo", "*", "taken"), false).
+		Return([]models.SavedURL{{ID: 1}, {ID: 2}, {Err: storage.ErrURLExists}}, nil).
+		Once()
+
+	status, body := serveBatch(t, urlSaverMock, newRequest(t, `[
+		{"url": "http://google.com", "alias": "promo"},
+		{"url": "http://google.com/a"},
+		{"url": "not-a-url"},
+		{"url": "http://google.com/b", "alias": "taken"}
+	]`))
+
+	require.Equal(t, http.StatusMultiStatus, status)
+	require.Equal(t, 2, body.Created)
+	require.Equal(t, 2, body.Failed)
+	require.Len(t, body.Results, 4)
+
+	require.Equal(t, "promo", body.Results[0].Alias)
+	require.Len(t, body.Results[1].Alias, 6)
+	require.Equal(t, resp.CodeValidationFailed, body.Results[2].Code)
+	require.Equal(t, resp.CodeURLExists, body.Results[3].Code)
+	for i, r := range body.Results {
+		require.Equal(t, i, r.Index)
+	}
+}
+
+func TestBatchHandlerCSVRetriesGeneratedAlias(t *testing.T) {
+	urlSaverMock := mocks.NewURLSaver(t)
+	urlSaverMock.On("SaveURLs", mock.Anything, int64(42), urlsWithAliases("promo", "*"), false).
+		Return([]models.SavedURL{{ID: 1}, {Err: storage.ErrURLExists}}, nil).
+		Once()
+	// повторяется только ссылка со сгенерированным алиасом
+	urlSaverMock.On("SaveURLs", mock.Anything, int64(42), urlsWithAliases("*"), false).
+		Return([]models.SavedURL{{ID: 2}}, nil).
+		Once()
+
+	req := newRequest(t, "\ufeffURL,alias,max_clicks\nhttp://google.com,promo,10\nhttp://google.com/a,,\n")
+	req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+
+	status, body := serveBatch(t, urlSaverMock, req)
+
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, 2, body.Created)
+	require.Equal(t, "promo", body.Results[0].Alias)
+	require.NotEmpty(t, body.Results[1].Alias)
+}
+
+func TestBatchHandlerMultipart(t *testing.T) {
+	urlSaverMock := mocks.NewURLSaver(t)
+	urlSaverMock.On("SaveURLs", mock.Anything, int64(42), urlsWithAliases("promo"), false).
+		Return([]models.SavedURL{{ID: 1}}, nil).
+		Once()
+
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+	file, err := form.CreateFormFile("file", "links.csv")
+	require.NoError(t, err)
+	_, err = file.Write([]byte("alias,url,expires_at\npromo,http://google.com,\nbad,http://google.com,yesterday\n"))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+
+	req := newRequest(t, buf.String())
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	status, body := serveBatch(t, urlSaverMock, req)
+
+	require.Equal(t, http.StatusMultiStatus, status)
+	require.Equal(t, resp.StatusOK, body.Results[0].Status)
+	require.Equal(t, resp.CodeValidationFailed, body.Results[1].Code)
+	require.Equal(t, "expires_at", body.Results[1].Details[0].Field)
+}
+
+func TestBatchHandlerAtomic(t *testing.T) {
+	t.Run("Taken Alias", func(t *testing.T) {
+		urlSaverMock := mocks.NewURLSaver(t)
+		urlSaverMock.On("SaveURLs", mock.Anything, int64(42), urlsWithAliases("promo", "taken"), true).
+			Return([]models.SavedURL{{ID: 1}, {Err: storage.ErrURLExists}}, storage.ErrBatchRejected).
+			Once()
+
+		req := newRequest(t, `[{"url": "http://google.com", "alias": "promo"}, {"url": "http://google.com", "alias": "taken"}]`)
+		req.URL.RawQuery = "atomic=true"
+
+		status, body := serveBatch(t, urlSaverMock, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, status)
+		require.Equal(t, resp.CodeBatchRejected, body.Code)
+		require.Equal(t, 0, body.Created)
+		require.Equal(t, resp.CodeBatchRejected, body.Results[0].Code)
+		require.Empty(t, body.Results[0].Alias)
+		require.Equal(t, resp.CodeURLExists, body.Results[1].Code)
+	})
+
+	t.Run("Generated Collision", func(t *testing.T) {
+		urlSaverMock := mocks.NewURLSaver(t)
+		// пакет откачен из-за коллизии и сохраняется заново целиком
+		urlSaverMock.On("SaveURLs", mock.Anything, int64(42), urlsWithAliases("promo", "*"), true).
+			Return([]models.SavedURL{{ID: 1}, {Err: storage.ErrURLExists}}, storage.ErrBatchRejected).
+			Once()
+		urlSaverMock.On("SaveURLs", mock.Anything, int64(42), urlsWithAliases("promo", "*"), true).
+			Return([]models.SavedURL{{ID: 2}, {ID: 3}}, nil).
+			Once()
+
+		req := newRequest(t, `[{"url": "http://google.com", "alias": "promo"}, {"url": "http://google.com"}]`)
+		req.URL.RawQuery = "atomic=true"
+
+		status, body := serveBatch(t, urlSaverMock, req)
+
+		require.Equal(t, http.StatusCreated, status)
+		require.Equal(t, 2, body.Created)
+	})
+
+	t.Run("Invalid Item", func(t *testing.T) {
+		// до стораджа пакет не доходит
+		req := newRequest(t, `[{"url": "http://google.com", "alias": "promo"}, {"url": "javascript:alert(1)"}]`)
+		req.URL.RawQuery = "atomic=true"
+
+		status, body := serveBatch(t, mocks.NewURLSaver(t), req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, status)
+		require.Equal(t, resp.CodeBatchRejected, body.Results[0].Code)
+		require.Equal(t, resp.CodeURLNotAllowed, body.Results[1].Code)
+	})
+}
+
+func TestBatchHandlerRejectsRequest(t *testing.T) {
+	cases := []struct {
+		name        string
+		body        string
+		contentType string
+		respStatus  int
+		respCode    string
+	}{
+		{
+			name:       "Too Many Items",
+			body:       `[{"url": "http://a.com"}, {"url": "http://b.com"}, {"url": "http://c.com"}, {"url": "http://d.com"}, {"url": "http://e.com"}]`,
+			respStatus: http.StatusRequestEntityTooLarge,
+			respCode:   resp.CodeBatchTooLarge,
+		}, {
+			name:       "Empty Batch",
+			body:       `[]`,
+			respStatus: http.StatusBadRequest,
+			respCode:   resp.CodeEmptyBody,
+		}, {
+			name:       "Not An Array",
+			body:       `{"url": "http://a.com"}`,
+			respStatus: http.StatusBadRequest,
+			respCode:   resp.CodeInvalidBody,
+		}, {
+			name:        "Unknown CSV Column",
+			body:        "url,comment\nhttp://a.com,hi\n",
+			contentType: "text/csv",
+			respStatus:  http.StatusBadRequest,
+			respCode:    resp.CodeInvalidBody,
+		}, {
+			name:        "CSV Without URL",
+			body:        "alias\npromo\n",
+			contentType: "text/csv",
+			respStatus:  http.StatusBadRequest,
+			respCode:    resp.CodeInvalidBody,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newRequest(t, tc.body)
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+
+			status, body := serveBatch(t, mocks.NewURLSaver(t), req)
+
+			require.Equal(t, tc.respStatus, status)
+			require.Equal(t, tc.respCode, body.Code)
+			require.Equal(t, resp.StatusError, body.Status)
+		})
+	}
+}
@@ -3,12 +3,20 @@ package save
 import (
 	// для краткости даем короткий алиас пакету
 	storage "API/internal/Storage"
+	"API/internal/http-server/api"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
 	resp "API/internal/lib/api/response"
+	aliasgen "API/internal/lib/alias"
 	"API/internal/lib/logger/sl"
-	"API/internal/lib/random"
+	"API/internal/lib/metrics"
+	"API/internal/lib/urlpolicy"
+	"API/internal/models"
+	"context"
 	"errors"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/render"
@@ -18,7 +26,12 @@ import (
 
 type Request struct {
 	URL   string `json:"url" validate:"required,url"`
-	Alias string `json:"alias,omitempty"`
+	// Alias необязательный алиас; если не задан, генерируется случайный
+	Alias string `json:"alias,omitempty" example:"summer-sale"`
+	// ExpiresAt момент, после которого ссылка отвечает 410
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// MaxClicks сколько раз ссылку можно открыть
+	MaxClicks *int `json:"max_clicks,omitempty" validate:"omitempty,min=1"`
 }
 
 type Response struct {
@@ -28,12 +41,38 @@ type Response struct {
 
 //go:generate go run github.com/vektra/mockery/v2@v2.28.2 --all
 type URLSaver interface {
-	SaveURL(URL, alias string) (int64, error)
+	SaveURL(ctx context.Context, URL, alias string, userID int64, expiresAt *time.Time, maxClicks *int) (int64, error)
+	SaveURLs(ctx context.Context, userID int64, urls []models.NewURL, atomic bool) ([]models.SavedURL, error)
 }
 
-const aliasLenght = 6
+// AliasGenerator интерфейс генератора алиасов
+type AliasGenerator interface {
+	Generate() (string, error)
+	Grow() (int, bool)
+	Attempts() int
+}
 
-func New(log *slog.Logger, urlSaver URLSaver) http.HandlerFunc {
+// collisionsBeforeGrow после скольких коллизий подряд удлинять алиасы
+const collisionsBeforeGrow = 2
+
+var errAliasesExhausted = errors.New("all generated aliases are taken")
+
+// New создает хендлер сохранения короткой ссылки
+// @Summary Создать короткую ссылку
+// @Description Сохраняет URL под заданным или сгенерированным алиасом. Ссылка принадлежит текущему пользователю.
+// @Description Необязательные expires_at и max_clicks ограничивают срок жизни ссылки и число переходов
+// @Tags url
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body Request true "URL и необязательный алиас"
+// @Success 201 {object} Response
+// @Failure 400 {object} resp.Response "Ошибка валидации или адрес запрещен политикой (URL_NOT_ALLOWED)"
+// @Failure 401 {object} resp.Response
+// @Failure 409 {object} resp.Response "Заданный алиас уже занят"
+// @Failure 500 {object} resp.Response
+// @Router /url [post]
+func New(log *slog.Logger, urlSaver URLSaver, generator AliasGenerator, policy urlpolicy.Checker) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.url.save.New"
 
@@ -42,61 +81,70 @@ func New(log *slog.Logger, urlSaver URLSaver) http.HandlerFunc {
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
 		)
 
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+
+			return
+		}
+
 		var req Request
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.DecodeJSON(r, &req)
 		if errors.Is(err, io.EOF) {
 			// ошибка вылезет если запрос будет с пустым телом
 			log.Error("request body is empty")
 
-			render.JSON(w, r, resp.Error("empty request"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeEmptyBody, "empty request body"))
 
 			return
-
 		}
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to decode body"))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "failed to decode request"))
 
 			return
-
 		}
 
 		log.Info("request body decoded", slog.Any("req", req))
 
-		// Создаем объект валидатора
-		// и передаем в него структуру, которую нужно провалидировать
-		if err := validator.New().Struct(req); err != nil {
-			var validateError validator.ValidationErrors
-			errors.As(err, &validateError)
+		if rejected := check(req, policy, r.Host, time.Now()); rejected != nil {
+			log.Info("request rejected", slog.String("code", rejected.Code), slog.String("error", rejected.Error))
 
-			log.Error("invalid request", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, *rejected)
 
-			render.JSON(w, r, resp.ValidErrors(validateError))
+			return
 		}
 
-		alias := req.Alias
-		if alias == "" {
-			alias = random.NewRandomString(aliasLenght)
+		var (
+			alias string
+			id    int64
+		)
+		if req.Alias != "" {
+			alias = req.Alias
+			id, err = urlSaver.SaveURL(r.Context(), req.URL, alias, userID, req.ExpiresAt, req.MaxClicks)
+		} else {
+			alias, id, err = saveGenerated(r.Context(), log, urlSaver, generator, req, userID)
 		}
-
-		id, err := urlSaver.SaveURL(req.URL, alias)
 		if errors.Is(err, storage.ErrURLExists) {
 			// Отдельно обрабатываем ситуацию,
 			// когда запись с таким Alias уже существует
-			log.Info("url already exist", slog.String("url", req.URL))
+			log.Info("alias already exists", slog.String("alias", alias))
 
-			render.JSON(w, r, resp.Error("this url is already exist"))
+			resp.RenderStorageError(w, r, err)
 
 			return
 		}
 		if err != nil {
 			log.Error("failed to add url", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to add url"))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "failed to save url"))
 
 			return
 		}
@@ -107,7 +155,83 @@ func New(log *slog.Logger, urlSaver URLSaver) http.HandlerFunc {
 
 }
 
+// check проверяет ссылку перед сохранением: теги validate, политику
+// адресов, формат алиаса и срок жизни. Возвращает ответ с ошибкой
+// или nil, если ссылку можно сохранять.
+func check(req Request, policy urlpolicy.Checker, host string, now time.Time) *resp.Response {
+	var rejected resp.Response
+
+	// Проверяем структуру по тегам validate
+	if err := request.Validate(req); err != nil {
+		var validateError validator.ValidationErrors
+		errors.As(err, &validateError)
+
+		rejected = resp.ValidErrors(validateError)
+
+		return &rejected
+	}
+
+	decision := policy.Check(req.URL, host)
+
+	switch {
+	case decision.Verdict == urlpolicy.Block:
+		rejected = resp.Error(resp.CodeURLNotAllowed, "url is not allowed: "+decision.Reason)
+	case req.Alias != "" && !aliasgen.ValidCustom(req.Alias):
+		rejected = resp.Error(resp.CodeAliasInvalid,
+			"alias must be 3-64 characters long and contain only latin letters, digits, '-' and '_'")
+	case req.Alias != "" && api.IsReserved(req.Alias):
+		// Алиас не должен перекрывать маршруты сервиса
+		rejected = resp.Error(resp.CodeAliasReserved, "alias is reserved")
+	case req.ExpiresAt != nil && !req.ExpiresAt.After(now):
+		rejected = resp.Error(resp.CodeInvalidParameter, "expires_at must be in the future")
+	default:
+		return nil
+	}
+
+	return &rejected
+}
+
+// saveGenerated сохраняет ссылку под случайным алиасом, повторяя попытку
+// при коллизии. Коллизии подряд означают, что пространство ключей текущей
+// длины заполнено, и генератор удлиняет алиасы.
+func saveGenerated(
+	ctx context.Context,
+	log *slog.Logger,
+	urlSaver URLSaver,
+	generator AliasGenerator,
+	req Request,
+	userID int64,
+) (string, int64, error) {
+	collisions := 0
+
+	for attempt := 0; attempt < generator.Attempts(); attempt++ {
+		alias, err := generator.Generate()
+		if err != nil {
+			return "", 0, err
+		}
+
+		id, err := urlSaver.SaveURL(ctx, req.URL, alias, userID, req.ExpiresAt, req.MaxClicks)
+		if !errors.Is(err, storage.ErrURLExists) {
+			return alias, id, err
+		}
+
+		metrics.AliasCollisions.Inc()
+		log.Warn("generated alias collision", slog.String("alias", alias), slog.Int("attempt", attempt+1))
+
+		collisions++
+		if collisions >= collisionsBeforeGrow {
+			collisions = 0
+			if length, grown := generator.Grow(); grown {
+				log.Warn("alias length increased", slog.Int("length", length))
+			}
+		}
+	}
+
+	return "", 0, errAliasesExhausted
+}
+
 func responseOK(w http.ResponseWriter, r *http.Request, alias string) {
+	render.Status(r, http.StatusCreated)
 	render.JSON(w, r, Response{
 		Response: resp.OK(),
 		Alias:    alias,
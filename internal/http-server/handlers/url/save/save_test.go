@@ -2,6 +2,7 @@ package save
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,53 +13,170 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
-	"API/internal/lib/logger/sl"
+	storage "API/internal/Storage"
+	"API/internal/config"
+	"API/internal/http-server/api"
+	"API/internal/http-server/handlers/url/save/mocks"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	aliasgen "API/internal/lib/alias"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/lib/urlpolicy"
 )
 
+func newGenerator(t *testing.T) *aliasgen.Generator {
+	t.Helper()
+
+	generator, err := aliasgen.NewGenerator(config.AliasConfig{
+		Alphabet:  "abcdefghijklmnopqrstuvwxyz0123456789",
+		Length:    6,
+		MaxLength: 8,
+		Attempts:  3,
+	}, api.IsReserved)
+	require.NoError(t, err)
+
+	return generator
+}
+
+func newPolicy(t *testing.T) *urlpolicy.Policy {
+	t.Helper()
+
+	policy, err := urlpolicy.Load(config.URLPolicyConfig{
+		AllowedSchemes:     []string{"http", "https"},
+		OwnHosts:           []string{"sho.rt"},
+		BlockInternalHosts: true,
+	})
+	require.NoError(t, err)
+
+	return policy
+}
+
+func newRequest(t *testing.T, body string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, "/save", bytes.NewReader([]byte(body)))
+	require.NoError(t, err)
+
+	// Хэндлер стоит за JWTAuth, который кладет user_id в контекст
+	return req.WithContext(context.WithValue(req.Context(), authMiddleware.UserIDKey, int64(42)))
+}
+
 func TestSaveHandler(t *testing.T) {
 	cases := []struct {
-		name      string // Имя теста
-		alias     string // Отправляемый alias
-		url       string // Отправляемый URL
-		respError string //Указываем какую ошибку хотим получить
-		mockError error  //Ошибка которую выдает mock
+		name       string // Имя теста
+		alias      string // Отправляемый alias
+		url        string // Отправляемый URL
+		respCode   string // Указываем какой код ошибки хотим получить
+		respStatus int    // Ожидаемый HTTP статус
+		mockError  error  // Ошибка которую выдает mock
+		callsMock  bool   // Должен ли хэндлер дойти до стораджа
+		anonymous  bool   // Запрос без пользователя в контексте
+		extra      string // Дополнительные поля JSON
 	}{
 		{
-			name:  "Success",
-			alias: "test_alias",
-			url:   "http://google.com",
-			// Тут поля respError и mockError оставляем пустыми,
-			// т.к. это успешный запрос
+			name:       "Success",
+			alias:      "test_alias",
+			url:        "http://google.com",
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Empty url",
+			alias:      "test_alias",
+			url:        "",
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Invalid URL",
+			alias:      "test_alias",
+			url:        "invalid-url",
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Missing Alias",
+			alias:      "",
+			url:        "http://google.com",
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Reserved Alias",
+			alias:      "events",
+			url:        "http://google.com",
+			respCode:   resp.CodeAliasReserved,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Invalid Alias",
+			alias:      "bad/alias",
+			url:        "http://google.com",
+			respCode:   resp.CodeAliasInvalid,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Too Short Alias",
+			alias:      "ab",
+			url:        "http://google.com",
+			respCode:   resp.CodeAliasInvalid,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Javascript Scheme",
+			alias:      "test_alias",
+			url:        "javascript:alert(1)",
+			respCode:   resp.CodeURLNotAllowed,
+			respStatus: http.StatusBadRequest,
 		}, {
-			name:      "Empty url",
-			alias:     "test_alias",
-			url:       "",
-			respError: "URL is required",
-			mockError: nil,
+			name:       "Loop",
+			alias:      "test_alias",
+			url:        "https://sho.rt/abc",
+			respCode:   resp.CodeURLNotAllowed,
+			respStatus: http.StatusBadRequest,
 		}, {
-			name:      "Inbalid URL",
-			alias:     "test_alias",
-			url:       "invalid-url",
-			respError: "invalid url format",
-			mockError: nil,
+			name:       "Internal Host",
+			alias:      "test_alias",
+			url:        "http://169.254.169.254/latest",
+			respCode:   resp.CodeURLNotAllowed,
+			respStatus: http.StatusBadRequest,
 		}, {
-			name:      "Missing Alias",
-			alias:     "",
-			url:       "http://google.com",
-			respError: "Alias is required",
-			mockError: nil,
+			name:       "Duplicate Alias",
+			alias:      "existing_alias",
+			url:        "http://google.com",
+			respCode:   resp.CodeURLExists,
+			respStatus: http.StatusConflict,
+			mockError:  fmt.Errorf("storage.postgres.SaveURL: %w", storage.ErrURLExists),
+			callsMock:  true,
 		}, {
-			name:      "Duplicate Alias",
-			alias:     "existing_alias",
-			url:       "http://google.com",
-			respError: "Alias already exists",
-			mockError: errors.New("alias already exists"),
+			name:       "Save URL Error",
+			alias:      "test_alias",
+			url:        "http://google.com",
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("database connection error"),
+			callsMock:  true,
 		}, {
-			name:      "Save URL Error",
-			alias:     "test_alias",
-			url:       "http://google.com",
-			respError: "Failed to save URL",
-			mockError: errors.New("database connection error"),
+			name:       "No User",
+			alias:      "test_alias",
+			url:        "http://google.com",
+			respCode:   resp.CodeUnauthorized,
+			respStatus: http.StatusUnauthorized,
+			anonymous:  true,
+		}, {
+			name:       "With Limits",
+			alias:      "presale",
+			url:        "http://google.com",
+			extra:      `, "expires_at": "2999-01-01T00:00:00Z", "max_clicks": 100`,
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Expired On Create",
+			alias:      "presale",
+			url:        "http://google.com",
+			extra:      `, "expires_at": "2000-01-01T00:00:00Z"`,
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Zero Max Clicks",
+			alias:      "presale",
+			url:        "http://google.com",
+			extra:      `, "max_clicks": 0`,
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
 		},
 	}
 
@@ -66,25 +184,24 @@ func TestSaveHandler(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			//создаем объект мока стораджа
 			urlSaverMock := mocks.NewURLSaver(t)
-			//если ожидается успешный ответ значит к моку точно будет вызов
-			//даже если ожидаем ошибку
-			//но мок должен ответить ошибкой, к нему тоже будет запрос
-			if tc.respError == "" || tc.mockError != nil {
+			if tc.callsMock {
 				// Сообщаем моку, какой к нему будет запрос, и что надо вернуть
-				urlSaverMock.On("SaveURL", tc.url, mock.AnythingOfType("string")).
+				urlSaverMock.On("SaveURL", mock.Anything, tc.url, mock.AnythingOfType("string"), int64(42), mock.Anything, mock.Anything).
 					Return(int64(1), tc.mockError).
 					Once() // Запрос будет ровно один
 			}
 
 			// Создаем наш хэндлер
-			handler := New(sl.NewDiscardLogger(), urlSaverMock)
+			handler := New(slogdiscard.NewDiscardLogger(), urlSaverMock, newGenerator(t), newPolicy(t))
 
 			// Формируем тело запроса
-			input := fmt.Sprintf(`{"url": "%s", "alias": "%s"}`, tc.url, tc.alias)
+			input := fmt.Sprintf(`{"url": "%s", "alias": "%s"%s}`, tc.url, tc.alias, tc.extra)
 
 			// Создаем объект запроса
-			req, err := http.NewRequest(http.MethodPost, "/save", bytes.NewReader([]byte(input)))
-			require.NoError(t, err)
+			req := newRequest(t, input)
+			if tc.anonymous {
+				req = req.WithContext(context.Background())
+			}
 
 			// Создаем ResponseRecorder для записи ответа хэндлера
 			rr := httptest.NewRecorder()
@@ -92,22 +209,58 @@ func TestSaveHandler(t *testing.T) {
 			handler.ServeHTTP(rr, req)
 
 			// Проверяем, что статус ответа корректный
-			require.Equal(t, rr.Code, http.StatusOK)
-
-			body := rr.Body.String()
+			require.Equal(t, tc.respStatus, rr.Code)
 
-			var resp Response
+			var body Response
 
 			// Анмаршаллим тело, и проверяем что при этом не возникло ошибок
-			require.NoError(t, json.Unmarshal([]byte(body), &resp))
-
-			// Проверяем наличие требуемой ошибки в ответе
-			require.Equal(t, tc.respError, resp.Error)
-
-			// Проверяем, что вызов SaveURL был один раз, если ожидался
-			urlSaverMock.AssertExpectations(t)
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
 
+			// Проверяем код ошибки в ответе
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respCode == "" {
+				require.NotEmpty(t, body.Alias)
+			}
 		})
 	}
+}
+
+func TestSaveHandlerRetriesGeneratedAlias(t *testing.T) {
+	urlSaverMock := mocks.NewURLSaver(t)
+	generator := newGenerator(t)
+
+	// Две коллизии подряд, третья попытка успешна
+	urlSaverMock.On("SaveURL", mock.Anything, "http://google.com", mock.AnythingOfType("string"), int64(42), mock.Anything, mock.Anything).
+		Return(int64(0), storage.ErrURLExists).
+		Twice()
+	urlSaverMock.On("SaveURL", mock.Anything, "http://google.com", mock.AnythingOfType("string"), int64(42), mock.Anything, mock.Anything).
+		Return(int64(1), nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	New(slogdiscard.NewDiscardLogger(), urlSaverMock, generator, newPolicy(t)).
+		ServeHTTP(rr, newRequest(t, `{"url": "http://google.com"}`))
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var body Response
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+
+	// После двух коллизий подряд алиасы удлиняются
+	require.Len(t, body.Alias, 7)
+	require.Equal(t, 7, generator.Length())
+}
+
+func TestSaveHandlerGivesUpAfterAttempts(t *testing.T) {
+	urlSaverMock := mocks.NewURLSaver(t)
+
+	urlSaverMock.On("SaveURL", mock.Anything, "http://google.com", mock.AnythingOfType("string"), int64(42), mock.Anything, mock.Anything).
+		Return(int64(0), storage.ErrURLExists).
+		Times(3)
+
+	rr := httptest.NewRecorder()
+	New(slogdiscard.NewDiscardLogger(), urlSaverMock, newGenerator(t), newPolicy(t)).
+		ServeHTTP(rr, newRequest(t, `{"url": "http://google.com"}`))
 
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
 }
@@ -0,0 +1,133 @@
+package stats
+
+import (
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/models"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"golang.org/x/exp/slog"
+)
+
+const defaultPeriod = 30 * 24 * time.Hour
+
+// maxPeriod ограничивает число точек в series для каждого интервала
+var maxPeriod = map[string]time.Duration{
+	models.StatsBucketHour: 7 * 24 * time.Hour,
+	models.StatsBucketDay:  366 * 24 * time.Hour,
+}
+
+// URLStatsGetter интерфейс для получения статистики переходов
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --all
+type URLStatsGetter interface {
+	GetURLStats(ctx context.Context, alias string, userID int64, from, to time.Time, bucket string) (*models.URLStats, error)
+}
+
+// Response ответ со статистикой
+type Response struct {
+	resp.Response
+	Stats models.URLStats `json:"stats"`
+}
+
+// New возвращает хендлер статистики переходов по ссылке
+// @Summary Статистика короткой ссылки
+// @Description Возвращает число переходов, разрезы по источникам, классам клиентов и регионам, а также ряд по часам или дням. Доступно только владельцу
+// @Tags url
+// @Security BearerAuth
+// @Produce json
+// @Param alias path string true "Алиас ссылки"
+// @Param from query string false "Начало периода, RFC3339 (по умолчанию 30 дней назад)"
+// @Param to query string false "Конец периода, RFC3339 (по умолчанию сейчас)"
+// @Param bucket query string false "Интервал ряда: hour (до 7 дней) или day (до 366 дней)" Enums(hour, day)
+// @Success 200 {object} Response
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response "Ссылка не найдена или принадлежит другому пользователю"
+// @Failure 500 {object} resp.Response
+// @Router /url/{alias}/stats [get]
+func New(log *slog.Logger, getter URLStatsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.stats.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		alias := chi.URLParam(r, "alias")
+		query := r.URL.Query()
+
+		bucket := query.Get("bucket")
+		if bucket == "" {
+			bucket = models.StatsBucketDay
+		}
+		limit, ok := maxPeriod[bucket]
+		if !ok {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "bucket must be hour or day"))
+			return
+		}
+
+		to := time.Now().UTC()
+		if v := query.Get("to"); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid to"))
+				return
+			}
+			to = t.UTC()
+		}
+
+		from := to.Add(-defaultPeriod)
+		if bucket == models.StatsBucketHour {
+			from = to.Add(-limit)
+		}
+		if v := query.Get("from"); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid from"))
+				return
+			}
+			from = t.UTC()
+		}
+
+		if !from.Before(to) {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "from must be before to"))
+			return
+		}
+		if to.Sub(from) > limit {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "period is too long for bucket "+bucket))
+			return
+		}
+
+		stats, err := getter.GetURLStats(r.Context(), alias, userID, from, to, bucket)
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("stats not available", sl.Err(err), slog.String("alias", alias))
+			} else {
+				log.Error("failed to get url stats", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Stats:    *stats,
+		})
+	}
+}
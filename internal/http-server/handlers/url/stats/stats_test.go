@@ -0,0 +1,145 @@
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/http-server/handlers/url/stats/mocks"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+func TestStatsHandler(t *testing.T) {
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name       string        // Имя теста
+		query      string        // Строка запроса
+		bucket     string        // Интервал, который хэндлер передает в сторадж
+		period     time.Duration // Ожидаемая длина периода
+		from       *time.Time    // Ожидаемое начало периода, если задано явно
+		respCode   string        // Указываем какой код ошибки хотим получить
+		respStatus int           // Ожидаемый HTTP статус
+		mockError  error         // Ошибка которую выдает mock
+		callsMock  bool          // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Default Period",
+			bucket:     models.StatsBucketDay,
+			period:     defaultPeriod,
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Default Hourly Period",
+			query:      "?bucket=hour",
+			bucket:     models.StatsBucketHour,
+			period:     7 * 24 * time.Hour,
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Explicit Period",
+			query:      "?from=2026-05-01T00:00:00Z&to=2026-05-02T00:00:00Z&bucket=hour",
+			bucket:     models.StatsBucketHour,
+			period:     24 * time.Hour,
+			from:       &from,
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Unknown Bucket",
+			query:      "?bucket=week",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Invalid From",
+			query:      "?from=yesterday",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Invalid To",
+			query:      "?to=2026-05-02",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "From After To",
+			query:      "?from=2026-05-02T00:00:00Z&to=2026-05-01T00:00:00Z",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Period Too Long For Hours",
+			query:      "?from=2026-05-01T00:00:00Z&to=2026-05-09T00:00:00Z&bucket=hour",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Not Owner",
+			bucket:     models.StatsBucketDay,
+			period:     defaultPeriod,
+			respCode:   resp.CodeURLNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrURLNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Storage Error",
+			bucket:     models.StatsBucketDay,
+			period:     defaultPeriod,
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("unexpected error"),
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			getterMock := mocks.NewURLStatsGetter(t)
+			if tc.callsMock {
+				var stats *models.URLStats
+				if tc.mockError == nil {
+					stats = &models.URLStats{}
+				}
+
+				var gotFrom, gotTo time.Time
+				getterMock.On("GetURLStats", mock.Anything, "promo", int64(42), mock.Anything, mock.Anything, tc.bucket).
+					Run(func(args mock.Arguments) {
+						gotFrom, gotTo = args.Get(3).(time.Time), args.Get(4).(time.Time)
+					}).
+					Return(stats, tc.mockError).
+					Once()
+				defer func() {
+					require.Equal(t, tc.period, gotTo.Sub(gotFrom))
+					if tc.from != nil {
+						require.True(t, tc.from.Equal(gotFrom))
+					}
+				}()
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/url/promo/stats"+tc.query, nil)
+			require.NoError(t, err)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("alias", "promo")
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			req = req.WithContext(context.WithValue(ctx, authMiddleware.UserIDKey, int64(42)))
+
+			rr := httptest.NewRecorder()
+			New(slogdiscard.NewDiscardLogger(), getterMock).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package update
+
+import (
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/lib/urlpolicy"
+	"API/internal/models"
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/exp/slog"
+)
+
+// URLUpdater интерфейс для изменения ссылки
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --all
+type URLUpdater interface {
+	UpdateURL(ctx context.Context, alias string, userID int64, newURL string) (*models.URL, error)
+}
+
+// Request запрос на изменение ссылки
+type Request struct {
+	URL string `json:"url" validate:"required,url"`
+}
+
+// Response ответ с измененной ссылкой
+type Response struct {
+	resp.Response
+	URL models.URLResponse `json:"url"`
+}
+
+// New возвращает хендлер для изменения адреса короткой ссылки
+// @Summary Изменить короткую ссылку
+// @Description Меняет адрес, на который ведет ссылка. Доступно только владельцу
+// @Tags url
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param alias path string true "Алиас ссылки"
+// @Param request body Request true "Новый URL"
+// @Success 200 {object} Response
+// @Failure 400 {object} resp.Response "Ошибка валидации или адрес запрещен политикой (URL_NOT_ALLOWED)"
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response "Ссылка не найдена или принадлежит другому пользователю"
+// @Failure 500 {object} resp.Response
+// @Router /url/{alias} [patch]
+func New(log *slog.Logger, updater URLUpdater, policy urlpolicy.Checker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.update.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		alias := chi.URLParam(r, "alias")
+
+		var req Request
+		if err := request.DecodeJSON(r, &req); err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "invalid request body"))
+			return
+		}
+
+		if err := request.Validate(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Error("validation failed", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.ValidationError(validateErr))
+			return
+		}
+
+		if decision := policy.Check(req.URL, r.Host); decision.Verdict == urlpolicy.Block {
+			log.Info("url rejected by policy", slog.String("url", req.URL), slog.String("reason", decision.Reason))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeURLNotAllowed, "url is not allowed: "+decision.Reason))
+			return
+		}
+
+		u, err := updater.UpdateURL(r.Context(), alias, userID, req.URL)
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("url not updated", sl.Err(err), slog.String("alias", alias))
+			} else {
+				log.Error("failed to update url", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		log.Info("url updated", slog.String("alias", alias))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			URL:      u.ToResponse(),
+		})
+	}
+}
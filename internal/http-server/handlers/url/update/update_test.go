@@ -0,0 +1,138 @@
+package update
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/http-server/handlers/url/update/mocks"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/lib/urlpolicy"
+	policyMocks "API/internal/lib/urlpolicy/mocks"
+	"API/internal/models"
+)
+
+func TestUpdateHandler(t *testing.T) {
+	cases := []struct {
+		name       string            // Имя теста
+		body       string            // Тело запроса
+		anonymous  bool              // Запрос без user_id в контексте
+		url        string            // Адрес, который проверяет политика
+		verdict    urlpolicy.Verdict // Решение политики по адресу
+		respCode   string            // Указываем какой код ошибки хотим получить
+		respStatus int               // Ожидаемый HTTP статус
+		mockError  error             // Ошибка которую выдает mock
+		callsMock  bool              // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Success",
+			body:       `{"url": "https://example.com/new"}`,
+			url:        "https://example.com/new",
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			// Предупреждение показывается при переходе, менять на такой адрес можно
+			name:       "Warned Domain",
+			body:       `{"url": "https://flagged.example/page"}`,
+			url:        "https://flagged.example/page",
+			verdict:    urlpolicy.Warn,
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Rejected By Policy",
+			body:       `{"url": "https://blocked.example/page"}`,
+			url:        "https://blocked.example/page",
+			verdict:    urlpolicy.Block,
+			respCode:   resp.CodeURLNotAllowed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Invalid URL",
+			body:       `{"url": "not a url"}`,
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Invalid Body",
+			body:       `{"url":`,
+			respCode:   resp.CodeInvalidBody,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Not Owner",
+			body:       `{"url": "https://example.com/new"}`,
+			url:        "https://example.com/new",
+			respCode:   resp.CodeURLNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrURLNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Storage Error",
+			body:       `{"url": "https://example.com/new"}`,
+			url:        "https://example.com/new",
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("unexpected error"),
+			callsMock:  true,
+		}, {
+			name:       "Unauthorized",
+			body:       `{"url": "https://example.com/new"}`,
+			anonymous:  true,
+			respCode:   resp.CodeUnauthorized,
+			respStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			updaterMock := mocks.NewURLUpdater(t)
+			policyMock := policyMocks.NewChecker(t)
+
+			if tc.url != "" {
+				policyMock.On("Check", tc.url, mock.Anything).
+					Return(urlpolicy.Decision{Verdict: tc.verdict, Reason: urlpolicy.ReasonBlocked}).
+					Once()
+			}
+			if tc.callsMock {
+				var u *models.URL
+				if tc.mockError == nil {
+					u = &models.URL{ID: 1, Alias: "promo", URL: tc.url}
+				}
+				updaterMock.On("UpdateURL", mock.Anything, "promo", int64(42), tc.url).
+					Return(u, tc.mockError).
+					Once()
+			}
+
+			req, err := http.NewRequest(http.MethodPatch, "/url/promo", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("alias", "promo")
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			if !tc.anonymous {
+				ctx = context.WithValue(ctx, authMiddleware.UserIDKey, int64(42))
+			}
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			New(slogdiscard.NewDiscardLogger(), updaterMock, policyMock).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respStatus == http.StatusOK {
+				require.Equal(t, tc.url, body.URL.URL)
+			}
+		})
+	}
+}
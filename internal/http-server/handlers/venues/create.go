@@ -0,0 +1,126 @@
+package venues
+
+import (
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/models"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/exp/slog"
+)
+
+// VenueCreator интерфейс для создания площадок
+type VenueCreator interface {
+	CreateVenue(ctx context.Context, venue *models.Venue) (*models.Venue, error)
+}
+
+// CreateRequest структура запроса на создание площадки
+type CreateRequest struct {
+	Name    string `json:"name" validate:"required,max=255" example:"Олимпийский"`
+	Address string `json:"address" validate:"required,max=500" example:"Олимпийский проспект, 16"`
+	// Capacity вместимость по умолчанию для мероприятий на площадке
+	Capacity int `json:"capacity" validate:"required,min=1" example:"35000"`
+	// Latitude и Longitude координаты площадки, задаются вместе
+	Latitude  *float64 `json:"latitude,omitempty" validate:"required_with=Longitude,omitempty,latitude" example:"55.7814"`
+	Longitude *float64 `json:"longitude,omitempty" validate:"required_with=Latitude,omitempty,longitude" example:"37.6258"`
+}
+
+// VenueResponse ответ с площадкой
+type VenueResponse struct {
+	resp.Response
+	Venue *models.Venue `json:"venue"`
+}
+
+// NewCreate создает хендлер для создания площадки
+// @Summary Создать площадку
+// @Description Добавляет площадку в общий справочник. Названия сравниваются без учета регистра,
+// @Description ё, кавычек и пунктуации: «Олимпийский» и "олимпийский" — одна площадка
+// @Tags venues
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body CreateRequest true "Данные площадки"
+// @Success 201 {object} VenueResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 409 {object} resp.Response "Площадка с таким названием уже есть"
+// @Failure 500 {object} resp.Response
+// @Router /venues [post]
+func NewCreate(log *slog.Logger, venueCreator VenueCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.venues.Create"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		var req CreateRequest
+		err := request.DecodeJSON(r, &req)
+		if errors.Is(err, io.EOF) {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeEmptyBody, "empty request body"))
+			return
+		}
+		if err != nil {
+			log.Info("failed to decode request", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "invalid request body"))
+			return
+		}
+
+		if err := request.Validate(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("validation failed", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.ValidationError(validateErr))
+			return
+		}
+
+		// Нормализованное название, по которому ищутся дубликаты,
+		// состоит только из букв и цифр
+		name := strings.TrimSpace(req.Name)
+		if strings.IndexFunc(name, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeValidationFailed, "name must contain letters or digits"))
+			return
+		}
+
+		venue, err := venueCreator.CreateVenue(r.Context(), &models.Venue{
+			Name:      name,
+			Address:   strings.TrimSpace(req.Address),
+			Capacity:  req.Capacity,
+			Latitude:  req.Latitude,
+			Longitude: req.Longitude,
+			CreatorID: &userID,
+		})
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("venue not created", sl.Err(err))
+			} else {
+				log.Error("failed to create venue", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		log.Info("venue created", slog.Int64("venue_id", venue.ID))
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, VenueResponse{Response: resp.OK(), Venue: venue})
+	}
+}
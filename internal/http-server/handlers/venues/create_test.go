@@ -0,0 +1,86 @@
+package venues
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+// creatorStub запоминает создаваемую площадку
+type creatorStub struct {
+	venue *models.Venue
+	err   error
+}
+
+func (s *creatorStub) CreateVenue(_ context.Context, venue *models.Venue) (*models.Venue, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.venue = venue
+	venue.ID = 1
+	return venue, nil
+}
+
+func serveCreate(t *testing.T, creator VenueCreator, body string) (int, VenueResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/venues", strings.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), authMiddleware.UserIDKey, int64(42)))
+
+	rr := httptest.NewRecorder()
+	NewCreate(slogdiscard.NewDiscardLogger(), creator).ServeHTTP(rr, req)
+
+	var response VenueResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+	return rr.Code, response
+}
+
+func TestCreate(t *testing.T) {
+	creator := &creatorStub{}
+
+	status, body := serveCreate(t, creator,
+		`{"name":" «Олимпийский» ","address":"Олимпийский проспект, 16","capacity":35000,"latitude":55.7814,"longitude":37.6258}`)
+
+	require.Equal(t, http.StatusCreated, status)
+	require.Equal(t, int64(1), body.Venue.ID)
+	require.Equal(t, "«Олимпийский»", creator.venue.Name)
+	require.Equal(t, 35000, creator.venue.Capacity)
+	require.Equal(t, int64(42), *creator.venue.CreatorID)
+}
+
+func TestCreateErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "empty body", body: "", status: http.StatusBadRequest, code: resp.CodeEmptyBody},
+		{name: "no capacity", body: `{"name":"Олимпийский","address":"Москва"}`, status: http.StatusBadRequest, code: resp.CodeValidationFailed},
+		{name: "half location", body: `{"name":"Олимпийский","address":"Москва","capacity":10,"latitude":55.7}`, status: http.StatusBadRequest, code: resp.CodeValidationFailed},
+		{name: "punctuation only", body: `{"name":"«—»","address":"Москва","capacity":10}`, status: http.StatusBadRequest, code: resp.CodeValidationFailed},
+		{name: "duplicate", body: `{"name":"олимпийский","address":"Москва","capacity":10}`, err: storage.ErrVenueExists, status: http.StatusConflict, code: resp.CodeVenueExists},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := serveCreate(t, &creatorStub{err: tc.err}, tc.body)
+
+			require.Equal(t, tc.status, status)
+			require.Equal(t, tc.code, body.Code)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package venues
+
+import (
+	"API/internal/lib/api/cursor"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/models"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"golang.org/x/exp/slog"
+)
+
+// VenueGetter интерфейс для получения площадок
+type VenueGetter interface {
+	GetVenueByID(ctx context.Context, id int64) (*models.Venue, error)
+	GetVenues(ctx context.Context, query string, page models.Page) ([]*models.Venue, *models.Cursor, error)
+}
+
+// EventLister интерфейс для мероприятий площадки
+type EventLister interface {
+	GetAllEvents(ctx context.Context, filter models.EventFilter, page models.Page) ([]*models.Event, *models.Cursor, error)
+}
+
+// ListResponse ответ со списком площадок
+type ListResponse struct {
+	resp.Response
+	Venues []*models.Venue `json:"venues"`
+	// NextCursor передается в cursor для следующей страницы; пустой на последней
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// GetByIDResponse ответ с площадкой и ее предстоящими мероприятиями
+type GetByIDResponse struct {
+	resp.Response
+	Venue  *models.Venue          `json:"venue"`
+	Events []models.EventResponse `json:"events"`
+	// NextCursor следующая страница мероприятий площадки
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewList создает хендлер списка и поиска площадок
+// @Summary Список площадок
+// @Description Без q — новые площадки первыми. С q — площадки, название которых содержит запрос
+// @Description или похоже на него (с опечатками), самые похожие первыми
+// @Tags venues
+// @Security BearerAuth
+// @Produce json
+// @Param q query string false "Поиск по названию"
+// @Param limit query int false "Количество записей (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение (по умолчанию 0), игнорируется вместе с cursor"
+// @Param cursor query string false "Курсор следующей страницы из next_cursor"
+// @Success 200 {object} ListResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 500 {object} resp.Response
+// @Router /venues [get]
+func NewList(log *slog.Logger, venueGetter VenueGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.venues.List"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		query := strings.TrimSpace(r.URL.Query().Get("q"))
+		order := models.OrderNewest
+		if query != "" {
+			order = models.SortRelevance
+		}
+
+		page, err := request.Page(r, order)
+		if err != nil {
+			log.Info("invalid cursor", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid cursor"))
+			return
+		}
+
+		venues, next, err := venueGetter.GetVenues(r.Context(), query, page)
+		if err != nil {
+			log.Error("failed to get venues", sl.Err(err))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "internal error"))
+			return
+		}
+		if venues == nil {
+			venues = []*models.Venue{}
+		}
+
+		render.JSON(w, r, ListResponse{
+			Response:   resp.OK(),
+			Venues:     venues,
+			NextCursor: cursor.Encode(next),
+		})
+	}
+}
+
+// NewGetByID создает хендлер площадки с предстоящими мероприятиями
+// @Summary Площадка по ID
+// @Description Возвращает площадку и ее еще не начавшиеся мероприятия по дате начала постранично
+// @Tags venues
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID площадки"
+// @Param limit query int false "Количество мероприятий (по умолчанию 20, максимум 100)"
+// @Param cursor query string false "Курсор следующей страницы мероприятий из next_cursor"
+// @Success 200 {object} GetByIDResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response
+// @Failure 500 {object} resp.Response
+// @Router /venues/{id} [get]
+func NewGetByID(log *slog.Logger, venueGetter VenueGetter, eventLister EventLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.venues.GetByID"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid id format"))
+			return
+		}
+
+		page, err := request.Page(r, models.SortDate)
+		if err != nil {
+			log.Info("invalid cursor", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid cursor"))
+			return
+		}
+
+		venue, err := venueGetter.GetVenueByID(r.Context(), id)
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("venue not found", slog.Int64("venue_id", id))
+			} else {
+				log.Error("failed to get venue", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		filter := models.DefaultEventFilter()
+		filter.VenueID = &venue.ID
+		events, next, err := eventLister.GetAllEvents(r.Context(), filter, page)
+		if err != nil {
+			log.Error("failed to get venue events", sl.Err(err))
+			resp.Render(w, r, http.StatusInternalServerError, resp.Error(resp.CodeInternal, "internal error"))
+			return
+		}
+
+		eventResponses := make([]models.EventResponse, 0, len(events))
+		for _, event := range events {
+			eventResponses = append(eventResponses, event.ToResponse())
+		}
+
+		render.JSON(w, r, GetByIDResponse{
+			Response:   resp.OK(),
+			Venue:      venue,
+			Events:     eventResponses,
+			NextCursor: cursor.Encode(next),
+		})
+	}
+}
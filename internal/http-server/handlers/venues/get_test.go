@@ -0,0 +1,226 @@
+package venues
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/lib/api/cursor"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+// getterStub запоминает параметры запроса площадок и отдает заданный результат
+type getterStub struct {
+	query string
+	page  *models.Page
+	id    int64
+
+	venue  *models.Venue
+	venues []*models.Venue
+	next   *models.Cursor
+	err    error
+}
+
+func (s *getterStub) GetVenueByID(_ context.Context, id int64) (*models.Venue, error) {
+	s.id = id
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.venue, nil
+}
+
+func (s *getterStub) GetVenues(_ context.Context, query string, page models.Page) ([]*models.Venue, *models.Cursor, error) {
+	s.query = query
+	s.page = &page
+	return s.venues, s.next, s.err
+}
+
+// eventListerStub запоминает фильтр мероприятий площадки
+type eventListerStub struct {
+	filter *models.EventFilter
+	events []*models.Event
+	next   *models.Cursor
+	err    error
+}
+
+func (s *eventListerStub) GetAllEvents(_ context.Context, filter models.EventFilter, _ models.Page) ([]*models.Event, *models.Cursor, error) {
+	s.filter = &filter
+	return s.events, s.next, s.err
+}
+
+func TestList(t *testing.T) {
+	next := &models.Cursor{Order: models.SortRelevance, ID: 3}
+
+	cases := []struct {
+		name       string
+		query      string
+		venues     []*models.Venue
+		mockError  error
+		respStatus int
+		respCode   string
+		search     string // Строка поиска, дошедшая до стораджа
+		callsStub  bool   // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Newest",
+			venues:     []*models.Venue{{ID: 2, Name: "Лужники"}, {ID: 1, Name: "Олимпийский"}},
+			respStatus: http.StatusOK,
+			callsStub:  true,
+		},
+		{
+			// пробелы вокруг запроса отбрасываются, курсор поиска принимается
+			name:       "Trigram Search",
+			query:      "q=%20%D0%BE%D0%BB%D0%B8%D0%BC%D0%BF%20&cursor=" + cursor.Encode(next),
+			venues:     []*models.Venue{{ID: 1, Name: "Олимпийский"}},
+			respStatus: http.StatusOK,
+			search:     "олимп",
+			callsStub:  true,
+		},
+		{
+			name:       "Empty",
+			query:      "q=nothing",
+			respStatus: http.StatusOK,
+			search:     "nothing",
+			callsStub:  true,
+		},
+		{
+			// курсор списка новых не подходит к поиску
+			name:       "Cursor Of Other Order",
+			query:      "q=olimp&cursor=" + cursor.Encode(&models.Cursor{Order: models.OrderNewest, ID: 1}),
+			respStatus: http.StatusBadRequest,
+			respCode:   resp.CodeInvalidParameter,
+		},
+		{
+			name:       "Broken Cursor",
+			query:      "cursor=not-a-cursor",
+			respStatus: http.StatusBadRequest,
+			respCode:   resp.CodeInvalidParameter,
+		},
+		{
+			name:       "Storage Error",
+			mockError:  errors.New("db down"),
+			respStatus: http.StatusInternalServerError,
+			respCode:   resp.CodeInternal,
+			callsStub:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			getter := &getterStub{venues: tc.venues, next: next, err: tc.mockError}
+
+			req := httptest.NewRequest(http.MethodGet, "/venues", nil)
+			req.URL.RawQuery = tc.query
+
+			rr := httptest.NewRecorder()
+			NewList(slogdiscard.NewDiscardLogger(), getter).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body ListResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+
+			if !tc.callsStub {
+				require.Nil(t, getter.page)
+				return
+			}
+			require.Equal(t, tc.search, getter.query)
+			if tc.respStatus != http.StatusOK {
+				return
+			}
+
+			// пустой результат отдается массивом, а не null
+			require.NotNil(t, body.Venues)
+			require.Len(t, body.Venues, len(tc.venues))
+			require.Equal(t, cursor.Encode(next), body.NextCursor)
+		})
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	venue := &models.Venue{ID: 7, Name: "Олимпийский"}
+	next := &models.Cursor{Order: models.SortDate, ID: 11}
+
+	cases := []struct {
+		name        string
+		id          string
+		query       string
+		mockError   error
+		eventsError error
+		respStatus  int
+		respCode    string
+		callsEvents bool // Должен ли хэндлер запросить мероприятия
+	}{
+		{name: "Success", id: "7", respStatus: http.StatusOK, callsEvents: true},
+		{name: "Invalid ID", id: "abc", respStatus: http.StatusBadRequest, respCode: resp.CodeInvalidParameter},
+		{name: "Broken Cursor", id: "7", query: "cursor=not-a-cursor", respStatus: http.StatusBadRequest, respCode: resp.CodeInvalidParameter},
+		{
+			name:       "Not Found",
+			id:         "7",
+			mockError:  storage.ErrVenueNotFound,
+			respStatus: http.StatusNotFound,
+			respCode:   resp.CodeVenueNotFound,
+		},
+		{
+			name:        "Events Error",
+			id:          "7",
+			eventsError: errors.New("db down"),
+			respStatus:  http.StatusInternalServerError,
+			respCode:    resp.CodeInternal,
+			callsEvents: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			getter := &getterStub{venue: venue, err: tc.mockError}
+			lister := &eventListerStub{
+				events: []*models.Event{{ID: 10, Title: "Концерт", VenueID: &venue.ID}, {ID: 11, Title: "Матч", VenueID: &venue.ID}},
+				next:   next,
+				err:    tc.eventsError,
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/venues/"+tc.id, nil)
+			req.URL.RawQuery = tc.query
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			NewGetByID(slogdiscard.NewDiscardLogger(), getter, lister).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body GetByIDResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+
+			if !tc.callsEvents {
+				require.Nil(t, lister.filter)
+				return
+			}
+
+			// мероприятия выбираются по площадке из пути
+			require.Equal(t, int64(7), getter.id)
+			require.Equal(t, int64(7), *lister.filter.VenueID)
+			if tc.respStatus != http.StatusOK {
+				return
+			}
+
+			require.Equal(t, venue.ID, body.Venue.ID)
+			require.Len(t, body.Events, 2)
+			require.Equal(t, int64(10), body.Events[0].ID)
+			require.Equal(t, cursor.Encode(next), body.NextCursor)
+		})
+	}
+}
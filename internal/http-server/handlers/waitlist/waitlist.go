@@ -0,0 +1,232 @@
+package waitlist
+
+import (
+	authMiddleware "API/internal/http-server/middleware/auth"
+	"API/internal/lib/api/request"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/sl"
+	"API/internal/models"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/exp/slog"
+)
+
+// WaitlistJoiner интерфейс для записи в лист ожидания
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --all
+type WaitlistJoiner interface {
+	JoinWaitlist(ctx context.Context, userID, eventID int64, quantity int) (*models.WaitlistEntry, error)
+}
+
+// WaitlistGetter интерфейс для получения записи в листе ожидания
+type WaitlistGetter interface {
+	GetWaitlistEntry(ctx context.Context, userID, eventID int64) (*models.WaitlistEntry, error)
+}
+
+// WaitlistLeaver интерфейс для выхода из листа ожидания
+type WaitlistLeaver interface {
+	LeaveWaitlist(ctx context.Context, userID, eventID int64) error
+}
+
+// JoinRequest запрос на запись в лист ожидания
+type JoinRequest struct {
+	Quantity int `json:"quantity" validate:"required,min=1,max=10" example:"2"`
+}
+
+// EntryResponse ответ с записью в листе ожидания
+type EntryResponse struct {
+	resp.Response
+	Entry *models.WaitlistEntry `json:"waitlist"`
+}
+
+// NewJoin возвращает хендлер записи в лист ожидания
+// @Summary Встать в лист ожидания
+// @Description Ставит в очередь на билеты распроданного мероприятия. Когда билеты возвращаются в продажу,
+// @Description они достаются строго первому в очереди: он получает удержание (status offered, booking_id) и
+// @Description уведомление; удержание нужно оплатить через POST /bookings/{id}/confirm до offer_expires_at
+// @Tags waitlist
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "ID мероприятия"
+// @Param request body JoinRequest true "Сколько билетов нужно"
+// @Success 201 {object} EntryResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response
+// @Failure 409 {object} resp.Response "Уже в очереди, уже есть бронь или билеты есть в продаже"
+// @Failure 500 {object} resp.Response
+// @Router /events/{id}/waitlist [post]
+func NewJoin(log *slog.Logger, joiner WaitlistJoiner) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.waitlist.Join"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid event id"))
+			return
+		}
+
+		var req JoinRequest
+		err = request.DecodeJSON(r, &req)
+		if errors.Is(err, io.EOF) {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeEmptyBody, "empty request body"))
+			return
+		}
+		if err != nil {
+			log.Info("failed to decode request", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidBody, "invalid request body"))
+			return
+		}
+
+		if err := request.Validate(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+			log.Info("validation failed", sl.Err(err))
+			resp.Render(w, r, http.StatusBadRequest, resp.ValidationError(validateErr))
+			return
+		}
+
+		entry, err := joiner.JoinWaitlist(r.Context(), userID, eventID, req.Quantity)
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("waitlist join rejected", sl.Err(err), slog.Int64("event_id", eventID))
+			} else {
+				log.Error("failed to join waitlist", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		log.Info("joined waitlist",
+			slog.Int64("event_id", eventID),
+			slog.Int64("waitlist_id", entry.ID),
+			slog.Int("position", entry.Position),
+		)
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, EntryResponse{Response: resp.OK(), Entry: entry})
+	}
+}
+
+// NewPosition возвращает хендлер места в листе ожидания
+// @Summary Место в листе ожидания
+// @Description Место в очереди или, если билеты уже предложены, удержание для оплаты
+// @Tags waitlist
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID мероприятия"
+// @Success 200 {object} EntryResponse
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response "Пользователь не в листе ожидания"
+// @Failure 500 {object} resp.Response
+// @Router /events/{id}/waitlist [get]
+func NewPosition(log *slog.Logger, getter WaitlistGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.waitlist.Position"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid event id"))
+			return
+		}
+
+		entry, err := getter.GetWaitlistEntry(r.Context(), userID, eventID)
+		if err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("waitlist entry not found", sl.Err(err), slog.Int64("event_id", eventID))
+			} else {
+				log.Error("failed to get waitlist entry", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		render.JSON(w, r, EntryResponse{Response: resp.OK(), Entry: entry})
+	}
+}
+
+// NewLeave возвращает хендлер выхода из листа ожидания
+// @Summary Покинуть лист ожидания
+// @Description Убирает из очереди. Уже предложенное удержание остается в бронях и отменяется через DELETE /bookings/{id}
+// @Tags waitlist
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID мероприятия"
+// @Success 200 {object} resp.Response
+// @Failure 400 {object} resp.Response
+// @Failure 401 {object} resp.Response
+// @Failure 404 {object} resp.Response "Пользователь не в листе ожидания"
+// @Failure 500 {object} resp.Response
+// @Router /events/{id}/waitlist [delete]
+func NewLeave(log *slog.Logger, leaver WaitlistLeaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.waitlist.Leave"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+			sl.TraceID(r.Context()),
+		)
+
+		userID, ok := authMiddleware.GetUserIDFromContext(r.Context())
+		if !ok {
+			log.Error("user_id not found in context")
+			resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "unauthorized"))
+			return
+		}
+
+		eventID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			resp.Render(w, r, http.StatusBadRequest, resp.Error(resp.CodeInvalidParameter, "invalid event id"))
+			return
+		}
+
+		if err := leaver.LeaveWaitlist(r.Context(), userID, eventID); err != nil {
+			if resp.IsKnownStorageError(err) {
+				log.Info("waitlist entry not deleted", sl.Err(err), slog.Int64("event_id", eventID))
+			} else {
+				log.Error("failed to leave waitlist", sl.Err(err))
+			}
+			resp.RenderStorageError(w, r, err)
+			return
+		}
+
+		log.Info("left waitlist", slog.Int64("event_id", eventID))
+		render.JSON(w, r, resp.OK())
+	}
+}
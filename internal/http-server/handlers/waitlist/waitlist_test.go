@@ -0,0 +1,263 @@
+package waitlist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+	"API/internal/http-server/handlers/waitlist/mocks"
+	authMiddleware "API/internal/http-server/middleware/auth"
+	resp "API/internal/lib/api/response"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+// newRequest создает запрос пользователя 42 с ID мероприятия в пути
+func newRequest(t *testing.T, method, id, body string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(method, "/", bytes.NewReader([]byte(body)))
+	require.NoError(t, err)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+
+	// Хэндлер стоит за JWTAuth, который кладет user_id в контекст
+	return req.WithContext(context.WithValue(ctx, authMiddleware.UserIDKey, int64(42)))
+}
+
+func TestJoinHandler(t *testing.T) {
+	cases := []struct {
+		name       string // Имя теста
+		body       string // Тело запроса
+		quantity   int    // Количество билетов, которое дойдет до стораджа
+		respCode   string // Указываем какой код ошибки хотим получить
+		respStatus int    // Ожидаемый HTTP статус
+		mockError  error  // Ошибка которую выдает mock
+		callsMock  bool   // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Success",
+			body:       `{"quantity": 2}`,
+			quantity:   2,
+			respStatus: http.StatusCreated,
+			callsMock:  true,
+		}, {
+			name:       "Empty Body",
+			respCode:   resp.CodeEmptyBody,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Too Many",
+			body:       `{"quantity": 11}`,
+			respCode:   resp.CodeValidationFailed,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Tickets Available",
+			body:       `{"quantity": 1}`,
+			quantity:   1,
+			respCode:   resp.CodeTicketsAvailable,
+			respStatus: http.StatusConflict,
+			mockError:  storage.ErrTicketsAvailable,
+			callsMock:  true,
+		}, {
+			name:       "Already Joined",
+			body:       `{"quantity": 1}`,
+			quantity:   1,
+			respCode:   resp.CodeWaitlistExists,
+			respStatus: http.StatusConflict,
+			mockError:  storage.ErrWaitlistExists,
+			callsMock:  true,
+		}, {
+			name:       "Has Booking",
+			body:       `{"quantity": 1}`,
+			quantity:   1,
+			respCode:   resp.CodeBookingExists,
+			respStatus: http.StatusConflict,
+			mockError:  storage.ErrBookingExists,
+			callsMock:  true,
+		}, {
+			name:       "No Event",
+			body:       `{"quantity": 1}`,
+			quantity:   1,
+			respCode:   resp.CodeEventNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrEventNotFound,
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			joinerMock := mocks.NewWaitlistJoiner(t)
+			if tc.callsMock {
+				var entry *models.WaitlistEntry
+				if tc.mockError == nil {
+					entry = &models.WaitlistEntry{ID: 1, EventID: 5, UserID: 42, Quantity: tc.quantity, Status: models.WaitlistWaiting, Position: 3}
+				}
+				joinerMock.On("JoinWaitlist", mock.Anything, int64(42), int64(5), tc.quantity).
+					Return(entry, tc.mockError).
+					Once()
+			}
+
+			rr := httptest.NewRecorder()
+			NewJoin(slogdiscard.NewDiscardLogger(), joinerMock).
+				ServeHTTP(rr, newRequest(t, http.MethodPost, "5", tc.body))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body EntryResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.respCode == "" {
+				require.Equal(t, models.WaitlistWaiting, body.Entry.Status)
+				require.Equal(t, 3, body.Entry.Position)
+			}
+		})
+	}
+}
+
+func TestPositionHandler(t *testing.T) {
+	bookingID := int64(100)
+	offerExpires := time.Now().Add(30 * time.Minute).UTC().Truncate(time.Second)
+
+	cases := []struct {
+		name       string                // Имя теста
+		id         string                // ID мероприятия в пути
+		entry      *models.WaitlistEntry // Запись, которую вернет mock
+		respCode   string                // Указываем какой код ошибки хотим получить
+		respStatus int                   // Ожидаемый HTTP статус
+		mockError  error                 // Ошибка которую выдает mock
+		callsMock  bool                  // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Waiting",
+			id:         "5",
+			entry:      &models.WaitlistEntry{ID: 1, EventID: 5, UserID: 42, Quantity: 2, Status: models.WaitlistWaiting, Position: 2},
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name: "Offered",
+			id:   "5",
+			entry: &models.WaitlistEntry{
+				ID: 1, EventID: 5, UserID: 42, Quantity: 2, Status: models.WaitlistOffered,
+				BookingID: &bookingID, OfferExpiresAt: &offerExpires,
+			},
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Invalid ID",
+			id:         "abc",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Not In Waitlist",
+			id:         "5",
+			respCode:   resp.CodeWaitlistNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrWaitlistNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Storage Error",
+			id:         "5",
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("database connection error"),
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			getterMock := mocks.NewWaitlistGetter(t)
+			if tc.callsMock {
+				getterMock.On("GetWaitlistEntry", mock.Anything, int64(42), int64(5)).
+					Return(tc.entry, tc.mockError).
+					Once()
+			}
+
+			rr := httptest.NewRecorder()
+			NewPosition(slogdiscard.NewDiscardLogger(), getterMock).
+				ServeHTTP(rr, newRequest(t, http.MethodGet, tc.id, ""))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body EntryResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+			if tc.entry != nil {
+				require.Equal(t, tc.entry.Status, body.Entry.Status)
+				require.Equal(t, tc.entry.Position, body.Entry.Position)
+				require.Equal(t, tc.entry.BookingID, body.Entry.BookingID)
+			}
+		})
+	}
+}
+
+func TestLeaveHandler(t *testing.T) {
+	cases := []struct {
+		name       string // Имя теста
+		id         string // ID мероприятия в пути
+		respCode   string // Указываем какой код ошибки хотим получить
+		respStatus int    // Ожидаемый HTTP статус
+		mockError  error  // Ошибка которую выдает mock
+		callsMock  bool   // Должен ли хэндлер дойти до стораджа
+	}{
+		{
+			name:       "Success",
+			id:         "5",
+			respStatus: http.StatusOK,
+			callsMock:  true,
+		}, {
+			name:       "Invalid ID",
+			id:         "abc",
+			respCode:   resp.CodeInvalidParameter,
+			respStatus: http.StatusBadRequest,
+		}, {
+			name:       "Not In Waitlist",
+			id:         "5",
+			respCode:   resp.CodeWaitlistNotFound,
+			respStatus: http.StatusNotFound,
+			mockError:  storage.ErrWaitlistNotFound,
+			callsMock:  true,
+		}, {
+			name:       "Storage Error",
+			id:         "5",
+			respCode:   resp.CodeInternal,
+			respStatus: http.StatusInternalServerError,
+			mockError:  errors.New("database connection error"),
+			callsMock:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			leaverMock := mocks.NewWaitlistLeaver(t)
+			if tc.callsMock {
+				leaverMock.On("LeaveWaitlist", mock.Anything, int64(42), int64(5)).
+					Return(tc.mockError).
+					Once()
+			}
+
+			rr := httptest.NewRecorder()
+			NewLeave(slogdiscard.NewDiscardLogger(), leaverMock).
+				ServeHTTP(rr, newRequest(t, http.MethodDelete, tc.id, ""))
+
+			require.Equal(t, tc.respStatus, rr.Code)
+
+			var body resp.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+			require.Equal(t, tc.respCode, body.Code)
+		})
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"net/http"
 	"strings"
 
-	"github.com/go-chi/render"
 	"golang.org/x/exp/slog"
 )
 
@@ -36,8 +35,7 @@ func JWTAuth(logger *slog.Logger, jwtManager *auth.JWTManager) func(next http.Ha
 
 			if authHeader == "" {
 				logger.Info("missing authorization header", slog.String("op", op))
-				w.WriteHeader(http.StatusUnauthorized)
-				render.JSON(w, r, resp.Error("authorization header is required"))
+				resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "authorization header is required"))
 				return
 			}
 
@@ -47,8 +45,7 @@ func JWTAuth(logger *slog.Logger, jwtManager *auth.JWTManager) func(next http.Ha
 
 			if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
 				logger.Info("invalid authorization header format", slog.String("op", op))
-				w.WriteHeader(http.StatusUnauthorized)
-				render.JSON(w, r, resp.Error("invalid authorization header format"))
+				resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeUnauthorized, "invalid authorization header format"))
 				return
 			}
 
@@ -60,8 +57,7 @@ func JWTAuth(logger *slog.Logger, jwtManager *auth.JWTManager) func(next http.Ha
 			if err != nil {
 				log.Printf("[v0] Token validation error: %v", err)
 				logger.Info("invalid token", slog.String("op", op), slog.String("error", err.Error()))
-				w.WriteHeader(http.StatusUnauthorized)
-				render.JSON(w, r, resp.Error("invalid or expired token"))
+				resp.Render(w, r, http.StatusUnauthorized, resp.Error(resp.CodeInvalidToken, "invalid or expired token"))
 				return
 			}
 
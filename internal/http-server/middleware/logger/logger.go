@@ -1,12 +1,12 @@
 package logger
 
 import (
+	"API/internal/lib/logger/sl"
 	"net/http"
 	"time"
 
-	"log/slog"
-
 	"github.com/go-chi/chi/middleware"
+	"golang.org/x/exp/slog"
 )
 
 //Его вообще не обязательно писать, можем обойтись и стандартным router.Use(middleware.Logger)
@@ -28,6 +28,7 @@ func New(log *slog.Logger) func(next http.Handler) http.Handler {
 				slog.String("remote_addr", r.RemoteAddr),
 				slog.String("user_agent", r.UserAgent()),
 				slog.String("request_id", middleware.GetReqID(r.Context())),
+				sl.TraceID(r.Context()),
 			)
 			// создаем обертку вокруг `http.ResponseWriter`
 			// для получения сведений об ответе
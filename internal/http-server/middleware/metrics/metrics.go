@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"API/internal/lib/metrics"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+)
+
+// unmatchedRoute метка для запросов, не попавших ни в один маршрут.
+// Сырой путь в метку не пишем, чтобы не раздувать кардинальность.
+const unmatchedRoute = "unmatched"
+
+// New создает middleware, считающий запросы и время их обработки
+// в разрезе шаблона маршрута chi и статуса ответа
+func New() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			start := time.Now()
+
+			metrics.HTTPRequestsInFlight.Inc()
+			defer func() {
+				metrics.HTTPRequestsInFlight.Dec()
+
+				// Шаблон маршрута известен только после того, как chi
+				// прошел по дереву роутов, поэтому читаем его в конце
+				route := unmatchedRoute
+				if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+					route = rctx.RoutePattern()
+				}
+
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+				labels := []string{r.Method, route, strconv.Itoa(status)}
+
+				metrics.HTTPRequests.WithLabelValues(labels...).Inc()
+				metrics.HTTPRequestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+			}()
+
+			next.ServeHTTP(ww, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"API/internal/lib/metrics"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	router := chi.NewRouter()
+	router.Use(New())
+	router.Route("/events", func(r chi.Router) {
+		r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+	})
+
+	cases := []struct {
+		name   string
+		path   string
+		route  string // Ожидаемая метка route
+		status string // Ожидаемая метка status
+	}{
+		{
+			name:   "Route pattern instead of raw path",
+			path:   "/events/42",
+			route:  "/events/{id}",
+			status: "404",
+		}, {
+			name:   "Unmatched route",
+			path:   "/unknown/path",
+			route:  unmatchedRoute,
+			status: "404",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			counter := metrics.HTTPRequests.WithLabelValues(http.MethodGet, tc.route, tc.status)
+			before := testutil.ToFloat64(counter)
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.Equal(t, before+1, testutil.ToFloat64(counter))
+		})
+	}
+}
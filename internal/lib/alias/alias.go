@@ -0,0 +1,147 @@
+package alias
+
+import (
+	"API/internal/config"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+)
+
+const (
+	// MinCustomLength и MaxCustomLength ограничения на алиас, заданный пользователем
+	MinCustomLength = 3
+	MaxCustomLength = 64
+
+	// maxReservedRetries сколько раз перегенерировать алиас, совпавший с занятым сервисом словом
+	maxReservedRetries = 10
+)
+
+var ErrInvalidAlphabet = errors.New("alias alphabet must contain at least 2 unique URL-safe characters")
+
+// Generator генерирует случайные алиасы из crypto/rand.
+// Длина растет, когда коллизии становятся частыми: см. Grow.
+type Generator struct {
+	alphabet  []byte
+	length    atomic.Int64
+	maxLength int64
+	attempts  int
+	reserved  func(string) bool
+}
+
+// NewGenerator создает генератор. reserved отсекает алиасы, совпадающие
+// с маршрутами сервиса; может быть nil.
+func NewGenerator(cfg config.AliasConfig, reserved func(string) bool) (*Generator, error) {
+	const op = "lib.alias.NewGenerator"
+
+	seen := make(map[byte]struct{}, len(cfg.Alphabet))
+	for i := 0; i < len(cfg.Alphabet); i++ {
+		c := cfg.Alphabet[i]
+		if _, dup := seen[c]; dup || !isAliasChar(c) {
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidAlphabet)
+		}
+		seen[c] = struct{}{}
+	}
+	if len(seen) < 2 {
+		return nil, fmt.Errorf("%s: %w", op, ErrInvalidAlphabet)
+	}
+
+	if cfg.Length <= 0 || cfg.MaxLength < cfg.Length {
+		return nil, fmt.Errorf("%s: invalid length %d..%d", op, cfg.Length, cfg.MaxLength)
+	}
+
+	if reserved == nil {
+		reserved = func(string) bool { return false }
+	}
+
+	g := &Generator{
+		alphabet:  []byte(cfg.Alphabet),
+		maxLength: int64(cfg.MaxLength),
+		attempts:  max(cfg.Attempts, 1),
+		reserved:  reserved,
+	}
+	g.length.Store(int64(cfg.Length))
+
+	return g, nil
+}
+
+// Attempts сколько раз пробовать сохранить сгенерированный алиас
+func (g *Generator) Attempts() int {
+	return g.attempts
+}
+
+// Length текущая длина генерируемых алиасов
+func (g *Generator) Length() int {
+	return int(g.length.Load())
+}
+
+// Generate возвращает случайный алиас текущей длины
+func (g *Generator) Generate() (string, error) {
+	for i := 0; i < maxReservedRetries; i++ {
+		alias, err := g.random(int(g.length.Load()))
+		if err != nil {
+			return "", err
+		}
+		if !g.reserved(alias) {
+			return alias, nil
+		}
+	}
+
+	return "", errors.New("lib.alias.Generate: too many reserved aliases generated")
+}
+
+// Grow увеличивает длину алиасов на один символ, если не достигнут максимум.
+// Вызывается, когда сгенерированные алиасы подряд оказываются заняты, то есть
+// пространство ключей текущей длины заполнено. Длина хранится в памяти процесса
+// и после рестарта начинается заново с config.AliasConfig.Length.
+func (g *Generator) Grow() (int, bool) {
+	for {
+		cur := g.length.Load()
+		if cur >= g.maxLength {
+			return int(cur), false
+		}
+		if g.length.CompareAndSwap(cur, cur+1) {
+			return int(cur + 1), true
+		}
+	}
+}
+
+func (g *Generator) random(length int) (string, error) {
+	// rand.Int дает равномерное распределение без смещения по модулю
+	n := big.NewInt(int64(len(g.alphabet)))
+
+	b := make([]byte, length)
+	for i := range b {
+		idx, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return "", fmt.Errorf("lib.alias.random: %w", err)
+		}
+		b[i] = g.alphabet[idx.Int64()]
+	}
+
+	return string(b), nil
+}
+
+// ValidCustom проверяет алиас, заданный пользователем: длина и допустимые символы.
+// Занятые сервисом слова проверяются отдельно через api.IsReserved.
+func ValidCustom(alias string) bool {
+	if len(alias) < MinCustomLength || len(alias) > MaxCustomLength {
+		return false
+	}
+	for i := 0; i < len(alias); i++ {
+		if !isAliasChar(alias[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isAliasChar символы, которые не требуют экранирования в пути
+func isAliasChar(c byte) bool {
+	return c >= 'a' && c <= 'z' ||
+		c >= 'A' && c <= 'Z' ||
+		c >= '0' && c <= '9' ||
+		c == '-' || c == '_'
+}
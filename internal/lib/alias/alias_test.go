@@ -0,0 +1,86 @@
+package alias
+
+import (
+	"API/internal/config"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() config.AliasConfig {
+	return config.AliasConfig{
+		Alphabet:  "abc",
+		Length:    4,
+		MaxLength: 5,
+		Attempts:  3,
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	g, err := NewGenerator(testConfig(), nil)
+	require.NoError(t, err)
+
+	seen := map[string]struct{}{}
+	for i := 0; i < 200; i++ {
+		a, err := g.Generate()
+		require.NoError(t, err)
+		require.Len(t, a, 4)
+		require.Empty(t, strings.Trim(a, "abc"))
+		seen[a] = struct{}{}
+	}
+	// 3^4 = 81 вариант, за 200 попыток должно выпасть большинство
+	require.Greater(t, len(seen), 40)
+}
+
+func TestGenerateSkipsReserved(t *testing.T) {
+	cfg := testConfig()
+	cfg.Alphabet = "abcd"
+	cfg.Length = 1
+
+	g, err := NewGenerator(cfg, func(s string) bool { return s == "a" })
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		a, err := g.Generate()
+		require.NoError(t, err)
+		require.NotEqual(t, "a", a)
+	}
+}
+
+func TestGrow(t *testing.T) {
+	g, err := NewGenerator(testConfig(), nil)
+	require.NoError(t, err)
+
+	length, grown := g.Grow()
+	require.True(t, grown)
+	require.Equal(t, 5, length)
+
+	length, grown = g.Grow()
+	require.False(t, grown)
+	require.Equal(t, 5, length)
+	require.Equal(t, 5, g.Length())
+}
+
+func TestNewGeneratorValidatesConfig(t *testing.T) {
+	for _, alphabet := range []string{"", "a", "aab", "ab/", "аб"} {
+		cfg := testConfig()
+		cfg.Alphabet = alphabet
+		_, err := NewGenerator(cfg, nil)
+		require.ErrorIs(t, err, ErrInvalidAlphabet, alphabet)
+	}
+
+	cfg := testConfig()
+	cfg.MaxLength = 2
+	_, err := NewGenerator(cfg, nil)
+	require.Error(t, err)
+}
+
+func TestValidCustom(t *testing.T) {
+	for _, a := range []string{"promo", "Summer_Sale-2025", "abc"} {
+		require.True(t, ValidCustom(a), a)
+	}
+	for _, a := range []string{"", "ab", "with space", "slash/alias", "кириллица", "dot.alias", strings.Repeat("a", 65)} {
+		require.False(t, ValidCustom(a), a)
+	}
+}
@@ -0,0 +1,41 @@
+// Package cursor кодирует курсоры keyset пагинации в непрозрачную
+// для клиента строку.
+package cursor
+
+import (
+	"API/internal/models"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalid = errors.New("invalid cursor")
+
+// Encode кодирует курсор в base64url от JSON. Для nil возвращает пустую строку.
+func Encode(c *models.Cursor) string {
+	if c == nil {
+		return ""
+	}
+
+	raw, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// Decode разбирает курсор и проверяет, что он выдан для сортировки order
+func Decode(s, order string) (*models.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+
+	var c models.Cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, ErrInvalid
+	}
+	if c.Order != order || c.ID <= 0 {
+		return nil, ErrInvalid
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,52 @@
+package cursor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"API/internal/models"
+)
+
+func TestRoundTrip(t *testing.T) {
+	key := 1500.5
+	c := &models.Cursor{
+		Order: models.SortPriceDesc,
+		Key:   &key,
+		Time:  time.Date(2025, 6, 1, 19, 30, 0, 123456000, time.UTC),
+		ID:    42,
+	}
+
+	encoded := Encode(c)
+	require.NotContains(t, encoded, "=")
+
+	decoded, err := Decode(encoded, models.SortPriceDesc)
+	require.NoError(t, err)
+	require.Equal(t, c.ID, decoded.ID)
+	require.Equal(t, *c.Key, *decoded.Key)
+	require.True(t, c.Time.Equal(decoded.Time))
+}
+
+func TestDecodeRejects(t *testing.T) {
+	valid := Encode(&models.Cursor{Order: models.SortDate, Time: time.Now(), ID: 1})
+
+	cases := map[string]struct {
+		raw   string
+		order string
+	}{
+		"Other Order": {raw: valid, order: models.SortPriceAsc},
+		"Not Base64":  {raw: "%%%", order: models.SortDate},
+		"Not JSON":    {raw: "bm90LWpzb24", order: models.SortDate},
+		"No ID":       {raw: Encode(&models.Cursor{Order: models.SortDate}), order: models.SortDate},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Decode(tc.raw, tc.order)
+			require.ErrorIs(t, err, ErrInvalid)
+		})
+	}
+
+	require.Empty(t, Encode(nil))
+}
@@ -0,0 +1,70 @@
+package request
+
+import (
+	"API/internal/models"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// EventFilter разбирает фильтры мероприятий: category (несколько раз или
+// через запятую), creator_id, only_available, upcoming (по умолчанию true)
+// и include_past. Текст ошибки можно отдавать клиенту.
+func EventFilter(q url.Values) (models.EventFilter, error) {
+	filter := models.DefaultEventFilter()
+
+	for _, value := range q["category"] {
+		for _, category := range strings.Split(value, ",") {
+			category = strings.TrimSpace(category)
+			if category == "" {
+				continue
+			}
+			if !models.IsEventCategory(category) {
+				return filter, errors.New("unknown category: " + category)
+			}
+			filter.Categories = append(filter.Categories, category)
+		}
+	}
+
+	ids := []struct {
+		name  string
+		value **int64
+	}{
+		{"creator_id", &filter.CreatorID},
+		{"venue_id", &filter.VenueID},
+	}
+	for _, p := range ids {
+		raw := q.Get(p.name)
+		if raw == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id <= 0 {
+			return filter, errors.New("invalid " + p.name)
+		}
+		*p.value = &id
+	}
+
+	flags := []struct {
+		name  string
+		value *bool
+	}{
+		{"only_available", &filter.OnlyAvailable},
+		{"upcoming", &filter.Upcoming},
+		{"include_past", &filter.IncludePast},
+	}
+	for _, f := range flags {
+		raw := q.Get(f.name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, errors.New(f.name + " must be true or false")
+		}
+		*f.value = v
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,107 @@
+package request
+
+import (
+	"API/internal/lib/api/cursor"
+	"API/internal/models"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel"
+)
+
+const tracerName = "API/internal/lib/api/request"
+
+// validate общий валидатор: он кеширует разбор структур, поэтому
+// создавать его на каждый запрос не нужно
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+
+	// В ошибках используем имена полей из json тегов,
+	// чтобы клиент мог сопоставить их с полями запроса
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" || name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	// Допустимые значения берутся из models, чтобы не дублировать их в тегах
+	_ = v.RegisterValidation("event_category", func(fl validator.FieldLevel) bool {
+		return models.IsEventCategory(fl.Field().String())
+	})
+	_ = v.RegisterValidation("event_language", func(fl validator.FieldLevel) bool {
+		return models.IsEventLanguage(fl.Field().String())
+	})
+
+	return v
+}
+
+// DecodeJSON декодирует тело запроса в v.
+// Декодирование выделено в отдельный span, чтобы в трейсе было видно,
+// сколько времени ушло на разбор тела, а сколько на работу с БД.
+func DecodeJSON(r *http.Request, v interface{}) error {
+	_, span := otel.Tracer(tracerName).Start(r.Context(), "json.decode")
+	defer span.End()
+
+	return render.DecodeJSON(r.Body, v)
+}
+
+// Validate проверяет структуру по тегам validate.
+// Ошибки полей возвращаются как validator.ValidationErrors.
+func Validate(v interface{}) error {
+	return validate.Struct(v)
+}
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Page разбирает параметры limit, offset и cursor. Некорректные limit
+// и offset заменяются значениями по умолчанию; курсор, выданный для другой
+// сортировки order, возвращает cursor.ErrInvalid.
+func Page(r *http.Request, order string) (models.Page, error) {
+	page := models.Page{Limit: DefaultLimit}
+
+	q := r.URL.Query()
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 && l <= MaxLimit {
+		page.Limit = l
+	}
+	if o, err := strconv.Atoi(q.Get("offset")); err == nil && o >= 0 {
+		page.Offset = o
+	}
+
+	if raw := q.Get("cursor"); raw != "" {
+		after, err := cursor.Decode(raw, order)
+		if err != nil {
+			return page, err
+		}
+		page.After = after
+	}
+
+	return page, nil
+}
+
+// PageOrAll как Page, но без limit и cursor возвращает страницу без лимита.
+// Нужен спискам, которые до пагинации отдавались целиком: старые клиенты
+// не передают параметры и должны по-прежнему получать все записи.
+func PageOrAll(r *http.Request, order string) (models.Page, error) {
+	page, err := Page(r, order)
+	if err != nil {
+		return page, err
+	}
+
+	q := r.URL.Query()
+	if q.Get("limit") == "" && q.Get("cursor") == "" {
+		page.Limit = 0
+	}
+
+	return page, nil
+}
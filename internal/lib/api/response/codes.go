@@ -0,0 +1,77 @@
+package response
+
+// Машиночитаемые коды ошибок. Клиенты должны опираться на них,
+// а не на текст ошибки: коды стабильны, текст может меняться.
+const (
+	// Общие ошибки запроса
+	CodeEmptyBody        = "EMPTY_BODY"
+	CodeInvalidBody      = "INVALID_BODY"
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeInvalidParameter = "INVALID_PARAMETER"
+	CodeInternal         = "INTERNAL_ERROR"
+	CodeNotReady         = "SERVICE_NOT_READY"
+
+	// Аутентификация
+	CodeUnauthorized       = "UNAUTHORIZED"
+	CodeInvalidToken       = "INVALID_TOKEN"
+	CodeInvalidCredentials = "INVALID_CREDENTIALS"
+
+	// Короткие ссылки
+	CodeURLNotFound   = "URL_NOT_FOUND"
+	CodeURLExists     = "URL_ALREADY_EXISTS"
+	CodeURLExpired    = "URL_EXPIRED"
+	CodeAliasReserved = "ALIAS_RESERVED"
+	CodeAliasInvalid  = "ALIAS_INVALID"
+	CodeURLNotAllowed = "URL_NOT_ALLOWED"
+	CodeBatchTooLarge = "BATCH_TOO_LARGE"
+	CodeBatchRejected = "BATCH_REJECTED"
+
+	// Пользователи
+	CodeUserNotFound = "USER_NOT_FOUND"
+	CodeUserExists   = "USER_ALREADY_EXISTS"
+
+	// Мероприятия и бронирования
+	CodeEventNotFound       = "EVENT_NOT_FOUND"
+	CodeBookingNotFound     = "BOOKING_NOT_FOUND"
+	CodeBookingExists       = "BOOKING_ALREADY_EXISTS"
+	CodeBookingCancelled    = "BOOKING_ALREADY_CANCELLED"
+	CodeBookingNotPending   = "BOOKING_NOT_PENDING"
+	CodeBookingNotConfirmed = "BOOKING_NOT_CONFIRMED"
+	CodeHoldExpired         = "HOLD_EXPIRED"
+	CodeNoTickets           = "NO_TICKETS_AVAILABLE"
+	CodeInsufficientBalance = "INSUFFICIENT_BALANCE"
+	CodeCapacityBelowSold   = "CAPACITY_BELOW_SOLD"
+	CodeSeatedCapacity      = "CAPACITY_FOLLOWS_SEAT_MAP"
+
+	// Передача броней
+	CodeTransferNotFound   = "TRANSFER_NOT_FOUND"
+	CodeTransferExists     = "TRANSFER_ALREADY_PENDING"
+	CodeTransferNotPending = "TRANSFER_NOT_PENDING"
+	CodeTransferToSelf     = "TRANSFER_TO_SELF"
+	CodeTransferQuantity   = "TRANSFER_QUANTITY_EXCEEDED"
+	CodeNotTransferable    = "BOOKING_NOT_TRANSFERABLE"
+
+	// Проверка билетов на входе
+	CodeTicketInvalid = "TICKET_INVALID"
+	CodeTicketRevoked = "TICKET_REVOKED"
+
+	// Лист ожидания
+	CodeWaitlistNotFound = "WAITLIST_ENTRY_NOT_FOUND"
+	CodeWaitlistExists   = "WAITLIST_ALREADY_JOINED"
+	CodeTicketsAvailable = "TICKETS_AVAILABLE"
+
+	// Места
+	CodeSeatMapNotFound = "SEAT_MAP_NOT_FOUND"
+	CodeSeatPrices      = "SEAT_PRICES_MISMATCH"
+	CodeSeatsRequired   = "SEATS_REQUIRED"
+	CodeSeatNotFound    = "SEAT_NOT_FOUND"
+	CodeSeatTaken       = "SEAT_TAKEN"
+
+	// Площадки
+	CodeVenueNotFound = "VENUE_NOT_FOUND"
+	CodeVenueExists   = "VENUE_ALREADY_EXISTS"
+
+	// Сохраненные поиски
+	CodeSavedSearchNotFound = "SAVED_SEARCH_NOT_FOUND"
+	CodeSavedSearchLimit    = "SAVED_SEARCH_LIMIT"
+)
@@ -0,0 +1,77 @@
+package response
+
+import (
+	storage "API/internal/Storage"
+	"errors"
+	"net/http"
+)
+
+// storageError описывает, как ошибка хранилища отдается клиенту
+type storageError struct {
+	err    error
+	status int
+	code   string
+	msg    string
+}
+
+// storageErrors единое сопоставление storage.Err* с HTTP статусом и кодом
+var storageErrors = []storageError{
+	{storage.ErrURLNotFound, http.StatusNotFound, CodeURLNotFound, "url not found"},
+	{storage.ErrURLExists, http.StatusConflict, CodeURLExists, "alias already exists"},
+	{storage.ErrURLExpired, http.StatusGone, CodeURLExpired, "url expired"},
+	{storage.ErrBatchRejected, http.StatusUnprocessableEntity, CodeBatchRejected, "batch rejected"},
+	{storage.ErrUserNotFound, http.StatusNotFound, CodeUserNotFound, "user not found"},
+	{storage.ErrUserExists, http.StatusConflict, CodeUserExists, "user with this email already exists"},
+	{storage.ErrEventNotFound, http.StatusNotFound, CodeEventNotFound, "event not found"},
+	{storage.ErrCapacityBelowSold, http.StatusConflict, CodeCapacityBelowSold, "capacity cannot be lower than tickets already sold"},
+	{storage.ErrSeatedCapacity, http.StatusConflict, CodeSeatedCapacity, "capacity of a seated event follows its seat map"},
+	{storage.ErrBookingNotFound, http.StatusNotFound, CodeBookingNotFound, "booking not found"},
+	{storage.ErrBookingExists, http.StatusConflict, CodeBookingExists, "you already have a booking for this event"},
+	{storage.ErrBookingCancelled, http.StatusConflict, CodeBookingCancelled, "booking already cancelled"},
+	{storage.ErrBookingNotPending, http.StatusConflict, CodeBookingNotPending, "booking is not a pending hold"},
+	{storage.ErrHoldExpired, http.StatusGone, CodeHoldExpired, "hold expired, tickets were released"},
+	{storage.ErrTransferNotFound, http.StatusNotFound, CodeTransferNotFound, "transfer not found"},
+	{storage.ErrTransferExists, http.StatusConflict, CodeTransferExists, "booking already has a pending transfer"},
+	{storage.ErrTransferNotPending, http.StatusConflict, CodeTransferNotPending, "transfer is already resolved"},
+	{storage.ErrTransferToSelf, http.StatusUnprocessableEntity, CodeTransferToSelf, "cannot transfer a booking to yourself"},
+	{storage.ErrTransferQuantity, http.StatusUnprocessableEntity, CodeTransferQuantity, "cannot transfer more tickets than the booking has"},
+	{storage.ErrNotTransferable, http.StatusConflict, CodeNotTransferable, "only confirmed bookings can be transferred"},
+	{storage.ErrWaitlistNotFound, http.StatusNotFound, CodeWaitlistNotFound, "you are not on the waitlist for this event"},
+	{storage.ErrWaitlistExists, http.StatusConflict, CodeWaitlistExists, "you are already on the waitlist for this event"},
+	{storage.ErrTicketsAvailable, http.StatusConflict, CodeTicketsAvailable, "enough tickets are available, book them instead"},
+	{storage.ErrNoTickets, http.StatusUnprocessableEntity, CodeNoTickets, "not enough available tickets"},
+	{storage.ErrInsufficientBalance, http.StatusUnprocessableEntity, CodeInsufficientBalance, "insufficient balance"},
+	{storage.ErrSeatMapNotFound, http.StatusNotFound, CodeSeatMapNotFound, "seat map not found"},
+	{storage.ErrSeatPrices, http.StatusUnprocessableEntity, CodeSeatPrices, "seat_prices must set a price for every price category of the seat map"},
+	{storage.ErrSeatsRequired, http.StatusUnprocessableEntity, CodeSeatsRequired, "event has assigned seats, choose seat_ids"},
+	{storage.ErrSeatNotFound, http.StatusNotFound, CodeSeatNotFound, "seat not found for this event"},
+	{storage.ErrSeatTaken, http.StatusConflict, CodeSeatTaken, "seat already taken"},
+	{storage.ErrVenueNotFound, http.StatusNotFound, CodeVenueNotFound, "venue not found"},
+	{storage.ErrVenueExists, http.StatusConflict, CodeVenueExists, "venue with this name already exists"},
+	{storage.ErrSavedSearchNotFound, http.StatusNotFound, CodeSavedSearchNotFound, "saved search not found"},
+	{storage.ErrSavedSearchLimit, http.StatusUnprocessableEntity, CodeSavedSearchLimit, "saved searches limit reached"},
+}
+
+// StorageError возвращает HTTP статус и ответ для ошибки хранилища.
+// Неизвестные ошибки считаются внутренними, их текст клиенту не отдается.
+func StorageError(err error) (int, Response) {
+	for _, se := range storageErrors {
+		if errors.Is(err, se.err) {
+			return se.status, Error(se.code, se.msg)
+		}
+	}
+
+	return http.StatusInternalServerError, Error(CodeInternal, "internal error")
+}
+
+// IsKnownStorageError сообщает, есть ли для ошибки отдельный код.
+// Нужен хендлерам, чтобы не логировать штатные ситуации как ошибки.
+func IsKnownStorageError(err error) bool {
+	for _, se := range storageErrors {
+		if errors.Is(err, se.err) {
+			return true
+		}
+	}
+
+	return false
+}
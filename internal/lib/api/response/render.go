@@ -0,0 +1,64 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/render"
+)
+
+// ContentTypeProblem тип ответа по RFC 7807
+const ContentTypeProblem = "application/problem+json"
+
+// problemTypePrefix префикс URI типа проблемы, к нему дописывается код ошибки
+const problemTypePrefix = "urn:api:error:"
+
+// Problem ответ с ошибкой в формате RFC 7807
+// @Description Ошибка в формате application/problem+json (RFC 7807)
+type Problem struct {
+	Type     string       `json:"type" example:"urn:api:error:EVENT_NOT_FOUND"`
+	Title    string       `json:"title" example:"Not Found"`
+	Status   int          `json:"status" example:"404"`
+	Detail   string       `json:"detail,omitempty" example:"event not found"`
+	Instance string       `json:"instance,omitempty" example:"/events/42"`
+	Code     string       `json:"code" example:"EVENT_NOT_FOUND"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// Render отдает ответ с ошибкой и заданным статусом.
+// Если клиент запросил application/problem+json, ответ отдается по RFC 7807,
+// иначе в обычном формате Response.
+func Render(w http.ResponseWriter, r *http.Request, status int, response Response) {
+	if wantsProblem(r) {
+		problem := Problem{
+			Type:     problemTypePrefix + response.Code,
+			Title:    http.StatusText(status),
+			Status:   status,
+			Detail:   response.Error,
+			Instance: r.URL.Path,
+			Code:     response.Code,
+			Errors:   response.Details,
+		}
+
+		w.Header().Set("Content-Type", ContentTypeProblem)
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(problem)
+		return
+	}
+
+	// render.Status выставляет код до записи тела, поэтому Content-Type
+	// успевает попасть в заголовки, в отличие от w.WriteHeader перед render.JSON
+	render.Status(r, status)
+	render.JSON(w, r, response)
+}
+
+// RenderStorageError отдает ответ для ошибки хранилища по единому сопоставлению
+func RenderStorageError(w http.ResponseWriter, r *http.Request, err error) {
+	status, response := StorageError(err)
+	Render(w, r, status, response)
+}
+
+func wantsProblem(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), ContentTypeProblem)
+}
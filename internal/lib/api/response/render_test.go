@@ -0,0 +1,95 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storage "API/internal/Storage"
+)
+
+func TestStorageError(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "Wrapped known error",
+			err:        fmt.Errorf("storage.postgres.CreateBooking: %w", storage.ErrInsufficientBalance),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   CodeInsufficientBalance,
+		}, {
+			name:       "Not found",
+			err:        storage.ErrEventNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   CodeEventNotFound,
+		}, {
+			name:       "Seats required",
+			err:        fmt.Errorf("storage.postgres.CreateBooking: %w", storage.ErrSeatsRequired),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   CodeSeatsRequired,
+		}, {
+			name:       "Seat taken",
+			err:        fmt.Errorf("storage.postgres.CreateSeatBooking: %w", storage.ErrSeatTaken),
+			wantStatus: http.StatusConflict,
+			wantCode:   CodeSeatTaken,
+		}, {
+			name:       "Unknown error is internal",
+			err:        errors.New("connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   CodeInternal,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, response := StorageError(tc.err)
+			require.Equal(t, tc.wantStatus, status)
+			require.Equal(t, tc.wantCode, response.Code)
+			require.Equal(t, StatusError, response.Status)
+		})
+	}
+}
+
+func TestRenderProblem(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events/42", nil)
+	req.Header.Set("Accept", ContentTypeProblem)
+	rr := httptest.NewRecorder()
+
+	Render(rr, req, http.StatusNotFound, Error(CodeEventNotFound, "event not found"))
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	require.Equal(t, ContentTypeProblem, rr.Header().Get("Content-Type"))
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	require.Equal(t, Problem{
+		Type:     problemTypePrefix + CodeEventNotFound,
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Detail:   "event not found",
+		Instance: "/events/42",
+		Code:     CodeEventNotFound,
+	}, problem)
+}
+
+func TestRenderJSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/events/42", nil)
+	rr := httptest.NewRecorder()
+
+	Render(rr, req, http.StatusNotFound, Error(CodeEventNotFound, "event not found"))
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	require.Contains(t, rr.Header().Get("Content-Type"), "application/json")
+
+	var response Response
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Equal(t, CodeEventNotFound, response.Code)
+}
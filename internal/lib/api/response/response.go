@@ -1,6 +1,7 @@
 package response
 
 import (
+	"API/internal/models"
 	"fmt"
 	"strings"
 
@@ -10,8 +11,19 @@ import (
 // Response базовая структура ответа
 // @Description Базовый ответ API
 type Response struct {
-	Status string `json:"status" example:"OK"`
-	Error  string `json:"error,omitempty" example:"error message"`
+	Status  string       `json:"status" example:"OK"`
+	Code    string       `json:"code,omitempty" example:"EVENT_NOT_FOUND"`
+	Error   string       `json:"error,omitempty" example:"error message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// FieldError описывает ошибку валидации одного поля
+// @Description Ошибка валидации поля
+type FieldError struct {
+	Field   string `json:"field" example:"quantity"`
+	Rule    string `json:"rule" example:"max"`
+	Param   string `json:"param,omitempty" example:"10"`
+	Message string `json:"message" example:"field quantity must be at most 10"`
 }
 
 const (
@@ -19,10 +31,11 @@ const (
 	StatusError = "Error"
 )
 
-// Error создает ответ с ошибкой
-func Error(msg string) Response {
+// Error создает ответ с ошибкой и машиночитаемым кодом
+func Error(code, msg string) Response {
 	return Response{
 		Status: StatusError,
+		Code:   code,
 		Error:  msg,
 	}
 }
@@ -39,29 +52,60 @@ func ValidationError(errs validator.ValidationErrors) Response {
 	return ValidErrors(errs)
 }
 
-// ValidErrors создает ответ с ошибками валидации
+// ValidErrors создает ответ с ошибками валидации.
+// Кроме общего сообщения в Details кладутся ошибки по каждому полю.
 func ValidErrors(errs validator.ValidationErrors) Response {
 	var errMsgs []string
+	details := make([]FieldError, 0, len(errs))
 
 	for _, err := range errs {
+		var msg string
 		switch err.ActualTag() {
 		case "required":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is required", err.Field()))
+			msg = fmt.Sprintf("field %s is required", err.Field())
 		case "url":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not a valid URL", err.Field()))
+			msg = fmt.Sprintf("field %s is not a valid URL", err.Field())
 		case "min":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s must be at least %s characters", err.Field(), err.Param()))
+			msg = fmt.Sprintf("field %s must be at least %s", err.Field(), err.Param())
 		case "max":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s must be at most %s characters", err.Field(), err.Param()))
+			msg = fmt.Sprintf("field %s must be at most %s", err.Field(), err.Param())
+		case "gt":
+			msg = fmt.Sprintf("field %s must be greater than %s", err.Field(), err.Param())
+		case "gte":
+			msg = fmt.Sprintf("field %s must be greater than or equal to %s", err.Field(), err.Param())
+		case "oneof":
+			msg = fmt.Sprintf("field %s must be one of: %s", err.Field(), err.Param())
+		case "event_category":
+			msg = fmt.Sprintf("field %s must be one of: %s", err.Field(), strings.Join(models.EventCategoryCodes(), " "))
+		case "event_language":
+			msg = fmt.Sprintf("field %s must be one of: %s", err.Field(), strings.Join(models.EventLanguages, " "))
 		case "email":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s must be a valid email", err.Field()))
+			msg = fmt.Sprintf("field %s must be a valid email", err.Field())
+		case "latitude", "longitude":
+			msg = fmt.Sprintf("field %s must be a valid %s", err.Field(), err.ActualTag())
+		case "required_with":
+			msg = fmt.Sprintf("field %s is required with %s", err.Field(), err.Param())
+		case "required_without":
+			msg = fmt.Sprintf("field %s is required without %s", err.Field(), err.Param())
+		case "excluded_with":
+			msg = fmt.Sprintf("field %s cannot be used with %s", err.Field(), err.Param())
 		default:
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not valid", err.Field()))
+			msg = fmt.Sprintf("field %s is not valid", err.Field())
 		}
+
+		errMsgs = append(errMsgs, msg)
+		details = append(details, FieldError{
+			Field:   err.Field(),
+			Rule:    err.ActualTag(),
+			Param:   err.Param(),
+			Message: msg,
+		})
 	}
 
 	return Response{
-		Status: StatusError,
-		Error:  strings.Join(errMsgs, ", "),
+		Status:  StatusError,
+		Code:    CodeValidationFailed,
+		Error:   strings.Join(errMsgs, ", "),
+		Details: details,
 	}
 }
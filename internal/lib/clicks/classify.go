@@ -0,0 +1,89 @@
+package clicks
+
+import (
+	"API/internal/models"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	referrerDirect = "direct"
+	regionUnknown  = "unknown"
+)
+
+// botMarkers подстроки User-Agent поисковых роботов и превью мессенджеров
+var botMarkers = []string{
+	"bot", "crawler", "spider", "slurp", "preview", "facebookexternalhit",
+	"curl", "wget", "python-requests", "go-http-client",
+}
+
+// FromRequest собирает переход из запроса. Сырые User-Agent и Referer не
+// сохраняются: только класс клиента и хост источника.
+func FromRequest(r *http.Request, alias, regionHeader string) models.Click {
+	return models.Click{
+		Alias:     alias,
+		ClickedAt: time.Now().UTC(),
+		Referrer:  ReferrerHost(r.Referer()),
+		UserAgent: ClassifyUserAgent(r.UserAgent()),
+		Region:    Region(r.Header.Get(regionHeader)),
+	}
+}
+
+// ClassifyUserAgent относит User-Agent к одному из классов models.UserAgent*
+func ClassifyUserAgent(ua string) string {
+	if ua == "" {
+		return models.UserAgentUnknown
+	}
+
+	ua = strings.ToLower(ua)
+
+	for _, marker := range botMarkers {
+		if strings.Contains(ua, marker) {
+			return models.UserAgentBot
+		}
+	}
+
+	switch {
+	case strings.Contains(ua, "ipad") || strings.Contains(ua, "tablet") ||
+		(strings.Contains(ua, "android") && !strings.Contains(ua, "mobile")):
+		return models.UserAgentTablet
+	case strings.Contains(ua, "mobi") || strings.Contains(ua, "iphone"):
+		return models.UserAgentMobile
+	case strings.Contains(ua, "windows") || strings.Contains(ua, "macintosh") ||
+		strings.Contains(ua, "x11") || strings.Contains(ua, "cros"):
+		return models.UserAgentDesktop
+	}
+
+	return models.UserAgentUnknown
+}
+
+// ReferrerHost возвращает хост источника без www или "direct"
+func ReferrerHost(referer string) string {
+	if referer == "" {
+		return referrerDirect
+	}
+
+	u, err := url.Parse(referer)
+	if err != nil || u.Hostname() == "" {
+		return referrerDirect
+	}
+
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// Region возвращает двухбуквенный код страны или "unknown"
+func Region(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 2 || code == "XX" {
+		return regionUnknown
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return regionUnknown
+		}
+	}
+
+	return code
+}
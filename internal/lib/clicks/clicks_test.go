@@ -0,0 +1,125 @@
+package clicks
+
+import (
+	"API/internal/config"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type saverStub struct {
+	mu      sync.Mutex
+	batches [][]models.Click
+}
+
+func (s *saverStub) SaveClicks(_ context.Context, clicks []models.Click) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, append([]models.Click(nil), clicks...))
+	return nil
+}
+
+func (s *saverStub) total() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, b := range s.batches {
+		n += len(b)
+	}
+	return n
+}
+
+func TestClassifyUserAgent(t *testing.T) {
+	cases := map[string]string{
+		"": models.UserAgentUnknown,
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64)":              models.UserAgentDesktop,
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0)":               models.UserAgentMobile,
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8) Mobile Safari": models.UserAgentMobile,
+		"Mozilla/5.0 (iPad; CPU OS 17_0)":                        models.UserAgentTablet,
+		"Mozilla/5.0 (Linux; Android 13; SM-X200)":               models.UserAgentTablet,
+		"TelegramBot (like TwitterBot)":                          models.UserAgentBot,
+		"curl/8.5.0":                                             models.UserAgentBot,
+	}
+
+	for ua, want := range cases {
+		require.Equal(t, want, ClassifyUserAgent(ua), ua)
+	}
+}
+
+func TestReferrerAndRegion(t *testing.T) {
+	require.Equal(t, "direct", ReferrerHost(""))
+	require.Equal(t, "direct", ReferrerHost("not a url"))
+	require.Equal(t, "t.me", ReferrerHost("https://t.me/some/post"))
+	require.Equal(t, "example.com", ReferrerHost("https://WWW.Example.com/page?q=1"))
+
+	require.Equal(t, "RU", Region("ru"))
+	require.Equal(t, "unknown", Region(""))
+	require.Equal(t, "unknown", Region("XX"))
+	require.Equal(t, "unknown", Region("R1"))
+}
+
+func TestRecorderFlushesOnClose(t *testing.T) {
+	saver := &saverStub{}
+	rec := NewRecorder(slogdiscard.NewDiscardLogger(), saver, config.AnalyticsConfig{
+		QueueSize:     10,
+		BatchSize:     3,
+		FlushInterval: time.Hour,
+		RegionHeader:  "CF-IPCountry",
+	})
+	go rec.Run()
+
+	for i := 0; i < 5; i++ {
+		req := httptest.NewRequest("GET", "/promo", nil)
+		req.Header.Set("CF-IPCountry", "de")
+		rec.Record(req, "promo")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, rec.Close(ctx))
+
+	require.Equal(t, 5, saver.total())
+	require.Equal(t, "DE", saver.batches[0][0].Region)
+}
+
+func TestRecorderDropsWhenQueueFull(t *testing.T) {
+	saver := &saverStub{}
+	rec := NewRecorder(slogdiscard.NewDiscardLogger(), saver, config.AnalyticsConfig{
+		QueueSize:     2,
+		BatchSize:     10,
+		FlushInterval: time.Hour,
+	})
+
+	// Run еще не запущен, поэтому очередь никто не читает
+	for i := 0; i < 5; i++ {
+		rec.Record(httptest.NewRequest("GET", "/promo", nil), "promo")
+	}
+
+	go rec.Run()
+	require.NoError(t, rec.Close(context.Background()))
+	require.Equal(t, 2, saver.total())
+}
+
+func TestRecorderDefaultFlushInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		saver := &saverStub{}
+		rec := NewRecorder(slogdiscard.NewDiscardLogger(), saver, config.AnalyticsConfig{
+			QueueSize:     1,
+			BatchSize:     10,
+			FlushInterval: interval,
+		})
+		require.Equal(t, defaultFlushInterval, rec.flushInterval)
+
+		// С неположительным интервалом time.NewTicker паникует
+		go rec.Run()
+		rec.Record(httptest.NewRequest("GET", "/promo", nil), "promo")
+		require.NoError(t, rec.Close(context.Background()))
+		require.Equal(t, 1, saver.total())
+	}
+}
@@ -0,0 +1,144 @@
+package clicks
+
+import (
+	"API/internal/config"
+	"API/internal/lib/logger/sl"
+	"API/internal/lib/metrics"
+	"API/internal/models"
+	"context"
+	"net/http"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+const (
+	// flushTimeout ограничивает запись одного батча
+	flushTimeout = 5 * time.Second
+	// defaultFlushInterval используется, если flush_interval не задан или не положительный
+	defaultFlushInterval = 2 * time.Second
+)
+
+// ClickSaver интерфейс для записи переходов
+type ClickSaver interface {
+	SaveClicks(ctx context.Context, clicks []models.Click) error
+}
+
+// Recorder пишет переходы в базу в фоне. Редирект только кладет
+// переход в буферизованный канал и не ждет базу.
+type Recorder struct {
+	log           *slog.Logger
+	saver         ClickSaver
+	queue         chan models.Click
+	batchSize     int
+	flushInterval time.Duration
+	regionHeader  string
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewRecorder создает Recorder. Запись начинается после вызова Run.
+func NewRecorder(log *slog.Logger, saver ClickSaver, cfg config.AnalyticsConfig) *Recorder {
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	// time.NewTicker паникует на неположительном интервале
+	flushInterval := cfg.FlushInterval
+	if flushInterval <= 0 {
+		log.Warn("invalid analytics flush interval, using default",
+			slog.Duration("flush_interval", cfg.FlushInterval),
+			slog.Duration("default", defaultFlushInterval),
+		)
+		flushInterval = defaultFlushInterval
+	}
+
+	return &Recorder{
+		log:           log.With(slog.String("component", "clicks.Recorder")),
+		saver:         saver,
+		queue:         make(chan models.Click, cfg.QueueSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		regionHeader:  cfg.RegionHeader,
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+// Record ставит переход в очередь. Если очередь заполнена, переход
+// отбрасывается: потерять строчку статистики лучше, чем задержать редирект.
+func (rec *Recorder) Record(r *http.Request, alias string) {
+	select {
+	case rec.queue <- FromRequest(r, alias, rec.regionHeader):
+	default:
+		metrics.ClicksDropped.WithLabelValues("queue_full").Inc()
+	}
+}
+
+// Run записывает переходы батчами до вызова Close
+func (rec *Recorder) Run() {
+	defer close(rec.done)
+
+	ticker := time.NewTicker(rec.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]models.Click, 0, rec.batchSize)
+
+	for {
+		select {
+		case click := <-rec.queue:
+			batch = append(batch, click)
+			if len(batch) >= rec.batchSize {
+				batch = rec.flush(batch)
+			}
+		case <-ticker.C:
+			batch = rec.flush(batch)
+		case <-rec.stop:
+			// Дописываем все, что успели поставить в очередь
+			for {
+				select {
+				case click := <-rec.queue:
+					batch = append(batch, click)
+					if len(batch) >= rec.batchSize {
+						batch = rec.flush(batch)
+					}
+				default:
+					rec.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// Close останавливает Run и ждет записи оставшихся переходов.
+// Вызывать после остановки HTTP сервера.
+func (rec *Recorder) Close(ctx context.Context) error {
+	close(rec.stop)
+
+	select {
+	case <-rec.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (rec *Recorder) flush(batch []models.Click) []models.Click {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	if err := rec.saver.SaveClicks(ctx, batch); err != nil {
+		rec.log.Error("failed to save clicks", sl.Err(err), slog.Int("count", len(batch)))
+		metrics.ClicksDropped.WithLabelValues("storage_error").Add(float64(len(batch)))
+	} else {
+		metrics.ClicksRecorded.Add(float64(len(batch)))
+	}
+
+	return batch[:0]
+}
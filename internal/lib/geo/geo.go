@@ -0,0 +1,76 @@
+package geo
+
+import "math"
+
+// EarthRadiusKm средний радиус Земли
+const EarthRadiusKm = 6371.0
+
+// Box прямоугольник широт и долгот, в который попадает круг заданного
+// радиуса. Если круг пересекает 180-й меридиан, MinLng > MaxLng и
+// подходят долготы вне отрезка [MaxLng, MinLng].
+type Box struct {
+	MinLat, MaxLat float64
+	MinLng, MaxLng float64
+}
+
+// CrossesAntimeridian пересекает ли прямоугольник 180-й меридиан
+func (b Box) CrossesAntimeridian() bool {
+	return b.MinLng > b.MaxLng
+}
+
+// Distance расстояние по поверхности Земли между точками в километрах
+// (формула гаверсинусов)
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := radians(lat2 - lat1)
+	dLng := radians(lng2 - lng1)
+
+	h := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Pow(math.Sin(dLng/2), 2)
+
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoundingBox прямоугольник вокруг круга радиусом radiusKm. Дает условие
+// на обычных индексах по широте и долготе, точное расстояние проверяется
+// уже для попавших в него точек.
+func BoundingBox(lat, lng, radiusKm float64) Box {
+	dLat := degrees(radiusKm / EarthRadiusKm)
+	box := Box{MinLat: lat - dLat, MaxLat: lat + dLat, MinLng: -180, MaxLng: 180}
+
+	// Круг накрывает полюс: подходят все долготы
+	if box.MinLat <= -90 || box.MaxLat >= 90 {
+		box.MinLat = math.Max(box.MinLat, -90)
+		box.MaxLat = math.Min(box.MaxLat, 90)
+		return box
+	}
+
+	dLng := degrees(math.Asin(math.Sin(radiusKm/EarthRadiusKm) / math.Cos(radians(lat))))
+	if dLng >= 180 {
+		return box
+	}
+
+	box.MinLng = normalizeLng(lng - dLng)
+	box.MaxLng = normalizeLng(lng + dLng)
+
+	return box
+}
+
+// normalizeLng приводит долготу к [-180, 180]
+func normalizeLng(lng float64) float64 {
+	switch {
+	case lng < -180:
+		return lng + 360
+	case lng > 180:
+		return lng - 360
+	default:
+		return lng
+	}
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
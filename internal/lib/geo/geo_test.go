@@ -0,0 +1,58 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		name       string
+		lat1, lng1 float64
+		lat2, lng2 float64
+		km         float64
+	}{
+		{name: "Same Point", lat1: 55.7558, lng1: 37.6173, lat2: 55.7558, lng2: 37.6173, km: 0},
+		// Красная площадь — Олимпийский
+		{name: "Moscow", lat1: 55.7539, lng1: 37.6208, lat2: 55.7814, lng2: 37.6258, km: 3.07},
+		{name: "Moscow To Saint Petersburg", lat1: 55.7558, lng1: 37.6173, lat2: 59.9343, lng2: 30.3351, km: 634},
+		{name: "Across Antimeridian", lat1: 0, lng1: 179.5, lat2: 0, lng2: -179.5, km: 111.2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.InDelta(t, tc.km, Distance(tc.lat1, tc.lng1, tc.lat2, tc.lng2), tc.km*0.01+0.01)
+		})
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	t.Run("Contains Circle", func(t *testing.T) {
+		lat, lng, radius := 55.7558, 37.6173, 10.0
+		box := BoundingBox(lat, lng, radius)
+
+		require.False(t, box.CrossesAntimeridian())
+		// точки на окружности по сторонам света внутри прямоугольника
+		require.InDelta(t, radius, Distance(lat, lng, box.MaxLat, lng), 0.01)
+		require.InDelta(t, radius, Distance(lat, lng, box.MinLat, lng), 0.01)
+		require.GreaterOrEqual(t, Distance(lat, lng, lat, box.MaxLng), radius)
+		require.GreaterOrEqual(t, Distance(lat, lng, lat, box.MinLng), radius)
+	})
+
+	t.Run("Antimeridian", func(t *testing.T) {
+		box := BoundingBox(65, 179.9, 50)
+
+		require.True(t, box.CrossesAntimeridian())
+		require.InDelta(t, 178.84, box.MinLng, 0.01)
+		require.InDelta(t, -179.04, box.MaxLng, 0.01)
+	})
+
+	t.Run("Pole", func(t *testing.T) {
+		box := BoundingBox(89.9, 10, 100)
+
+		require.Equal(t, 90.0, box.MaxLat)
+		require.Equal(t, -180.0, box.MinLng)
+		require.Equal(t, 180.0, box.MaxLng)
+	})
+}
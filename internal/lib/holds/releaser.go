@@ -0,0 +1,45 @@
+// Package holds возвращает в продажу неоплаченные удержания билетов.
+package holds
+
+import (
+	"API/internal/lib/metrics"
+	"context"
+	"fmt"
+	"time"
+)
+
+// Storage методы хранилища для истечения удержаний
+type Storage interface {
+	// ReleaseExpiredHolds переводит удержания, истекшие к now, в expired
+	// и возвращает их билеты в продажу
+	ReleaseExpiredHolds(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Releaser периодически снимает удержания, которые не оплатили до expires_at
+type Releaser struct {
+	storage Storage
+	now     func() time.Time
+}
+
+// NewReleaser создает Releaser
+func NewReleaser(storage Storage) *Releaser {
+	return &Releaser{
+		storage: storage,
+		now:     time.Now,
+	}
+}
+
+// Release делает один проход и возвращает число истекших удержаний.
+// Подходит как sweeper.SweepFunc.
+func (r *Releaser) Release(ctx context.Context) (int64, error) {
+	const op = "holds.Release"
+
+	n, err := r.storage.ReleaseExpiredHolds(ctx, r.now())
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	metrics.BookingHoldsExpired.Add(float64(n))
+
+	return n, nil
+}
@@ -0,0 +1,54 @@
+package holds
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"API/internal/lib/metrics"
+)
+
+// storageStub запоминает момент прохода и отдает заданный результат
+type storageStub struct {
+	released int64
+	err      error
+	now      time.Time
+}
+
+func (s *storageStub) ReleaseExpiredHolds(_ context.Context, now time.Time) (int64, error) {
+	s.now = now
+	return s.released, s.err
+}
+
+func TestRelease(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	storage := &storageStub{released: 3}
+
+	r := NewReleaser(storage)
+	r.now = func() time.Time { return now }
+
+	before := testutil.ToFloat64(metrics.BookingHoldsExpired)
+
+	n, err := r.Release(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+
+	// Истекшими считаются удержания с expires_at не позже момента прохода
+	require.Equal(t, now, storage.now)
+	require.Equal(t, before+3, testutil.ToFloat64(metrics.BookingHoldsExpired))
+}
+
+func TestReleaseError(t *testing.T) {
+	storage := &storageStub{released: 5, err: errors.New("connection refused")}
+
+	before := testutil.ToFloat64(metrics.BookingHoldsExpired)
+
+	n, err := NewReleaser(storage).Release(context.Background())
+	require.ErrorIs(t, err, storage.err)
+	require.Zero(t, n)
+	require.Equal(t, before, testutil.ToFloat64(metrics.BookingHoldsExpired))
+}
@@ -16,6 +16,11 @@ type DiscardHandler struct{}
 func NewDiscardHandler() *DiscardHandler {
 	return &DiscardHandler{}
 }
+func (h *DiscardHandler) Handle(_ context.Context, _ slog.Record) error {
+	// Просто игнорируем запись журнала
+	return nil
+}
+
 func (h *DiscardHandler) WithAttrs(_ []slog.Attr) slog.Handler {
 	//Возвращает тот же обработчик, т.к нет атрибутов для сохранения
 	return h
@@ -1,7 +1,10 @@
 package sl
 
 import (
-	"log/slog"
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
 )
 
 //Оставляем удобная штучка для вывода подробностей об ошибке
@@ -12,3 +15,14 @@ func Err(err error) slog.Attr {
 		Value: slog.StringValue(err.Error()),
 	}
 }
+
+// TraceID возвращает атрибут с trace_id текущего span,
+// чтобы логи запроса можно было найти по трейсу и наоборот
+func TraceID(ctx context.Context) slog.Attr {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.HasTraceID() {
+		return slog.String("trace_id", "")
+	}
+
+	return slog.String("trace_id", spanCtx.TraceID().String())
+}
@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "api"
+
+// Registry отдельный реестр метрик приложения, чтобы не тянуть
+// глобальное состояние prometheus.DefaultRegisterer
+var Registry = prometheus.NewRegistry()
+
+// HTTP метрики
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Количество обработанных HTTP запросов.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Время обработки HTTP запросов.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "Количество HTTP запросов в обработке.",
+	})
+)
+
+// Бизнес метрики
+var (
+	BookingsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bookings_created_total",
+		Help:      "Количество созданных бронирований.",
+	})
+
+	BookingsCancelled = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bookings_cancelled_total",
+		Help:      "Количество отмененных бронирований.",
+	})
+
+	BookingHolds = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "booking_holds_total",
+		Help:      "Количество удержаний билетов до оплаты.",
+	})
+
+	BookingHoldsExpired = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "booking_holds_expired_total",
+		Help:      "Количество удержаний, которые не оплатили вовремя.",
+	})
+
+	TicketsSold = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tickets_sold_total",
+		Help:      "Количество проданных билетов по категориям мероприятий.",
+	}, []string{"category"})
+
+	BalanceTopUps = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "balance_topups_total",
+		Help:      "Количество пополнений баланса.",
+	})
+
+	BalanceTopUpAmount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "balance_topup_amount_total",
+		Help:      "Сумма пополнений баланса.",
+	})
+
+	LoginFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_failures_total",
+		Help:      "Количество неудачных попыток входа.",
+	}, []string{"reason"})
+
+	RedirectsServed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "redirects_served_total",
+		Help:      "Количество выполненных редиректов по коротким ссылкам.",
+	})
+
+	AliasCollisions = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "alias_collisions_total",
+		Help:      "Количество коллизий сгенерированных алиасов.",
+	})
+
+	URLCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "url_cache",
+		Name:      "requests_total",
+		Help:      "Обращения к кэшу коротких ссылок по результату (hit, negative_hit, shared_hit, miss).",
+	}, []string{"result"})
+
+	URLCacheEntries = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "url_cache",
+		Name:      "entries",
+		Help:      "Количество записей в кэше коротких ссылок.",
+	})
+
+	ClicksRecorded = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "clicks_recorded_total",
+		Help:      "Количество переходов, записанных в базу.",
+	})
+
+	ClicksDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "clicks_dropped_total",
+		Help:      "Количество потерянных переходов по причинам.",
+	}, []string{"reason"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		HTTPRequestsInFlight,
+		BookingsCreated,
+		BookingsCancelled,
+		BookingHolds,
+		BookingHoldsExpired,
+		TicketsSold,
+		BalanceTopUps,
+		BalanceTopUpAmount,
+		LoginFailures,
+		RedirectsServed,
+		AliasCollisions,
+		URLCacheRequests,
+		URLCacheEntries,
+		ClicksRecorded,
+		ClicksDropped,
+	)
+}
+
+// Handler возвращает хендлер для отдачи метрик в формате Prometheus
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
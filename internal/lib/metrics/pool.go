@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector отдает статистику пула соединений pgx.
+// Значения снимаются в момент сбора метрик, поэтому отдельная
+// горутина для обновления не нужна.
+type PoolCollector struct {
+	stat func() *pgxpool.Stat
+
+	acquiredConns    *prometheus.Desc
+	idleConns        *prometheus.Desc
+	totalConns       *prometheus.Desc
+	maxConns         *prometheus.Desc
+	acquireCount     *prometheus.Desc
+	acquireDuration  *prometheus.Desc
+	emptyAcquire     *prometheus.Desc
+	canceledAcquire  *prometheus.Desc
+	newConns         *prometheus.Desc
+	maxLifetimeDrops *prometheus.Desc
+	maxIdleDrops     *prometheus.Desc
+}
+
+// NewPoolCollector создает коллектор для пула, статистику которого возвращает stat
+func NewPoolCollector(stat func() *pgxpool.Stat) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	return &PoolCollector{
+		stat:             stat,
+		acquiredConns:    desc("acquired_conns", "Количество занятых соединений."),
+		idleConns:        desc("idle_conns", "Количество свободных соединений."),
+		totalConns:       desc("total_conns", "Общее количество соединений."),
+		maxConns:         desc("max_conns", "Максимальный размер пула."),
+		acquireCount:     desc("acquire_total", "Количество успешных получений соединения."),
+		acquireDuration:  desc("acquire_duration_seconds_total", "Суммарное время ожидания соединения."),
+		emptyAcquire:     desc("empty_acquire_total", "Количество получений, которым пришлось ждать соединение."),
+		canceledAcquire:  desc("canceled_acquire_total", "Количество отмененных получений соединения."),
+		newConns:         desc("new_conns_total", "Количество открытых соединений."),
+		maxLifetimeDrops: desc("max_lifetime_destroy_total", "Количество соединений, закрытых по MaxConnLifetime."),
+		maxIdleDrops:     desc("max_idle_destroy_total", "Количество соединений, закрытых по MaxConnIdleTime."),
+	}
+}
+
+// Describe реализует prometheus.Collector
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.emptyAcquire
+	ch <- c.canceledAcquire
+	ch <- c.newConns
+	ch <- c.maxLifetimeDrops
+	ch <- c.maxIdleDrops
+}
+
+// Collect реализует prometheus.Collector
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(s.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(s.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(s.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(s.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(s.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, s.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquire, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquire, prometheus.CounterValue, float64(s.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.newConns, prometheus.CounterValue, float64(s.NewConnsCount()))
+	ch <- prometheus.MustNewConstMetric(c.maxLifetimeDrops, prometheus.CounterValue, float64(s.MaxLifetimeDestroyCount()))
+	ch <- prometheus.MustNewConstMetric(c.maxIdleDrops, prometheus.CounterValue, float64(s.MaxIdleDestroyCount()))
+}
@@ -0,0 +1,115 @@
+package notify
+
+import (
+	"API/internal/models"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// ErrRateLimited уведомление не отправлено: пользователь исчерпал лимит
+var ErrRateLimited = errors.New("notification rate limit exceeded")
+
+// Notifier доставляет уведомления пользователям. Канал доставки
+// (почта, пуши) подключается своей реализацией в main.
+type Notifier interface {
+	Notify(ctx context.Context, n models.Notification) error
+}
+
+// Log пишет уведомления в лог. Используется, пока не подключен
+// настоящий канал доставки.
+type Log struct {
+	log *slog.Logger
+}
+
+// NewLog создает Log
+func NewLog(log *slog.Logger) *Log {
+	return &Log{log: log.With(slog.String("component", "notify.Log"))}
+}
+
+func (l *Log) Notify(_ context.Context, n models.Notification) error {
+	l.log.Info("notification",
+		slog.Int64("user_id", n.UserID),
+		slog.String("kind", n.Kind),
+		slog.Int64("event_id", n.EventID),
+		slog.String("subject", n.Subject),
+	)
+	return nil
+}
+
+// Limiter пропускает пользователю не больше limit уведомлений за скользящее
+// окно window, остальные отклоняет с ErrRateLimited. Счетчики хранятся
+// в памяти инстанса.
+type Limiter struct {
+	next   Notifier
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	mu   sync.Mutex
+	sent map[int64][]time.Time
+	// pruned когда из sent последний раз удалялись неактивные пользователи
+	pruned time.Time
+}
+
+// NewLimiter оборачивает next ограничением частоты
+func NewLimiter(next Notifier, limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		next:   next,
+		limit:  limit,
+		window: window,
+		now:    time.Now,
+		sent:   make(map[int64][]time.Time),
+	}
+}
+
+func (l *Limiter) Notify(ctx context.Context, n models.Notification) error {
+	if !l.allow(n.UserID) {
+		return ErrRateLimited
+	}
+	return l.next.Notify(ctx, n)
+}
+
+// allow учитывает уведомление, если лимит пользователя не исчерпан
+func (l *Limiter) allow(userID int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	from := now.Add(-l.window)
+
+	// Раз в окно забываем пользователей без отправок в окне,
+	// иначе sent растет с каждым новым получателем
+	if now.Sub(l.pruned) >= l.window {
+		l.prune(from)
+		l.pruned = now
+	}
+
+	// Отправки вне окна больше не считаются
+	sent := l.sent[userID]
+	i := 0
+	for i < len(sent) && !sent[i].After(from) {
+		i++
+	}
+	sent = sent[i:]
+
+	if len(sent) >= l.limit {
+		l.sent[userID] = sent
+		return false
+	}
+
+	l.sent[userID] = append(sent, now)
+	return true
+}
+
+// prune удаляет пользователей, чья последняя отправка не позже from
+func (l *Limiter) prune(from time.Time) {
+	for userID, sent := range l.sent {
+		if len(sent) == 0 || !sent[len(sent)-1].After(from) {
+			delete(l.sent, userID)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"API/internal/models"
+)
+
+// recorder запоминает доставленные уведомления
+type recorder struct {
+	sent []models.Notification
+}
+
+func (r *recorder) Notify(_ context.Context, n models.Notification) error {
+	r.sent = append(r.sent, n)
+	return nil
+}
+
+func TestLimiter(t *testing.T) {
+	next := &recorder{}
+	limiter := NewLimiter(next, 2, time.Hour)
+
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	notify := func(userID int64) error {
+		return limiter.Notify(context.Background(), models.Notification{UserID: userID})
+	}
+
+	require.NoError(t, notify(1))
+	now = now.Add(10 * time.Minute)
+	require.NoError(t, notify(1))
+	require.ErrorIs(t, notify(1), ErrRateLimited)
+
+	// лимит у каждого пользователя свой
+	require.NoError(t, notify(2))
+
+	// первое уведомление вышло из окна
+	now = now.Add(50 * time.Minute)
+	require.NoError(t, notify(1))
+	require.ErrorIs(t, notify(1), ErrRateLimited)
+
+	require.Len(t, next.sent, 4)
+}
+
+func TestLimiterPrunesIdleUsers(t *testing.T) {
+	limiter := NewLimiter(&recorder{}, 2, time.Hour)
+
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	notify := func(userID int64) error {
+		return limiter.Notify(context.Background(), models.Notification{UserID: userID})
+	}
+
+	for userID := int64(1); userID <= 3; userID++ {
+		require.NoError(t, notify(userID))
+	}
+	require.Len(t, limiter.sent, 3)
+
+	// через окно старые получатели забываются, остается только новый
+	now = now.Add(time.Hour + time.Minute)
+	require.NoError(t, notify(4))
+	require.Len(t, limiter.sent, 1)
+	require.Contains(t, limiter.sent, int64(4))
+}
@@ -0,0 +1,119 @@
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+
+	DefaultSize = 256
+	MinSize     = 64
+	MaxSize     = 2048
+)
+
+var levels = map[string]qrcode.RecoveryLevel{
+	"L": qrcode.Low,
+	"M": qrcode.Medium,
+	"Q": qrcode.High,
+	"H": qrcode.Highest,
+}
+
+// Options параметры изображения
+type Options struct {
+	Format string
+	Size   int
+	Level  qrcode.RecoveryLevel
+}
+
+// ParseOptions читает format, size и level из query. Ошибка
+// содержит текст, пригодный для ответа клиенту.
+func ParseOptions(query url.Values) (Options, error) {
+	opts := Options{
+		Format: FormatPNG,
+		Size:   DefaultSize,
+		Level:  qrcode.Medium,
+	}
+
+	if v := query.Get("format"); v != "" {
+		v = strings.ToLower(v)
+		if v != FormatPNG && v != FormatSVG {
+			return opts, errors.New("format must be png or svg")
+		}
+		opts.Format = v
+	}
+
+	if v := query.Get("size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size < MinSize || size > MaxSize {
+			return opts, fmt.Errorf("size must be between %d and %d", MinSize, MaxSize)
+		}
+		opts.Size = size
+	}
+
+	if v := query.Get("level"); v != "" {
+		level, ok := levels[strings.ToUpper(v)]
+		if !ok {
+			return opts, errors.New("level must be one of L, M, Q, H")
+		}
+		opts.Level = level
+	}
+
+	return opts, nil
+}
+
+// Render кодирует content и возвращает изображение и его Content-Type
+func Render(content string, opts Options) ([]byte, string, error) {
+	const op = "lib.qr.Render"
+
+	code, err := qrcode.New(content, opts.Level)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if opts.Format == FormatSVG {
+		return renderSVG(code.Bitmap(), opts.Size), "image/svg+xml", nil
+	}
+
+	png, err := code.PNG(opts.Size)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return png, "image/png", nil
+}
+
+// renderSVG рисует матрицу одним path: горизонтальные отрезки темных модулей
+// в координатах модулей, масштабирование через viewBox
+func renderSVG(bitmap [][]bool, size int) []byte {
+	n := len(bitmap)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, n, n)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, n, n)
+
+	for y, row := range bitmap {
+		for x := 0; x < len(row); {
+			if !row[x] {
+				x++
+				continue
+			}
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fmt.Fprintf(&b, "M%d %dh%dv1h-%dz", start, y, x-start, x-start)
+		}
+	}
+
+	b.WriteString(`"/></svg>`)
+
+	return []byte(b.String())
+}
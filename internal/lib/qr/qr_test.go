@@ -0,0 +1,48 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"net/url"
+	"strings"
+	"testing"
+
+	qrcode "github.com/skip2/go-qrcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOptions(t *testing.T) {
+	opts, err := ParseOptions(url.Values{})
+	require.NoError(t, err)
+	require.Equal(t, Options{Format: FormatPNG, Size: DefaultSize, Level: qrcode.Medium}, opts)
+
+	opts, err = ParseOptions(url.Values{"format": {"SVG"}, "size": {"512"}, "level": {"h"}})
+	require.NoError(t, err)
+	require.Equal(t, Options{Format: FormatSVG, Size: 512, Level: qrcode.Highest}, opts)
+
+	for _, q := range []url.Values{
+		{"format": {"gif"}},
+		{"size": {"10"}},
+		{"size": {"abc"}},
+		{"level": {"X"}},
+	} {
+		_, err := ParseOptions(q)
+		require.Error(t, err, q.Encode())
+	}
+}
+
+func TestRender(t *testing.T) {
+	data, contentType, err := Render("https://sho.rt/promo", Options{Format: FormatPNG, Size: 128, Level: qrcode.Medium})
+	require.NoError(t, err)
+	require.Equal(t, "image/png", contentType)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, 128, img.Bounds().Dx())
+
+	data, contentType, err = Render("https://sho.rt/promo", Options{Format: FormatSVG, Size: 300, Level: qrcode.Low})
+	require.NoError(t, err)
+	require.Equal(t, "image/svg+xml", contentType)
+	require.True(t, strings.HasPrefix(string(data), `<svg xmlns="http://www.w3.org/2000/svg" width="300" height="300"`))
+	require.Contains(t, string(data), `<path fill="#000" d="M`)
+}
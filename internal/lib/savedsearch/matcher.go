@@ -0,0 +1,192 @@
+package savedsearch
+
+import (
+	"API/internal/lib/geo"
+	"API/internal/lib/logger/sl"
+	"API/internal/lib/notify"
+	"API/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// matchTimeout ограничивает проверку одного мероприятия
+const matchTimeout = 30 * time.Second
+
+// Storage методы хранилища для подбора сохраненных поисков
+type Storage interface {
+	// SavedSearchesForEvent сохраненные поиски, которые могут подойти
+	// мероприятию; поиски его автора не возвращаются
+	SavedSearchesForEvent(ctx context.Context, event *models.Event) ([]models.SavedSearch, error)
+	// MatchEvent находит ли поиск с params мероприятие eventID
+	MatchEvent(ctx context.Context, eventID int64, params models.EventSearch) (bool, error)
+}
+
+// Matcher проверяет созданные мероприятия по сохраненным поискам в фоне
+// и уведомляет их владельцев. Создание мероприятия только ставит его
+// в очередь и не ждет проверки; ждать приходится, только пока очередь полна.
+type Matcher struct {
+	log      *slog.Logger
+	storage  Storage
+	notifier notify.Notifier
+	queue    chan models.Event
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewMatcher создает Matcher. Проверка начинается после вызова Run.
+func NewMatcher(log *slog.Logger, storage Storage, notifier notify.Notifier, queueSize int) *Matcher {
+	return &Matcher{
+		log:      log.With(slog.String("component", "savedsearch.Matcher")),
+		storage:  storage,
+		notifier: notifier,
+		queue:    make(chan models.Event, queueSize),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Enqueue ставит мероприятие в очередь на проверку. Если очередь
+// заполнена, Enqueue ждет, пока Run ее разберет: создание мероприятия
+// притормаживает, но уведомления не теряются. Мероприятие пропускается,
+// только если отменен ctx запроса или Matcher уже остановлен.
+func (m *Matcher) Enqueue(ctx context.Context, event *models.Event) {
+	select {
+	case m.queue <- *event:
+		return
+	default:
+	}
+
+	m.log.Warn("match queue is full, waiting", slog.Int64("event_id", event.ID))
+
+	select {
+	case m.queue <- *event:
+	case <-ctx.Done():
+		m.log.Error("match queue is full, event skipped", sl.Err(ctx.Err()), slog.Int64("event_id", event.ID))
+	case <-m.stop:
+		m.log.Error("matcher is stopped, event skipped", slog.Int64("event_id", event.ID))
+	}
+}
+
+// Run проверяет мероприятия из очереди до вызова Close
+func (m *Matcher) Run() {
+	defer close(m.done)
+
+	for {
+		select {
+		case event := <-m.queue:
+			m.match(event)
+		case <-m.stop:
+			// Проверяем все, что успели поставить в очередь
+			for {
+				select {
+				case event := <-m.queue:
+					m.match(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// Close останавливает Run и ждет проверки оставшихся мероприятий.
+// Вызывать после остановки HTTP сервера.
+func (m *Matcher) Close(ctx context.Context) error {
+	close(m.stop)
+
+	select {
+	case <-m.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *Matcher) match(event models.Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), matchTimeout)
+	defer cancel()
+
+	log := m.log.With(slog.Int64("event_id", event.ID))
+
+	searches, err := m.storage.SavedSearchesForEvent(ctx, &event)
+	if err != nil {
+		log.Error("failed to load saved searches", sl.Err(err))
+		return
+	}
+
+	notified := 0
+	for _, search := range searches {
+		if !mayMatch(search.Params, &event) {
+			continue
+		}
+
+		ok, err := m.storage.MatchEvent(ctx, event.ID, search.Params)
+		if err != nil {
+			log.Error("failed to match saved search", sl.Err(err), slog.Int64("saved_search_id", search.ID))
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		err = m.notifier.Notify(ctx, newNotification(search, &event))
+		switch {
+		case errors.Is(err, notify.ErrRateLimited):
+			log.Info("notification rate limited", slog.Int64("user_id", search.UserID))
+		case err != nil:
+			log.Error("failed to notify", sl.Err(err), slog.Int64("user_id", search.UserID))
+		default:
+			notified++
+		}
+	}
+
+	if notified > 0 {
+		log.Info("saved searches matched", slog.Int("notified", notified))
+	}
+}
+
+// mayMatch отсеивает поиски по полям, которые можно проверить без базы.
+// Окончательно совпадение проверяет MatchEvent, поэтому проверки здесь
+// не должны быть строже поиска.
+func mayMatch(p models.EventSearch, e *models.Event) bool {
+	switch {
+	case len(p.Categories) > 0 && !slices.Contains(p.Categories, e.Category),
+		p.CreatorID != nil && *p.CreatorID != e.CreatorID,
+		p.OnlyAvailable && e.AvailableTickets <= 0,
+		p.PriceMin != nil && e.Price < *p.PriceMin,
+		p.PriceMax != nil && e.Price > *p.PriceMax,
+		p.DateFrom != nil && e.StartTime.Before(*p.DateFrom),
+		p.DateTo != nil && e.StartTime.After(*p.DateTo):
+		return false
+	}
+
+	if p.Near != nil {
+		if e.Latitude == nil || e.Longitude == nil {
+			return false
+		}
+		// запас на погрешность вычислений в базе
+		if p.RadiusKm != nil && geo.Distance(p.Near.Lat, p.Near.Lng, *e.Latitude, *e.Longitude) > *p.RadiusKm*1.01 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newNotification уведомление о мероприятии, найденном поиском
+func newNotification(search models.SavedSearch, e *models.Event) models.Notification {
+	return models.Notification{
+		UserID:  search.UserID,
+		Kind:    models.NotificationSavedSearch,
+		EventID: e.ID,
+		Subject: fmt.Sprintf("Новое мероприятие по поиску «%s»", search.Name),
+		Text: fmt.Sprintf("%s — %s, %s",
+			e.Title, e.Venue, e.StartTime.Format("02.01.2006 15:04")),
+	}
+}
@@ -0,0 +1,173 @@
+package savedsearch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/lib/notify"
+	"API/internal/models"
+)
+
+// storageStub отдает заданные поиски; MatchEvent совпадает для поисков
+// с запросом из matches и запоминает проверенные
+type storageStub struct {
+	searches []models.SavedSearch
+	matches  map[string]bool
+	checked  []string
+}
+
+func (s *storageStub) SavedSearchesForEvent(_ context.Context, _ *models.Event) ([]models.SavedSearch, error) {
+	return s.searches, nil
+}
+
+func (s *storageStub) MatchEvent(_ context.Context, _ int64, params models.EventSearch) (bool, error) {
+	s.checked = append(s.checked, params.Query)
+	return s.matches[params.Query], nil
+}
+
+// notifierStub запоминает уведомления; limited пользователям отказывает
+type notifierStub struct {
+	sent    []models.Notification
+	limited map[int64]bool
+}
+
+func (n *notifierStub) Notify(_ context.Context, notification models.Notification) error {
+	if n.limited[notification.UserID] {
+		return notify.ErrRateLimited
+	}
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestMatcher(t *testing.T) {
+	storage := &storageStub{
+		searches: []models.SavedSearch{
+			{ID: 1, UserID: 10, Name: "Джаз", Params: models.EventSearch{Query: "джаз"}},
+			{ID: 2, UserID: 11, Name: "Дешевые концерты", Params: models.EventSearch{
+				Query:       "cheap",
+				EventFilter: models.EventFilter{Categories: []string{"concert"}},
+				PriceMax:    ptr(1000.0),
+			}},
+			{ID: 3, UserID: 12, Name: "Театр", Params: models.EventSearch{Query: "theater"}},
+			{ID: 4, UserID: 13, Name: "Лимит", Params: models.EventSearch{Query: "limited"}},
+		},
+		matches: map[string]bool{"джаз": true, "cheap": true, "limited": true},
+	}
+	notifier := &notifierStub{limited: map[int64]bool{13: true}}
+
+	matcher := NewMatcher(slogdiscard.NewDiscardLogger(), storage, notifier, 10)
+	go matcher.Run()
+
+	matcher.Enqueue(context.Background(), &models.Event{
+		ID:        5,
+		Title:     "Вечер джаза",
+		Category:  "concert",
+		Venue:     "Клуб",
+		Price:     2500,
+		StartTime: time.Date(2025, 6, 1, 19, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, matcher.Close(context.Background()))
+
+	// поиск дешевых концертов отсеян по цене без обращения к базе
+	require.Equal(t, []string{"джаз", "theater", "limited"}, storage.checked)
+
+	require.Len(t, notifier.sent, 1)
+	require.Equal(t, models.Notification{
+		UserID:  10,
+		Kind:    models.NotificationSavedSearch,
+		EventID: 5,
+		Subject: "Новое мероприятие по поиску «Джаз»",
+		Text:    "Вечер джаза — Клуб, 01.06.2025 19:00",
+	}, notifier.sent[0])
+}
+
+func TestEnqueueWaitsForFullQueue(t *testing.T) {
+	storage := &storageStub{}
+	matcher := NewMatcher(slogdiscard.NewDiscardLogger(), storage, &notifierStub{}, 1)
+
+	// Run еще не запущен: первое мероприятие занимает всю очередь
+	matcher.Enqueue(context.Background(), &models.Event{ID: 1})
+
+	enqueued := make(chan struct{})
+	go func() {
+		matcher.Enqueue(context.Background(), &models.Event{ID: 2})
+		close(enqueued)
+	}()
+
+	select {
+	case <-enqueued:
+		t.Fatal("Enqueue did not wait for a full queue")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// Run освобождает очередь, и второе мероприятие не теряется
+	go matcher.Run()
+	select {
+	case <-enqueued:
+	case <-time.After(time.Second):
+		t.Fatal("Enqueue did not resume after the queue was drained")
+	}
+	require.NoError(t, matcher.Close(context.Background()))
+}
+
+func TestEnqueueGivesUpWithRequest(t *testing.T) {
+	matcher := NewMatcher(slogdiscard.NewDiscardLogger(), &storageStub{}, &notifierStub{}, 1)
+	matcher.Enqueue(context.Background(), &models.Event{ID: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Клиент оборвал запрос: мероприятие пропускается, а не ждет вечно
+	matcher.Enqueue(ctx, &models.Event{ID: 2})
+	require.Len(t, matcher.queue, 1)
+
+	// После остановки Enqueue тоже не блокируется
+	go matcher.Run()
+	require.NoError(t, matcher.Close(context.Background()))
+	matcher.queue <- models.Event{ID: 3}
+	matcher.Enqueue(context.Background(), &models.Event{ID: 4})
+}
+
+func TestMayMatch(t *testing.T) {
+	moscow := &models.GeoPoint{Lat: 55.7558, Lng: 37.6173}
+	event := &models.Event{
+		Category:         "concert",
+		CreatorID:        1,
+		Price:            2500,
+		AvailableTickets: 0,
+		StartTime:        time.Date(2025, 6, 1, 19, 0, 0, 0, time.UTC),
+		Latitude:         ptr(55.7814),
+		Longitude:        ptr(37.6258),
+	}
+
+	cases := []struct {
+		name   string
+		params models.EventSearch
+		match  bool
+	}{
+		{name: "Empty", match: true},
+		{name: "Other Category", params: models.EventSearch{EventFilter: models.EventFilter{Categories: []string{"sport", "theater"}}}},
+		{name: "Same Category", params: models.EventSearch{EventFilter: models.EventFilter{Categories: []string{"sport", "concert"}}}, match: true},
+		{name: "Other Creator", params: models.EventSearch{EventFilter: models.EventFilter{CreatorID: ptr(int64(2))}}},
+		{name: "Sold Out", params: models.EventSearch{EventFilter: models.EventFilter{OnlyAvailable: true}}},
+		{name: "Too Expensive", params: models.EventSearch{PriceMax: ptr(2000.0)}},
+		{name: "Price In Range", params: models.EventSearch{PriceMin: ptr(1000.0), PriceMax: ptr(3000.0)}, match: true},
+		{name: "Before Dates", params: models.EventSearch{DateTo: ptr(time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC))}},
+		{name: "Within Radius", params: models.EventSearch{Near: moscow, RadiusKm: ptr(5.0)}, match: true},
+		{name: "Outside Radius", params: models.EventSearch{Near: moscow, RadiusKm: ptr(1.0)}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.match, mayMatch(tc.params, event))
+		})
+	}
+}
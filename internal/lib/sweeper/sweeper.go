@@ -0,0 +1,62 @@
+package sweeper
+
+import (
+	"API/internal/lib/logger/sl"
+	"context"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// SweepFunc выполняет один проход очистки и возвращает число удаленных записей
+type SweepFunc func(ctx context.Context) (int64, error)
+
+// Sweeper периодически запускает фоновую очистку
+type Sweeper struct {
+	log      *slog.Logger
+	interval time.Duration
+	sweep    SweepFunc
+}
+
+// New создает Sweeper с именем name для логов
+func New(log *slog.Logger, name string, interval time.Duration, sweep SweepFunc) *Sweeper {
+	return &Sweeper{
+		log:      log.With(slog.String("component", "sweeper"), slog.String("sweeper", name)),
+		interval: interval,
+		sweep:    sweep,
+	}
+}
+
+// Run запускает очистку сразу и далее каждые interval, пока не отменен ctx
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Sweeper) runOnce(ctx context.Context) {
+	// Проход не должен пережить следующий тик
+	ctx, cancel := context.WithTimeout(ctx, s.interval)
+	defer cancel()
+
+	n, err := s.sweep(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.log.Error("sweep failed", sl.Err(err))
+		}
+		return
+	}
+
+	if n > 0 {
+		s.log.Info("sweep finished", slog.Int64("deleted", n))
+	}
+}
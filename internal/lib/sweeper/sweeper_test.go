@@ -0,0 +1,74 @@
+package sweeper
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"API/internal/lib/logger/handlers/slogdiscard"
+)
+
+func TestRunSweepsImmediatelyAndOnTicks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls atomic.Int64
+	s := New(slogdiscard.NewDiscardLogger(), "test", 10*time.Millisecond, func(ctx context.Context) (int64, error) {
+		calls.Add(1)
+		return 1, nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return calls.Load() >= 3 }, time.Second, time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop after context cancel")
+	}
+}
+
+func TestRunContinuesAfterError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls atomic.Int64
+	s := New(slogdiscard.NewDiscardLogger(), "test", 10*time.Millisecond, func(ctx context.Context) (int64, error) {
+		calls.Add(1)
+		return 0, errors.New("connection refused")
+	})
+	go s.Run(ctx)
+
+	// Ошибка прохода не останавливает очистку
+	require.Eventually(t, func() bool { return calls.Load() >= 2 }, time.Second, time.Millisecond)
+}
+
+func TestRunOnceBoundedByInterval(t *testing.T) {
+	const interval = 20 * time.Millisecond
+
+	var deadline time.Time
+	s := New(slogdiscard.NewDiscardLogger(), "test", interval, func(ctx context.Context) (int64, error) {
+		var ok bool
+		deadline, ok = ctx.Deadline()
+		require.True(t, ok)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+
+	start := time.Now()
+	s.runOnce(context.Background())
+
+	// Проход, не успевший за interval, отменяется до следующего тика
+	require.WithinDuration(t, start.Add(interval), deadline, 10*time.Millisecond)
+	require.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,32 @@
+package textlang
+
+import (
+	"API/internal/models"
+	"unicode"
+)
+
+// Detect определяет язык текста мероприятия по алфавиту: кириллица
+// считается русским, латиница английским. Если букв ни одного из
+// алфавитов нет, возвращается models.LanguageSimple.
+func Detect(texts ...string) string {
+	var cyrillic, latin int
+	for _, text := range texts {
+		for _, r := range text {
+			switch {
+			case unicode.Is(unicode.Cyrillic, r):
+				cyrillic++
+			case unicode.Is(unicode.Latin, r):
+				latin++
+			}
+		}
+	}
+
+	switch {
+	case cyrillic == 0 && latin == 0:
+		return models.LanguageSimple
+	case latin > cyrillic:
+		return models.LanguageEnglish
+	default:
+		return models.LanguageRussian
+	}
+}
@@ -0,0 +1,31 @@
+package textlang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"API/internal/models"
+)
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		name  string
+		texts []string
+		lang  string
+	}{
+		{name: "Russian", texts: []string{"Вечер джаза", "Лучшие музыканты города"}, lang: models.LanguageRussian},
+		{name: "English", texts: []string{"Jazz Night", "Best musicians in town"}, lang: models.LanguageEnglish},
+		{name: "Mostly Russian", texts: []string{"Концерт группы Queen", "Трибьют-шоу с оркестром"}, lang: models.LanguageRussian},
+		{name: "Mostly English", texts: []string{"Rock Festival", "Open air в Москве, headliners from UK"}, lang: models.LanguageEnglish},
+		{name: "No Letters", texts: []string{"2025", "!!!"}, lang: models.LanguageSimple},
+		{name: "Other Alphabet", texts: []string{"音楽祭"}, lang: models.LanguageSimple},
+		{name: "Empty", lang: models.LanguageSimple},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.lang, Detect(tc.texts...))
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package urlcache
+
+import (
+	storage "API/internal/Storage"
+	"API/internal/config"
+	"API/internal/lib/logger/sl"
+	"API/internal/lib/metrics"
+	"API/internal/models"
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// Результаты обращения к кэшу для метрик
+const (
+	resultHit         = "hit"
+	resultNegativeHit = "negative_hit"
+	resultSharedHit   = "shared_hit"
+	resultMiss        = "miss"
+)
+
+// Source хранилище, из которого кэш берет ссылки
+type Source interface {
+	ResolveURL(ctx context.Context, alias string) (*models.URL, error)
+	CountClick(ctx context.Context, urlID int64) error
+}
+
+// Shared общий для инстансов кэш, например Redis. Необязателен: без него
+// каждый инстанс кэширует сам, а инвалидация видна только локально.
+// nil в Get и Set означает закэшированное отсутствие алиаса.
+type Shared interface {
+	Get(ctx context.Context, alias string) (u *models.URL, found bool, err error)
+	Set(ctx context.Context, alias string, u *models.URL, ttl time.Duration) error
+	Delete(ctx context.Context, alias string) error
+}
+
+type entry struct {
+	alias     string
+	url       *models.URL // nil для неизвестного алиаса
+	expiresAt time.Time
+}
+
+// Cache LRU кэш ссылок с TTL перед хранилищем. Реализует те же GetURL
+// и CountClick, что и хранилище.
+type Cache struct {
+	log    *slog.Logger
+	source Source
+	shared Shared
+
+	size        int
+	ttl         time.Duration
+	negativeTTL time.Duration
+
+	mu    sync.Mutex
+	items map[string]*list.Element
+	lru   *list.List
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// New создает кэш. shared может быть nil.
+func New(log *slog.Logger, source Source, shared Shared, cfg config.URLCacheConfig) *Cache {
+	return &Cache{
+		log:         log.With(slog.String("component", "urlcache")),
+		source:      source,
+		shared:      shared,
+		size:        max(cfg.Size, 1),
+		ttl:         cfg.TTL,
+		negativeTTL: cfg.NegativeTTL,
+		items:       make(map[string]*list.Element),
+		lru:         list.New(),
+	}
+}
+
+// GetURL возвращает ссылку по алиасу и проверяет срок действия.
+// Возвращаемую ссылку нельзя менять: она общая с кэшем.
+func (c *Cache) GetURL(ctx context.Context, alias string) (*models.URL, error) {
+	u, err := c.resolve(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Expired(time.Now()) {
+		return nil, storage.ErrURLExpired
+	}
+
+	return u, nil
+}
+
+// CountClick списывает переход у ссылки с лимитом. Лимит всегда списывается
+// в хранилище: счетчик в кэше может отставать, решение о 410 принимает база.
+func (c *Cache) CountClick(ctx context.Context, urlID int64) error {
+	return c.source.CountClick(ctx, urlID)
+}
+
+// Invalidate удаляет алиас из кэша. Вызывается при создании, изменении
+// и удалении ссылки.
+func (c *Cache) Invalidate(ctx context.Context, alias string) {
+	c.mu.Lock()
+	if el, ok := c.items[alias]; ok {
+		c.removeElement(el)
+	}
+	c.mu.Unlock()
+
+	if c.shared != nil {
+		if err := c.shared.Delete(ctx, alias); err != nil {
+			c.log.Error("failed to invalidate shared cache", sl.Err(err), slog.String("alias", alias))
+		}
+	}
+}
+
+// HitRatio доля запросов, обслуженных без обращения к хранилищу
+func (c *Cache) HitRatio() float64 {
+	hits, misses := c.hits.Load(), c.misses.Load()
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
+
+// LogStats периодически пишет в лог долю попаданий, пока не отменен ctx
+func (c *Cache) LogStats(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.mu.Lock()
+			entries := c.lru.Len()
+			c.mu.Unlock()
+
+			c.log.Info("url cache stats",
+				slog.Int64("hits", c.hits.Load()),
+				slog.Int64("misses", c.misses.Load()),
+				slog.Float64("hit_ratio", c.HitRatio()),
+				slog.Int("entries", entries),
+			)
+		}
+	}
+}
+
+func (c *Cache) resolve(ctx context.Context, alias string) (*models.URL, error) {
+	if u, ok := c.get(alias); ok {
+		c.hit(resultHit, u)
+		return found(u)
+	}
+
+	if c.shared != nil {
+		u, ok, err := c.shared.Get(ctx, alias)
+		if err != nil {
+			c.log.Error("failed to read shared cache", sl.Err(err), slog.String("alias", alias))
+		} else if ok {
+			c.set(alias, u, c.ttlFor(u))
+			c.hit(resultSharedHit, u)
+			return found(u)
+		}
+	}
+
+	c.misses.Add(1)
+	metrics.URLCacheRequests.WithLabelValues(resultMiss).Inc()
+
+	u, err := c.source.ResolveURL(ctx, alias)
+	if err != nil && !errors.Is(err, storage.ErrURLNotFound) {
+		return nil, err
+	}
+	// u == nil для неизвестного алиаса: кэшируем отсутствие,
+	// чтобы перебор алиасов не нагружал базу
+	ttl := c.ttlFor(u)
+	c.set(alias, u, ttl)
+
+	if c.shared != nil {
+		if err := c.shared.Set(ctx, alias, u, ttl); err != nil {
+			c.log.Error("failed to write shared cache", sl.Err(err), slog.String("alias", alias))
+		}
+	}
+
+	return found(u)
+}
+
+func (c *Cache) hit(result string, u *models.URL) {
+	if u == nil {
+		result = resultNegativeHit
+	}
+	c.hits.Add(1)
+	metrics.URLCacheRequests.WithLabelValues(result).Inc()
+}
+
+func found(u *models.URL) (*models.URL, error) {
+	if u == nil {
+		return nil, storage.ErrURLNotFound
+	}
+	return u, nil
+}
+
+// ttlFor не дает ссылке жить в кэше дольше ее expires_at
+func (c *Cache) ttlFor(u *models.URL) time.Duration {
+	if u == nil {
+		return c.negativeTTL
+	}
+
+	ttl := c.ttl
+	if u.ExpiresAt != nil {
+		if left := time.Until(*u.ExpiresAt); left < ttl {
+			ttl = max(left, 0)
+		}
+	}
+
+	return ttl
+}
+
+func (c *Cache) get(alias string) (*models.URL, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[alias]
+	if !ok {
+		return nil, false
+	}
+
+	e := el.Value.(*entry)
+	if time.Now().After(e.expiresAt) {
+		c.removeElement(el)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(el)
+	return e.url, true
+}
+
+func (c *Cache) set(alias string, u *models.URL, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := &entry{alias: alias, url: u, expiresAt: time.Now().Add(ttl)}
+
+	if el, ok := c.items[alias]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.items[alias] = c.lru.PushFront(e)
+
+	for c.lru.Len() > c.size {
+		c.removeElement(c.lru.Back())
+	}
+	metrics.URLCacheEntries.Set(float64(c.lru.Len()))
+}
+
+func (c *Cache) removeElement(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.items, el.Value.(*entry).alias)
+	metrics.URLCacheEntries.Set(float64(c.lru.Len()))
+}
@@ -0,0 +1,136 @@
+package urlcache
+
+import (
+	storage "API/internal/Storage"
+	"API/internal/config"
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sourceStub struct {
+	urls     map[string]*models.URL
+	resolves int
+	counted  int
+}
+
+func (s *sourceStub) ResolveURL(_ context.Context, alias string) (*models.URL, error) {
+	s.resolves++
+	u, ok := s.urls[alias]
+	if !ok {
+		return nil, storage.ErrURLNotFound
+	}
+	cp := *u
+	return &cp, nil
+}
+
+func (s *sourceStub) CountClick(_ context.Context, _ int64) error {
+	s.counted++
+	return nil
+}
+
+func newCache(src Source, size int) *Cache {
+	return New(slogdiscard.NewDiscardLogger(), src, nil, config.URLCacheConfig{
+		Size:        size,
+		TTL:         time.Minute,
+		NegativeTTL: time.Minute,
+	})
+}
+
+func TestCacheHitsAndInvalidation(t *testing.T) {
+	ctx := context.Background()
+	src := &sourceStub{urls: map[string]*models.URL{
+		"promo": {ID: 1, Alias: "promo", URL: "https://example.com/a"},
+	}}
+	c := newCache(src, 10)
+
+	for i := 0; i < 3; i++ {
+		u, err := c.GetURL(ctx, "promo")
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/a", u.URL)
+	}
+	require.Equal(t, 1, src.resolves)
+
+	// После изменения ссылки кэш должен отдать новый адрес
+	src.urls["promo"].URL = "https://example.com/b"
+	c.Invalidate(ctx, "promo")
+
+	u, err := c.GetURL(ctx, "promo")
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/b", u.URL)
+	require.Equal(t, 2, src.resolves)
+	require.InDelta(t, 0.5, c.HitRatio(), 0.01)
+}
+
+func TestCacheNegative(t *testing.T) {
+	ctx := context.Background()
+	src := &sourceStub{urls: map[string]*models.URL{}}
+	c := newCache(src, 10)
+
+	for i := 0; i < 3; i++ {
+		_, err := c.GetURL(ctx, "missing")
+		require.ErrorIs(t, err, storage.ErrURLNotFound)
+	}
+	require.Equal(t, 1, src.resolves)
+
+	// Созданная ссылка видна сразу после инвалидации
+	src.urls["missing"] = &models.URL{ID: 2, URL: "https://example.com"}
+	c.Invalidate(ctx, "missing")
+
+	_, err := c.GetURL(ctx, "missing")
+	require.NoError(t, err)
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	src := &sourceStub{urls: map[string]*models.URL{
+		"a": {ID: 1, URL: "https://a"},
+		"b": {ID: 2, URL: "https://b"},
+		"c": {ID: 3, URL: "https://c"},
+	}}
+	c := newCache(src, 2)
+
+	for _, alias := range []string{"a", "b", "a", "c"} {
+		_, err := c.GetURL(ctx, alias)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, src.resolves)
+
+	// "b" вытеснен, "a" остался
+	_, _ = c.GetURL(ctx, "a")
+	require.Equal(t, 3, src.resolves)
+	_, _ = c.GetURL(ctx, "b")
+	require.Equal(t, 4, src.resolves)
+}
+
+func TestCacheRespectsLimits(t *testing.T) {
+	ctx := context.Background()
+	limit := 5
+	past := time.Now().Add(-time.Second)
+	src := &sourceStub{urls: map[string]*models.URL{
+		"limited": {ID: 1, URL: "https://a", MaxClicks: &limit},
+		"expired": {ID: 2, URL: "https://b", ExpiresAt: &past},
+	}}
+	c := newCache(src, 10)
+
+	for i := 0; i < 2; i++ {
+		u, err := c.GetURL(ctx, "limited")
+		require.NoError(t, err)
+		require.NoError(t, c.CountClick(ctx, u.ID))
+	}
+	// Ссылка берется из кэша, но каждый переход списывается в хранилище
+	require.Equal(t, 1, src.resolves)
+	require.Equal(t, 2, src.counted)
+
+	// Сама выдача ссылки переход не списывает
+	_, err := c.GetURL(ctx, "limited")
+	require.NoError(t, err)
+	require.Equal(t, 2, src.counted)
+
+	_, err = c.GetURL(ctx, "expired")
+	require.ErrorIs(t, err, storage.ErrURLExpired)
+}
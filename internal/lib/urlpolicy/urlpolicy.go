@@ -0,0 +1,216 @@
+package urlpolicy
+
+import (
+	"API/internal/config"
+	"bufio"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Verdict решение политики по адресу
+type Verdict int
+
+const (
+	// Allow адрес можно сокращать и открывать
+	Allow Verdict = iota
+	// Warn адрес допустим, но перед переходом показывается предупреждение
+	Warn
+	// Block адрес запрещен
+	Block
+)
+
+// Причины запрета, отдаются клиенту
+const (
+	ReasonInvalidURL     = "invalid url"
+	ReasonScheme         = "scheme is not allowed"
+	ReasonLoop           = "url points to this shortener"
+	ReasonInternalHost   = "internal hosts are not allowed"
+	ReasonBlocked        = "domain is blocked"
+	ReasonNotAllowlisted = "domain is not in the allowlist"
+	ReasonFlagged        = "domain is flagged"
+)
+
+// Decision результат проверки адреса
+type Decision struct {
+	Verdict Verdict
+	Reason  string
+}
+
+// Checker проверка адреса политикой. Хендлеры сохранения, изменения
+// и редиректа зависят от него, а не от *Policy.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.28.2 --all
+type Checker interface {
+	Check(rawURL, requestHost string) Decision
+}
+
+// Policy проверяет адреса назначения коротких ссылок.
+// Домены в списках совпадают вместе с поддоменами.
+type Policy struct {
+	schemes   map[string]struct{}
+	ownHosts  domainSet
+	blocklist domainSet
+	allowlist domainSet
+	warnlist  domainSet
+	internal  bool
+}
+
+// Load создает политику, читая списки доменов из файлов конфига.
+// Пустой путь означает пустой список.
+func Load(cfg config.URLPolicyConfig) (*Policy, error) {
+	const op = "lib.urlpolicy.Load"
+
+	p := &Policy{
+		schemes:  make(map[string]struct{}, len(cfg.AllowedSchemes)),
+		ownHosts: newDomainSet(cfg.OwnHosts),
+		internal: cfg.BlockInternalHosts,
+	}
+	for _, s := range cfg.AllowedSchemes {
+		p.schemes[strings.ToLower(strings.TrimSpace(s))] = struct{}{}
+	}
+
+	var err error
+	if p.blocklist, err = loadDomains(cfg.BlocklistFile); err != nil {
+		return nil, fmt.Errorf("%s: blocklist: %w", op, err)
+	}
+	if p.allowlist, err = loadDomains(cfg.AllowlistFile); err != nil {
+		return nil, fmt.Errorf("%s: allowlist: %w", op, err)
+	}
+	if p.warnlist, err = loadDomains(cfg.WarnlistFile); err != nil {
+		return nil, fmt.Errorf("%s: warnlist: %w", op, err)
+	}
+
+	return p, nil
+}
+
+// Check проверяет адрес. requestHost хост, по которому клиент обратился
+// к сервису; ссылки на него всегда считаются петлей.
+func (p *Policy) Check(rawURL, requestHost string) Decision {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" && u.Opaque == "" {
+		return Decision{Block, ReasonInvalidURL}
+	}
+
+	if _, ok := p.schemes[strings.ToLower(u.Scheme)]; !ok {
+		return Decision{Block, ReasonScheme}
+	}
+
+	host := normalizeHost(u.Hostname())
+	if host == "" {
+		return Decision{Block, ReasonInvalidURL}
+	}
+
+	if p.ownHosts.contains(host) || host == normalizeHost(stripPort(requestHost)) {
+		return Decision{Block, ReasonLoop}
+	}
+
+	if p.internal && isInternalHost(host) {
+		return Decision{Block, ReasonInternalHost}
+	}
+
+	if p.blocklist.contains(host) {
+		return Decision{Block, ReasonBlocked}
+	}
+
+	if len(p.allowlist) > 0 && !p.allowlist.contains(host) {
+		return Decision{Block, ReasonNotAllowlisted}
+	}
+
+	if p.warnlist.contains(host) {
+		return Decision{Warn, ReasonFlagged}
+	}
+
+	return Decision{Verdict: Allow}
+}
+
+// isInternalHost отсекает адреса внутренней сети по имени и IP литералу.
+// DNS не разрешается: имя, указывающее на внутренний адрес, здесь не поймать.
+func isInternalHost(host string) bool {
+	if host == "localhost" ||
+		strings.HasSuffix(host, ".localhost") ||
+		strings.HasSuffix(host, ".local") ||
+		strings.HasSuffix(host, ".internal") ||
+		!strings.Contains(host, ".") && !strings.Contains(host, ":") {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsUnspecified() || addr.IsMulticast()
+}
+
+type domainSet map[string]struct{}
+
+func newDomainSet(domains []string) domainSet {
+	set := make(domainSet, len(domains))
+	for _, d := range domains {
+		if d = normalizeHost(stripPort(d)); d != "" {
+			set[d] = struct{}{}
+		}
+	}
+	return set
+}
+
+// contains проверяет домен и все его родительские домены
+func (s domainSet) contains(host string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for {
+		if _, ok := s[host]; ok {
+			return true
+		}
+		i := strings.IndexByte(host, '.')
+		if i < 0 {
+			return false
+		}
+		host = host[i+1:]
+	}
+}
+
+// loadDomains читает файл с доменом на строку; # начинает комментарий
+func loadDomains(path string) (domainSet, error) {
+	if path == "" {
+		return domainSet{}, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var domains []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			domains = append(domains, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return newDomainSet(domains), nil
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
+
+func stripPort(hostport string) string {
+	if host, _, err := net.SplitHostPort(hostport); err == nil {
+		return host
+	}
+	return hostport
+}
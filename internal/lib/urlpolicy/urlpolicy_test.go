@@ -0,0 +1,75 @@
+package urlpolicy
+
+import (
+	"API/internal/config"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeList(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "list.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestCheck(t *testing.T) {
+	p, err := Load(config.URLPolicyConfig{
+		AllowedSchemes:     []string{"http", "https"},
+		OwnHosts:           []string{"sho.rt"},
+		BlocklistFile:      writeList(t, "# фишинг\nevil.com\nbad.example.org # и поддомены\n"),
+		WarnlistFile:       writeList(t, "sketchy.net\n"),
+		BlockInternalHosts: true,
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		url     string
+		verdict Verdict
+		reason  string
+	}{
+		{"https://example.com/events/1", Allow, ""},
+		{"javascript:alert(1)", Block, ReasonScheme},
+		{"ftp://example.com/file", Block, ReasonScheme},
+		{"https://sho.rt/abc", Block, ReasonLoop},
+		{"https://SHO.RT./abc", Block, ReasonLoop},
+		{"http://localhost:8082/abc", Block, ReasonLoop},
+		{"http://127.0.0.1/admin", Block, ReasonInternalHost},
+		{"http://10.1.2.3", Block, ReasonInternalHost},
+		{"http://[::1]/", Block, ReasonInternalHost},
+		{"http://169.254.169.254/latest/meta-data", Block, ReasonInternalHost},
+		{"http://db.internal", Block, ReasonInternalHost},
+		{"http://intranet/", Block, ReasonInternalHost},
+		{"https://evil.com/login", Block, ReasonBlocked},
+		{"https://login.evil.com/", Block, ReasonBlocked},
+		{"https://notevil.com/", Allow, ""},
+		{"https://a.bad.example.org/", Block, ReasonBlocked},
+		{"https://sketchy.net/x", Warn, ReasonFlagged},
+		{"not a url", Block, ReasonInvalidURL},
+	}
+
+	for _, tc := range cases {
+		d := p.Check(tc.url, "localhost:8082")
+		require.Equal(t, tc.verdict, d.Verdict, tc.url)
+		require.Equal(t, tc.reason, d.Reason, tc.url)
+	}
+}
+
+func TestAllowlist(t *testing.T) {
+	p, err := Load(config.URLPolicyConfig{
+		AllowedSchemes: []string{"https"},
+		AllowlistFile:  writeList(t, "example.com\n"),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, Allow, p.Check("https://tickets.example.com/1", "").Verdict)
+	require.Equal(t, ReasonNotAllowlisted, p.Check("https://other.com/", "").Reason)
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	_, err := Load(config.URLPolicyConfig{BlocklistFile: "/nonexistent/blocklist.txt"})
+	require.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package waitlist
+
+import (
+	"API/internal/lib/logger/sl"
+	"API/internal/lib/notify"
+	"API/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// Storage методы хранилища для раздачи билетов из листа ожидания
+type Storage interface {
+	// WaitlistEvents возвращает мероприятия, где свободных билетов хватает
+	// первому в листе ожидания
+	WaitlistEvents(ctx context.Context, now time.Time) ([]int64, error)
+	// OfferEventTickets удерживает свободные билеты мероприятия под
+	// очередь до now+window и возвращает созданные предложения
+	OfferEventTickets(ctx context.Context, eventID int64, now time.Time, window time.Duration) ([]models.WaitlistOffer, error)
+}
+
+// Offerer предлагает вернувшиеся в продажу билеты листу ожидания и
+// уведомляет пользователей. Отмена брони и увеличение вместимости
+// вызывают OfferEvent сразу, периодический Offer через sweeper подбирает
+// истекшие удержания и то, что не удалось предложить сразу.
+type Offerer struct {
+	log      *slog.Logger
+	storage  Storage
+	notifier notify.Notifier
+	window   time.Duration
+	now      func() time.Time
+}
+
+// NewOfferer создает Offerer; window — сколько предложение ждет оплаты
+func NewOfferer(log *slog.Logger, storage Storage, notifier notify.Notifier, window time.Duration) *Offerer {
+	return &Offerer{
+		log:      log.With(slog.String("component", "waitlist.Offerer")),
+		storage:  storage,
+		notifier: notifier,
+		window:   window,
+		now:      time.Now,
+	}
+}
+
+// Offer делает один проход по всем мероприятиям и возвращает число
+// предложений. Ошибка на одном мероприятии не мешает остальным. Подходит
+// как sweeper.SweepFunc.
+func (o *Offerer) Offer(ctx context.Context) (int64, error) {
+	eventIDs, err := o.storage.WaitlistEvents(ctx, o.now())
+	if err != nil {
+		return 0, err
+	}
+
+	var offered int64
+	for _, eventID := range eventIDs {
+		offered += o.OfferEvent(ctx, eventID)
+	}
+
+	return offered, nil
+}
+
+// OfferEvent предлагает свободные билеты мероприятия его листу ожидания
+// и возвращает число предложений. Ошибка только пишется в лог: билеты
+// дождутся следующего прохода Offer.
+func (o *Offerer) OfferEvent(ctx context.Context, eventID int64) int64 {
+	offers, err := o.storage.OfferEventTickets(ctx, eventID, o.now(), o.window)
+	if err != nil {
+		o.log.Error("failed to offer tickets", sl.Err(err), slog.Int64("event_id", eventID))
+		return 0
+	}
+
+	for _, offer := range offers {
+		if err := o.notifier.Notify(ctx, newNotification(offer)); err != nil {
+			o.log.Error("failed to notify", sl.Err(err),
+				slog.Int64("user_id", offer.UserID),
+				slog.Int64("booking_id", offer.BookingID),
+			)
+		}
+	}
+
+	return int64(len(offers))
+}
+
+// newNotification уведомление о билетах, предложенных из листа ожидания
+func newNotification(offer models.WaitlistOffer) models.Notification {
+	return models.Notification{
+		UserID:  offer.UserID,
+		Kind:    models.NotificationWaitlistOffer,
+		EventID: offer.EventID,
+		Subject: fmt.Sprintf("Появились билеты на «%s»", offer.EventTitle),
+		Text: fmt.Sprintf("Для вас удержано билетов: %d. Оплатите бронь %d до %s, иначе билеты получит следующий в очереди.",
+			offer.Quantity, offer.BookingID, offer.ExpiresAt.Format("02.01.2006 15:04")),
+	}
+}
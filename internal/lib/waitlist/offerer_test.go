@@ -0,0 +1,102 @@
+package waitlist
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"API/internal/lib/logger/handlers/slogdiscard"
+	"API/internal/models"
+)
+
+// storageStub отдает заданные предложения по мероприятиям и запоминает окно
+type storageStub struct {
+	events  []int64
+	offers  map[int64][]models.WaitlistOffer
+	errs    map[int64]error
+	offered []int64
+	now     time.Time
+	window  time.Duration
+}
+
+func (s *storageStub) WaitlistEvents(_ context.Context, now time.Time) ([]int64, error) {
+	s.now = now
+	return s.events, nil
+}
+
+func (s *storageStub) OfferEventTickets(_ context.Context, eventID int64, now time.Time, window time.Duration) ([]models.WaitlistOffer, error) {
+	s.offered = append(s.offered, eventID)
+	s.now, s.window = now, window
+	return s.offers[eventID], s.errs[eventID]
+}
+
+// notifierStub запоминает уведомления
+type notifierStub struct {
+	sent []models.Notification
+}
+
+func (n *notifierStub) Notify(_ context.Context, notification models.Notification) error {
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func TestOffer(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	storage := &storageStub{
+		events: []int64{1},
+		offers: map[int64][]models.WaitlistOffer{1: {
+			{UserID: 10, EventID: 1, EventTitle: "Джаз", BookingID: 100, Quantity: 2, ExpiresAt: now.Add(30 * time.Minute)},
+			{UserID: 11, EventID: 1, EventTitle: "Джаз", BookingID: 101, Quantity: 1, ExpiresAt: now.Add(30 * time.Minute)},
+		}},
+	}
+	notifier := &notifierStub{}
+
+	o := NewOfferer(slogdiscard.NewDiscardLogger(), storage, notifier, 30*time.Minute)
+	o.now = func() time.Time { return now }
+
+	n, err := o.Offer(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+	require.Equal(t, now, storage.now)
+	require.Equal(t, 30*time.Minute, storage.window)
+	require.Len(t, notifier.sent, 2)
+	require.Equal(t, int64(10), notifier.sent[0].UserID)
+	require.Equal(t, models.NotificationWaitlistOffer, notifier.sent[0].Kind)
+	require.Contains(t, notifier.sent[0].Text, "бронь 100")
+	require.Contains(t, notifier.sent[0].Text, "01.03.2026 12:30")
+}
+
+func TestOfferContinuesAfterEventError(t *testing.T) {
+	storage := &storageStub{
+		events: []int64{1, 2},
+		offers: map[int64][]models.WaitlistOffer{2: {{UserID: 10, EventID: 2, BookingID: 100, Quantity: 1}}},
+		errs:   map[int64]error{1: errors.New("connection reset")},
+	}
+	notifier := &notifierStub{}
+
+	n, err := NewOfferer(slogdiscard.NewDiscardLogger(), storage, notifier, time.Minute).Offer(context.Background())
+
+	// Ошибка первого мероприятия только пишется в лог
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2}, storage.offered)
+	require.Equal(t, int64(1), n)
+	require.Len(t, notifier.sent, 1)
+	require.Equal(t, int64(2), notifier.sent[0].EventID)
+}
+
+func TestOfferEvent(t *testing.T) {
+	storage := &storageStub{
+		offers: map[int64][]models.WaitlistOffer{5: {{UserID: 10, EventID: 5, BookingID: 100, Quantity: 3}}},
+	}
+	notifier := &notifierStub{}
+
+	n := NewOfferer(slogdiscard.NewDiscardLogger(), storage, notifier, time.Minute).OfferEvent(context.Background(), 5)
+
+	require.Equal(t, int64(1), n)
+	require.Equal(t, []int64{5}, storage.offered)
+	require.Len(t, notifier.sent, 1)
+}
@@ -6,9 +6,13 @@ import "time"
 type BookingStatus string
 
 const (
+	// BookingStatusPending билеты удержаны до ExpiresAt и еще не оплачены
+	BookingStatusPending   BookingStatus = "pending"
 	BookingStatusConfirmed BookingStatus = "confirmed"
 	BookingStatusCancelled BookingStatus = "cancelled"
 	BookingStatusUsed      BookingStatus = "used"
+	// BookingStatusExpired удержание не подтверждено вовремя, билеты вернулись в продажу
+	BookingStatusExpired BookingStatus = "expired"
 )
 
 // Booking представляет бронирование билета
@@ -21,6 +25,12 @@ type Booking struct {
 	Status      BookingStatus `json:"status"`
 	BookingCode string        `json:"booking_code"`
 	CreatedAt   time.Time     `json:"created_at"`
+	// ExpiresAt до какого момента нужно подтвердить удержание
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Seats места брони на мероприятии со схемой зала
+	Seats []Seat `json:"seats,omitempty"`
+
+	EventCategory string `json:"-"` // заполняется при создании, нужно для метрик
 }
 
 // BookingResponse - DTO для ответа с данными мероприятия
@@ -35,6 +45,8 @@ type BookingResponse struct {
 	Status      BookingStatus `json:"status"`
 	BookingCode string        `json:"booking_code"`
 	CreatedAt   time.Time     `json:"created_at"`
+	ExpiresAt   *time.Time    `json:"expires_at,omitempty"`
+	Seats       []Seat        `json:"seats,omitempty"`
 }
 
 // BookingWithEvent - бронирование с информацией о мероприятии
@@ -58,5 +70,7 @@ func (b *BookingWithEvent) ToResponse() BookingResponse {
 		Status:      b.Status,
 		BookingCode: b.BookingCode,
 		CreatedAt:   b.CreatedAt,
+		ExpiresAt:   b.ExpiresAt,
+		Seats:       b.Seats,
 	}
 }
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Классы клиентов по User-Agent
+const (
+	UserAgentDesktop = "desktop"
+	UserAgentMobile  = "mobile"
+	UserAgentTablet  = "tablet"
+	UserAgentBot     = "bot"
+	UserAgentUnknown = "unknown"
+)
+
+// Интервалы агрегации статистики
+const (
+	StatsBucketHour = "hour"
+	StatsBucketDay  = "day"
+)
+
+// Click переход по короткой ссылке
+type Click struct {
+	Alias     string
+	ClickedAt time.Time
+	Referrer  string // хост источника или "direct"
+	UserAgent string // класс клиента, а не исходная строка User-Agent
+	Region    string // код страны от edge-прокси или "unknown"
+}
+
+// ClickCount количество переходов в разрезе одного значения
+type ClickCount struct {
+	Key    string `json:"key" example:"t.me"`
+	Clicks int64  `json:"clicks" example:"42"`
+}
+
+// ClickPoint количество переходов за интервал
+type ClickPoint struct {
+	Time   time.Time `json:"time"`
+	Clicks int64     `json:"clicks" example:"7"`
+}
+
+// URLStats статистика переходов по ссылке за период
+type URLStats struct {
+	Alias      string       `json:"alias" example:"promo24"`
+	From       time.Time    `json:"from"`
+	To         time.Time    `json:"to"`
+	Bucket     string       `json:"bucket" example:"day"`
+	Total      int64        `json:"total" example:"128"`
+	Referrers  []ClickCount `json:"referrers"`
+	UserAgents []ClickCount `json:"user_agents"`
+	Regions    []ClickCount `json:"regions"`
+	Series     []ClickPoint `json:"series"`
+}
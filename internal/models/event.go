@@ -2,6 +2,81 @@ package models
 
 import "time"
 
+// Языки мероприятий. Значения совпадают с конфигурациями текстового
+// поиска PostgreSQL, по которым строится поисковый индекс.
+const (
+	LanguageRussian = "russian"
+	LanguageEnglish = "english"
+	// LanguageSimple без стемминга: для текстов на других языках
+	LanguageSimple = "simple"
+)
+
+// EventCategories категории мероприятий и их названия
+var EventCategories = []struct {
+	Code  string
+	Label string
+}{
+	{"concert", "Концерты"},
+	{"sport", "Спортивные мероприятия"},
+	{"theater", "Театр"},
+	{"exhibition", "Выставки"},
+	{"festival", "Фестивали"},
+	{"other", "Другое"},
+}
+
+// IsEventCategory является ли code кодом категории
+func IsEventCategory(code string) bool {
+	for _, c := range EventCategories {
+		if c.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// EventCategoryCodes коды категорий в порядке EventCategories
+func EventCategoryCodes() []string {
+	codes := make([]string, 0, len(EventCategories))
+	for _, c := range EventCategories {
+		codes = append(codes, c.Code)
+	}
+	return codes
+}
+
+// EventFilter фильтры списка и поиска мероприятий
+type EventFilter struct {
+	// Categories любая из категорий; пустой — все
+	Categories []string `json:"categories,omitempty"`
+	CreatorID  *int64   `json:"creator_id,omitempty"`
+	VenueID    *int64   `json:"venue_id,omitempty"`
+	// OnlyAvailable только мероприятия, на которые остались билеты
+	OnlyAvailable bool `json:"only_available,omitempty"`
+	// Upcoming только еще не начавшиеся мероприятия; без него
+	// в выдачу попадают и идущие сейчас
+	Upcoming bool `json:"upcoming"`
+	// IncludePast снимает ограничение по времени: в выдаче
+	// и завершившиеся мероприятия
+	IncludePast bool `json:"include_past,omitempty"`
+}
+
+// DefaultEventFilter фильтр публичного списка: только предстоящие мероприятия
+func DefaultEventFilter() EventFilter {
+	return EventFilter{Upcoming: true}
+}
+
+// EventLanguages допустимые языки мероприятий
+var EventLanguages = []string{LanguageRussian, LanguageEnglish, LanguageSimple}
+
+// IsEventLanguage является ли lang допустимым языком мероприятия
+func IsEventLanguage(lang string) bool {
+	for _, l := range EventLanguages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
 // Event представляет мероприятие
 type Event struct {
 	ID               int64     `json:"id"`
@@ -9,16 +84,24 @@ type Event struct {
 	Description      string    `json:"description"`
 	Category         string    `json:"category"` // категория (концерт, спорт, театр)
 	ImageURL         *string   `json:"image_url,omitempty"`
-	Venue            string    `json:"venue"`             // место проведения
-	Address          string    `json:"address"`           // адрес
-	Price            float64   `json:"price"`             // цена билета
-	Capacity         int       `json:"capacity"`          // вместимость
-	AvailableTickets int       `json:"available_tickets"` // доступные билеты
+	VenueID          *int64    `json:"venue_id,omitempty"` // площадка из справочника
+	Venue            string    `json:"venue"`              // место проведения
+	Address          string    `json:"address"`            // адрес
+	Price            float64   `json:"price"`              // цена билета
+	Capacity         int       `json:"capacity"`           // вместимость
+	AvailableTickets int       `json:"available_tickets"`  // доступные билеты
 	StartTime        time.Time `json:"start_time"`
 	EndTime          time.Time `json:"end_time"`
 	CreatorID        int64     `json:"creator_id"`
 	CreatedAt        time.Time `json:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at"`
+	Language         string    `json:"language"`            // язык текста для поиска
+	Latitude         *float64  `json:"latitude,omitempty"`  // координаты места проведения
+	Longitude        *float64  `json:"longitude,omitempty"` // задаются вместе или не задаются
+	// SeatMapID схема зала для продажи мест; с ней вместимость равна числу
+	// мест, а цена билета — минимальной из SeatPrices
+	SeatMapID  *int64             `json:"seat_map_id,omitempty"`
+	SeatPrices map[string]float64 `json:"seat_prices,omitempty"` // цена по категории места
 }
 
 // EventResponse - DTO для ответа
@@ -28,6 +111,7 @@ type EventResponse struct {
 	Description      string    `json:"description"`
 	Category         string    `json:"category"`
 	ImageURL         *string   `json:"image_url,omitempty"`
+	VenueID          *int64    `json:"venue_id,omitempty" example:"1"`
 	Venue            string    `json:"venue"`
 	Address          string    `json:"address"`
 	Price            float64   `json:"price"`
@@ -37,6 +121,12 @@ type EventResponse struct {
 	EndTime          time.Time `json:"end_time"`
 	CreatorID        int64     `json:"creator_id"`
 	CreatedAt        time.Time `json:"created_at"`
+	Language         string    `json:"language" example:"russian"`
+	Latitude         *float64  `json:"latitude,omitempty" example:"55.7814"`
+	Longitude        *float64  `json:"longitude,omitempty" example:"37.6258"`
+	// SeatMapID места продаются по схеме зала, см. GET /events/{id}/seats
+	SeatMapID  *int64             `json:"seat_map_id,omitempty" example:"1"`
+	SeatPrices map[string]float64 `json:"seat_prices,omitempty"`
 }
 
 // ToResponse конвертирует Event в EventResponse
@@ -47,6 +137,7 @@ func (e *Event) ToResponse() EventResponse {
 		Description:      e.Description,
 		Category:         e.Category,
 		ImageURL:         e.ImageURL,
+		VenueID:          e.VenueID,
 		Venue:            e.Venue,
 		Address:          e.Address,
 		Price:            e.Price,
@@ -56,5 +147,10 @@ func (e *Event) ToResponse() EventResponse {
 		EndTime:          e.EndTime,
 		CreatorID:        e.CreatorID,
 		CreatedAt:        e.CreatedAt,
+		Language:         e.Language,
+		Latitude:         e.Latitude,
+		Longitude:        e.Longitude,
+		SeatMapID:        e.SeatMapID,
+		SeatPrices:       e.SeatPrices,
 	}
 }
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// OrderNewest порядок списков бронирований и ссылок: новые первыми
+const OrderNewest = "newest"
+
+// Page параметры страницы списка. After включает keyset пагинацию и имеет
+// приоритет над Offset, который оставлен для старых клиентов.
+// Нулевой Limit означает список целиком, без страниц.
+type Page struct {
+	Limit  int
+	Offset int
+	After  *Cursor
+}
+
+// Cursor позиция в упорядоченном списке: ключ сортировки последнего
+// элемента страницы и его id. Клиенту отдается закодированным.
+type Cursor struct {
+	// Order сортировка, для которой выдан курсор
+	Order string `json:"o"`
+	// Key числовой ключ сортировки (цена, популярность, релевантность), если он первый
+	Key  *float64  `json:"k,omitempty"`
+	Time time.Time `json:"t"`
+	ID   int64     `json:"i"`
+}
+
+// All true, если запрошен список целиком
+func (p Page) All() bool {
+	return p.Limit == 0
+}
+
+// Fetch сколько строк запрашивать: на одну больше лимита,
+// чтобы узнать, есть ли следующая страница
+func (p Page) Fetch() int {
+	return p.Limit + 1
+}
+
+// SkipRows смещение для запроса; с курсором не используется
+func (p Page) SkipRows() int {
+	if p.After != nil {
+		return 0
+	}
+	return p.Offset
+}
+
+// NextPage отрезает строку, запрошенную сверх лимита, и возвращает
+// курсор по последнему элементу страницы или nil, если страница последняя
+func NextPage[T any](items []T, p Page, cursor func(T) Cursor) ([]T, *Cursor) {
+	if p.All() || len(items) <= p.Limit {
+		return items, nil
+	}
+
+	items = items[:p.Limit]
+	next := cursor(items[len(items)-1])
+
+	return items, &next
+}
+
+// NewestCursor курсор списка с порядком OrderNewest
+func NewestCursor(createdAt time.Time, id int64) Cursor {
+	return Cursor{Order: OrderNewest, Time: createdAt, ID: id}
+}
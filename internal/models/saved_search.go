@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// SavedSearch сохраненный поиск пользователя. О новых мероприятиях,
+// подходящих под него, пользователь получает уведомления.
+type SavedSearch struct {
+	ID     int64  `json:"id" example:"1"`
+	UserID int64  `json:"-"`
+	Name   string `json:"name" example:"Концерты до 3000"`
+	// Query параметры в формате строки запроса GET /search
+	Query string `json:"query" example:"category=concert&price_max=3000"`
+	// Params разобранный Query, по нему подбираются мероприятия
+	Params    EventSearch `json:"-"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// Виды уведомлений
+const (
+	NotificationSavedSearch   = "saved_search_match"
+	NotificationWaitlistOffer = "waitlist_offer"
+)
+
+// Notification уведомление пользователю о мероприятии
+type Notification struct {
+	UserID  int64
+	Kind    string
+	EventID int64
+	// Subject и Text готовый текст для канала доставки
+	Subject string
+	Text    string
+}
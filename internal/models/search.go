@@ -0,0 +1,196 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Сортировки поиска мероприятий
+const (
+	SortDate       = "date"       // ближайшие первыми
+	SortPriceAsc   = "price_asc"  // сначала дешевые
+	SortPriceDesc  = "price_desc" // сначала дорогие
+	SortRelevance  = "relevance"  // по ts_rank и сходству, только с поисковым запросом
+	SortPopularity = "popularity" // по числу проданных билетов
+	SortDistance   = "distance"   // сначала ближайшие, только с точкой поиска
+)
+
+// SearchSorts допустимые значения параметра sort
+var SearchSorts = []string{SortDate, SortPriceAsc, SortPriceDesc, SortRelevance, SortPopularity, SortDistance}
+
+// GeoPoint точка на карте
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// EventSearch параметры поиска мероприятий. Без страницы они же
+// хранятся в сохраненных поисках, отсюда json теги.
+type EventSearch struct {
+	Query string `json:"query,omitempty"`
+	EventFilter
+	DateFrom *time.Time `json:"date_from,omitempty"`
+	DateTo   *time.Time `json:"date_to,omitempty"`
+	PriceMin *float64   `json:"price_min,omitempty"`
+	PriceMax *float64   `json:"price_max,omitempty"`
+	// Near точка поиска: с ней у найденных мероприятий считается расстояние,
+	// а мероприятия без координат не попадают в выдачу
+	Near *GeoPoint `json:"near,omitempty"`
+	// RadiusKm максимальное расстояние от Near
+	RadiusKm *float64 `json:"radius_km,omitempty"`
+	Sort     string   `json:"sort,omitempty"`
+	Page     `json:"-"`
+}
+
+// EventSearchResult страница результатов поиска с фасетами
+// по всему набору, подходящему под фильтры
+type EventSearchResult struct {
+	Events []*Event
+	Total  int
+	Facets SearchFacets
+	// Next курсор следующей страницы, nil на последней
+	Next *Cursor
+	// Highlights совпавшие с запросом фрагменты по id мероприятия;
+	// пустой без поискового запроса
+	Highlights map[int64]Highlight
+	// Distances расстояние в километрах от точки поиска по id мероприятия
+	Distances map[int64]float64
+}
+
+// Маркеры совпадений в Highlight. Символы из области для частного
+// использования не встречаются в тексте, поэтому текст можно безопасно
+// экранировать, а маркеры затем заменить разметкой.
+const (
+	HighlightStart = "\ue000"
+	HighlightStop  = "\ue001"
+)
+
+// Highlight текст мероприятия с отмеченными совпадениями: название целиком
+// и до двух фрагментов описания. Совпадения только по сходству (опечатки)
+// не отмечаются.
+type Highlight struct {
+	Title       string
+	Description string
+}
+
+// SearchFacets число мероприятий по значениям фильтров
+type SearchFacets struct {
+	Categories []CategoryFacet `json:"categories"`
+	Prices     []PriceFacet    `json:"prices"`
+	Dates      []DateFacet     `json:"dates"`
+}
+
+// CategoryFacet число мероприятий категории
+type CategoryFacet struct {
+	Category string `json:"category" example:"concert"`
+	Count    int    `json:"count" example:"12"`
+}
+
+// PriceFacet число мероприятий в ценовом диапазоне [min, max)
+type PriceFacet struct {
+	Key   string   `json:"key" example:"1000-3000"`
+	Min   *float64 `json:"min,omitempty" example:"1000"`
+	Max   *float64 `json:"max,omitempty" example:"3000"`
+	Count int      `json:"count" example:"5"`
+}
+
+// DateFacet число мероприятий, начинающихся в промежутке [from, to)
+type DateFacet struct {
+	Key   string     `json:"key" example:"today"`
+	From  *time.Time `json:"from,omitempty"`
+	To    *time.Time `json:"to,omitempty"`
+	Count int        `json:"count" example:"3"`
+}
+
+// PriceBounds границы ценовых фасетов: бесплатные, до 1000, 1000-3000,
+// 3000-5000, 5000-10000 и дороже 10000
+var PriceBounds = []float64{0.01, 1000, 3000, 5000, 10000}
+
+// PriceFacets возвращает пустые ценовые фасеты в порядке границ
+func PriceFacets() []PriceFacet {
+	keys := []string{"free", "0-1000", "1000-3000", "3000-5000", "5000-10000", "10000+"}
+
+	facets := make([]PriceFacet, len(keys))
+	for i, key := range keys {
+		facets[i].Key = key
+		if i > 0 {
+			facets[i].Min = &PriceBounds[i-1]
+		}
+		if i < len(PriceBounds) {
+			facets[i].Max = &PriceBounds[i]
+		}
+	}
+
+	return facets
+}
+
+// DateFacets возвращает пустые фасеты по дате начала относительно now
+// (в UTC) и их границы: прошедшие, сегодня, завтра, неделя, месяц, позже.
+func DateFacets(now time.Time) ([]DateFacet, []time.Time) {
+	today := now.UTC().Truncate(24 * time.Hour)
+	bounds := []time.Time{
+		today,
+		today.AddDate(0, 0, 1),
+		today.AddDate(0, 0, 2),
+		today.AddDate(0, 0, 7),
+		today.AddDate(0, 0, 30),
+	}
+	keys := []string{"past", "today", "tomorrow", "week", "month", "later"}
+
+	facets := make([]DateFacet, len(keys))
+	for i, key := range keys {
+		facets[i].Key = key
+		if i > 0 {
+			facets[i].From = &bounds[i-1]
+		}
+		if i < len(bounds) {
+			facets[i].To = &bounds[i]
+		}
+	}
+
+	return facets, bounds
+}
+
+// Suggestions подсказки для строки поиска по началу слов запроса,
+// популярные (по проданным билетам) первыми
+type Suggestions struct {
+	Titles     []Suggestion `json:"titles"`
+	Venues     []Suggestion `json:"venues"`
+	Categories []Suggestion `json:"categories"`
+}
+
+// Suggestion вариант запроса и число предстоящих мероприятий с ним
+type Suggestion struct {
+	Text string `json:"text" example:"Вечер джаза"`
+	// Label название категории, только для категорий
+	Label  string `json:"label,omitempty" example:"Концерты"`
+	Events int    `json:"events" example:"2"`
+}
+
+// MatchCategories возвращает коды категорий, код или название
+// которых начинается с prefix без учета регистра
+func MatchCategories(prefix string) []string {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+
+	codes := []string{}
+	if prefix == "" {
+		return codes
+	}
+	for _, c := range EventCategories {
+		if strings.HasPrefix(c.Code, prefix) || strings.HasPrefix(strings.ToLower(c.Label), prefix) {
+			codes = append(codes, c.Code)
+		}
+	}
+
+	return codes
+}
+
+// CategoryLabel название категории по коду
+func CategoryLabel(code string) string {
+	for _, c := range EventCategories {
+		if c.Code == code {
+			return c.Label
+		}
+	}
+	return ""
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// SeatMap схема зала площадки: секции, ряды и места с ценовыми
+// категориями. После создания не меняется, поэтому мероприятия
+// могут на нее ссылаться.
+type SeatMap struct {
+	ID        int64     `json:"id" example:"1"`
+	VenueID   int64     `json:"venue_id" example:"1"`
+	Name      string    `json:"name" example:"Партер и балкон"`
+	CreatorID *int64    `json:"creator_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// SeatCount число мест, становится вместимостью мероприятия
+	SeatCount int `json:"seat_count" example:"350"`
+	// PriceCategories категории мест; мероприятие задает цену каждой
+	PriceCategories []string `json:"price_categories" example:"A,B"`
+
+	Seats []Seat `json:"-"` // заполняется при создании
+}
+
+// Seat место в схеме зала
+type Seat struct {
+	ID            int64  `json:"id" example:"101"`
+	Section       string `json:"section" example:"Партер"`
+	Row           string `json:"row" example:"5"`
+	Number        int    `json:"number" example:"12"`
+	PriceCategory string `json:"price_category" example:"A"`
+}
+
+// EventSeat место на конкретном мероприятии
+type EventSeat struct {
+	Seat
+	Price     float64 `json:"price" example:"5000"`
+	Available bool    `json:"available"`
+}
+
+// EventSeats места мероприятия по схеме зала
+type EventSeats struct {
+	EventID   int64       `json:"event_id"`
+	SeatMapID int64       `json:"seat_map_id"`
+	Available int         `json:"available"`
+	Seats     []EventSeat `json:"seats"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// TransferStatus статусы передачи брони
+type TransferStatus string
+
+const (
+	// TransferPending ждет ответа получателя
+	TransferPending TransferStatus = "pending"
+	// TransferAccepted получатель принял передачу, билеты перешли к нему
+	TransferAccepted TransferStatus = "accepted"
+	// TransferDeclined получатель отказался
+	TransferDeclined TransferStatus = "declined"
+	// TransferCancelled отправитель отозвал передачу или отменил бронь
+	TransferCancelled TransferStatus = "cancelled"
+)
+
+// BookingTransfer передача брони или части ее билетов другому пользователю.
+// Принятые передачи — история смены владельцев билетов.
+type BookingTransfer struct {
+	ID        int64  `json:"id" example:"1"`
+	BookingID int64  `json:"booking_id" example:"10"`
+	EventID   int64  `json:"event_id" example:"1"`
+	FromEmail string `json:"from_email" example:"anna@example.com"`
+	ToEmail   string `json:"to_email" example:"boris@example.com"`
+	Quantity  int    `json:"quantity" example:"1"`
+	// SeatIDs передаваемые места брони на мероприятии со схемой зала
+	SeatIDs []int64        `json:"seat_ids,omitempty"`
+	Status  TransferStatus `json:"status" example:"pending"`
+	// NewBookingID бронь получателя после принятия; при передаче всей
+	// брони совпадает с BookingID
+	NewBookingID *int64     `json:"new_booking_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ResolvedAt   *time.Time `json:"resolved_at,omitempty"`
+
+	FromUserID int64 `json:"-"`
+	ToUserID   int64 `json:"-"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// URL представляет короткую ссылку
+type URL struct {
+	ID         int64      `json:"id"`
+	Alias      string     `json:"alias"`
+	URL        string     `json:"url"`
+	UserID     *int64     `json:"user_id,omitempty"` // владелец, у старых ссылок может отсутствовать
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	MaxClicks  *int       `json:"max_clicks,omitempty"`
+	ClickCount int        `json:"click_count"` // учитывается только у ссылок с MaxClicks
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Expired сообщает, что ссылка больше не открывается
+func (u *URL) Expired(now time.Time) bool {
+	if u.ExpiresAt != nil && !now.Before(*u.ExpiresAt) {
+		return true
+	}
+	return u.MaxClicks != nil && u.ClickCount >= *u.MaxClicks
+}
+
+// URLResponse - DTO для ответа
+type URLResponse struct {
+	Alias      string     `json:"alias" example:"promo24"`
+	URL        string     `json:"url" example:"https://example.com/events/42"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	MaxClicks  *int       `json:"max_clicks,omitempty" example:"100"`
+	ClickCount int        `json:"click_count" example:"12"`
+	Expired    bool       `json:"expired"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ToResponse конвертирует URL в URLResponse
+func (u *URL) ToResponse() URLResponse {
+	return URLResponse{
+		Alias:      u.Alias,
+		URL:        u.URL,
+		ExpiresAt:  u.ExpiresAt,
+		MaxClicks:  u.MaxClicks,
+		ClickCount: u.ClickCount,
+		Expired:    u.Expired(time.Now()),
+		CreatedAt:  u.CreatedAt,
+	}
+}
+
+// NewURL ссылка для пакетного сохранения
+type NewURL struct {
+	URL       string
+	Alias     string
+	ExpiresAt *time.Time
+	MaxClicks *int
+}
+
+// SavedURL результат сохранения одной ссылки из пакета.
+// Err равен storage.ErrURLExists, если алиас занят.
+type SavedURL struct {
+	ID  int64
+	Err error
+}
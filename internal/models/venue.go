@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Venue место проведения мероприятий. Мероприятия ссылаются на него,
+// чтобы одна площадка не записывалась по-разному в каждом мероприятии.
+type Venue struct {
+	ID      int64  `json:"id" example:"1"`
+	Name    string `json:"name" example:"Олимпийский"`
+	Address string `json:"address" example:"Олимпийский проспект, 16"`
+	// Capacity вместимость по умолчанию для мероприятий на площадке
+	Capacity  int       `json:"capacity" example:"35000"`
+	Latitude  *float64  `json:"latitude,omitempty" example:"55.7814"`
+	Longitude *float64  `json:"longitude,omitempty" example:"37.6258"`
+	CreatorID *int64    `json:"creator_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// WaitlistStatus статусы записи в листе ожидания
+type WaitlistStatus string
+
+const (
+	WaitlistWaiting WaitlistStatus = "waiting"
+	// WaitlistOffered билеты удержаны под пользователя до OfferExpiresAt
+	WaitlistOffered WaitlistStatus = "offered"
+)
+
+// WaitlistEntry запись пользователя в листе ожидания мероприятия
+type WaitlistEntry struct {
+	ID       int64          `json:"id" example:"1"`
+	EventID  int64          `json:"event_id" example:"1"`
+	UserID   int64          `json:"-"`
+	Quantity int            `json:"quantity" example:"2"`
+	Status   WaitlistStatus `json:"status" example:"waiting"`
+	// Position место среди ожидающих, 1 — следующий. Вернувшиеся билеты
+	// получает первый, кому их хватает, поэтому меньший запрос может
+	// пройти раньше.
+	Position int `json:"position,omitempty" example:"3"`
+	// BookingID удержание с предложенными билетами, оплачивается через
+	// POST /bookings/{id}/confirm до OfferExpiresAt
+	BookingID      *int64     `json:"booking_id,omitempty"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// WaitlistOffer билеты, предложенные пользователю из листа ожидания
+type WaitlistOffer struct {
+	UserID     int64
+	EventID    int64
+	EventTitle string
+	BookingID  int64
+	Quantity   int
+	ExpiresAt  time.Time
+}